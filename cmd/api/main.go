@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"fund-o/api-server/cmd/api/server"
 	"fund-o/api-server/config"
 	"fund-o/api-server/internal/datasource"
 	"fund-o/api-server/pkg/logger"
+	"fund-o/api-server/pkg/tracing"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
+	"time"
 )
 
 func init() {
@@ -19,6 +22,17 @@ func main() {
 		log.Fatal().Err(err).Msg("Failed to load app config")
 	}
 
+	shutdownTracing, err := tracing.Init(context.Background(), &tracing.Config{
+		ServiceName:  appConfig.TracingServiceName,
+		Exporter:     appConfig.TracingExporter,
+		OtlpEndpoint: appConfig.TracingOtlpEndpoint,
+		OtlpInsecure: appConfig.TracingOtlpInsecure,
+		SampleRatio:  appConfig.TracingSampleRatio,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize tracing")
+	}
+
 	datasources := datasource.NewDatasourceContext(&appConfig.DatasourceConfig)
 
 	gin.SetMode(appConfig.GinMode)
@@ -26,4 +40,11 @@ func main() {
 	if err := s.Start(); err != nil {
 		log.Fatal().Err(err).Msg("Failed to start API server")
 	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := shutdownTracing(ctx); err != nil {
+		log.Error().Err(err).Msg("Failed to flush traces")
+	}
 }
@@ -7,8 +7,13 @@ import (
 	"fund-o/api-server/cmd/worker"
 	"fund-o/api-server/cmd/ws"
 	"fund-o/api-server/config"
+	"fund-o/api-server/pkg/chain"
+	"fund-o/api-server/pkg/health"
 	"fund-o/api-server/pkg/mail"
+	"fund-o/api-server/pkg/metrics"
+	"fund-o/api-server/pkg/oauth"
 	"fund-o/api-server/pkg/uploader"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 	"github.com/ulule/limiter/v3"
 	"net/http"
@@ -21,6 +26,7 @@ import (
 
 	"fund-o/api-server/internal/datasource"
 	"fund-o/api-server/internal/datasource/repository"
+	"fund-o/api-server/internal/entity"
 	"fund-o/api-server/internal/http/handler"
 	"fund-o/api-server/internal/http/middleware"
 	"fund-o/api-server/internal/usecase"
@@ -28,6 +34,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 	cors "github.com/rs/cors/wrapper/gin"
 	mgin "github.com/ulule/limiter/v3/drivers/middleware/gin"
@@ -44,14 +51,26 @@ type ApiServer interface {
 	HttpServer() *http.Server
 }
 
+// Timeouts of the readiness checks. S3 is reached over the internet, so it gets more time than
+// the dependencies on the local network.
+const (
+	sqlCheckTimeout   = 2 * time.Second
+	redisCheckTimeout = time.Second
+	asynqCheckTimeout = time.Second
+	s3CheckTimeout    = 3 * time.Second
+)
+
 type apiServer struct {
-	httpServer *http.Server
-	config     *config.ApiServerConfig
-	datasource datasource.Datasource
+	httpServer    *http.Server
+	metricsServer *http.Server
+	config        *config.ApiServerConfig
+	datasource    datasource.Datasource
+	checker       health.Checker
 }
 
 func NewApiServer(config *config.ApiServerConfig, datasource datasource.Datasource) ApiServer {
-	router := inject(config, datasource)
+	checker := health.NewChecker()
+	router := inject(config, datasource, checker)
 
 	server := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", config.Host, config.Port),
@@ -59,9 +78,27 @@ func NewApiServer(config *config.ApiServerConfig, datasource datasource.Datasour
 	}
 
 	return &apiServer{
-		httpServer: server,
-		config:     config,
-		datasource: datasource,
+		httpServer:    server,
+		metricsServer: newMetricsServer(config),
+		config:        config,
+		datasource:    datasource,
+		checker:       checker,
+	}
+}
+
+// newMetricsServer serves /metrics on its own listener, so the metrics are not reachable through
+// the public port and need no authentication. It returns nil when the metrics port is 0.
+func newMetricsServer(config *config.ApiServerConfig) *http.Server {
+	if config.MetricsPort == 0 {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+
+	return &http.Server{
+		Addr:    fmt.Sprintf("%s:%d", config.MetricsHost, config.MetricsPort),
+		Handler: mux,
 	}
 }
 
@@ -79,36 +116,58 @@ func (server *apiServer) Start() error {
 		}
 	}()
 
+	if server.metricsServer != nil {
+		go func() {
+			log.Info().Msgf("Metrics listening at http://%s/metrics", server.metricsServer.Addr)
+			if err := server.metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatal().Err(err).Msg("Failed to serve metrics")
+			}
+		}()
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
 	<-quit
+	server.checker.Drain()
 	log.Info().Msg("Shutting down server...")
 
-	log.Info().Msg("Unregistering datasource...")
-	if err := server.datasource.Close(); err != nil {
-		return fmt.Errorf("error when close datasources: %v", err)
+	if server.config.ShutdownDrainDelay > 0 {
+		log.Info().Msgf("Waiting %s for load balancers to stop sending traffic...", server.config.ShutdownDrainDelay)
+		time.Sleep(server.config.ShutdownDrainDelay)
 	}
-	log.Info().Msg("Unregistering datasource completed")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancel()
 
+	// In-flight requests still use the datasource, so it is closed once the servers have stopped.
 	if err := server.httpServer.Shutdown(ctx); err != nil {
 		return fmt.Errorf("error when shutdown server: %v", err)
 	}
 
+	if server.metricsServer != nil {
+		if err := server.metricsServer.Shutdown(ctx); err != nil {
+			return fmt.Errorf("error when shutdown metrics server: %v", err)
+		}
+	}
+
+	log.Info().Msg("Unregistering datasource...")
+	if err := server.datasource.Close(); err != nil {
+		return fmt.Errorf("error when close datasources: %v", err)
+	}
+	log.Info().Msg("Unregistering datasource completed")
+
 	<-ctx.Done()
 	log.Info().Msg("Timeout of 1 second")
 	log.Info().Msg("Shutting down server completed")
 	return nil
 }
 
-func inject(config *config.ApiServerConfig, datasource datasource.Datasource) *gin.Engine {
+func inject(config *config.ApiServerConfig, datasource datasource.Datasource, checker health.Checker) *gin.Engine {
 	// Makers
-	jwtMaker, err := token.NewJWTMaker(config.JwtSecretKey)
+	keySet, jwtMaker, err := newTokenMaker(config)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to create JWT maker")
+		log.Fatal().Err(err).Msg("Failed to create token maker")
 	}
 
 	imageUploader, err := uploader.NewS3Store(&uploader.S3StoreConfig{
@@ -121,15 +180,40 @@ func inject(config *config.ApiServerConfig, datasource datasource.Datasource) *g
 		log.Fatal().Err(err).Msg("Failed to create image uploader")
 	}
 
+	var chainClient chain.Client
+	if config.ChainRpcUrl != "" {
+		chainClient = chain.NewRPCClient(config.ChainRpcUrl)
+	} else {
+		log.Warn().Msg("CHAIN_RPC_URL is not set, contributions will stay pending")
+		chainClient = chain.NewMemoryClient()
+	}
+
+	// Redis
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: config.RedisAddress,
+	})
+	if err := redisotel.InstrumentTracing(redisClient); err != nil {
+		log.Fatal().Err(err).Msg("Failed to instrument redis client")
+	}
+
 	// Repositories
 	userRepository := repository.NewUserRepository(datasource.GetSqlDB())
 	sessionRepository := repository.NewSessionRepository(datasource.GetSqlDB())
 	projectRepository := repository.NewProjectRepository(datasource.GetSqlDB())
 	projectCategoryRepository := repository.NewProjectCategoryRepository(datasource.GetSqlDB())
+	projectUpdateRepository := repository.NewProjectUpdateRepository(datasource.GetSqlDB())
 	verifyEmailRepository := repository.NewVerifyEmailRepository(datasource.GetSqlDB())
+	passwordResetRepository := repository.NewPasswordResetRepository(datasource.GetSqlDB())
+	emailChangeRepository := repository.NewEmailChangeRepository(datasource.GetSqlDB())
+	dataExportRepository := repository.NewDataExportRepository(datasource.GetSqlDB())
+	mfaRepository := repository.NewMfaRepository(datasource.GetSqlDB())
 	forumRepository := repository.NewForumRepository(datasource.GetSqlDB())
 	channelRepository := repository.NewChannelRepository(datasource.GetSqlDB())
 	messageRepository := repository.NewMessageRepository(datasource.GetSqlDB())
+	chainEventRepository := repository.NewChainEventRepository(datasource.GetSqlDB())
+	apiKeyRepository := repository.NewApiKeyRepository(datasource.GetSqlDB())
+	nonceRepository := repository.NewNonceRepository(redisClient)
+	loginAttemptRepository := repository.NewLoginAttemptRepository(redisClient)
 
 	// UseCases
 	userUseCase := usecase.NewUserUseCase(&usecase.UserUseCaseOptions{
@@ -140,8 +224,11 @@ func inject(config *config.ApiServerConfig, datasource datasource.Datasource) *g
 		SessionRepository: sessionRepository,
 	})
 	projectUseCase := usecase.NewProjectUseCase(&usecase.ProjectUseCaseOptions{
-		ProjectRepository: projectRepository,
-		ImageUploader:     imageUploader,
+		ProjectRepository:       projectRepository,
+		ProjectUpdateRepository: projectUpdateRepository,
+		UserRepository:          userRepository,
+		ImageUploader:           imageUploader,
+		ChainClient:             chainClient,
 	})
 	projectCategoryUseCase := usecase.NewProjectCategoryUseCase(&usecase.ProjectCategoryUseCaseOptions{
 		ProjectCategoryRepository: projectCategoryRepository,
@@ -149,6 +236,23 @@ func inject(config *config.ApiServerConfig, datasource datasource.Datasource) *g
 	verifyEmailUseCase := usecase.NewVerifyEmailUseCase(&usecase.VerifyEmailUseCaseOptions{
 		VerifyEmailRepository: verifyEmailRepository,
 	})
+	passwordResetUseCase := usecase.NewPasswordResetUseCase(&usecase.PasswordResetUseCaseOptions{
+		PasswordResetRepository: passwordResetRepository,
+		UserRepository:          userRepository,
+		SessionRepository:       sessionRepository,
+		ResetURL:                config.PasswordResetUrl,
+	})
+	emailChangeUseCase := usecase.NewEmailChangeUseCase(&usecase.EmailChangeUseCaseOptions{
+		EmailChangeRepository: emailChangeRepository,
+		UserRepository:        userRepository,
+		ConfirmURL:            config.EmailChangeUrl,
+	})
+	accountUseCase := usecase.NewAccountUseCase(&usecase.AccountUseCaseOptions{
+		DataExportRepository: dataExportRepository,
+		UserRepository:       userRepository,
+		ProjectRepository:    projectRepository,
+		DownloadURL:          config.DataExportUrl,
+	})
 	forumUseCase := usecase.NewForumUseCase(&usecase.ForumUseCaseOptions{
 		ForumRepository: forumRepository,
 		ImageUploader:   imageUploader,
@@ -160,6 +264,48 @@ func inject(config *config.ApiServerConfig, datasource datasource.Datasource) *g
 		MessageRepository: messageRepository,
 		ImageUploader:     imageUploader,
 	})
+	walletAuthUseCase := usecase.NewWalletAuthUseCase(&usecase.WalletAuthUseCaseOptions{
+		UserRepository:  userRepository,
+		NonceRepository: nonceRepository,
+		Domain:          config.SiweDomain,
+		ChainID:         config.ChainID,
+	})
+	mfaUseCase := usecase.NewMfaUseCase(&usecase.MfaUseCaseOptions{
+		MfaRepository:   mfaRepository,
+		UserRepository:  userRepository,
+		NonceRepository: nonceRepository,
+	})
+	googleAuthUseCase := usecase.NewGoogleAuthUseCase(&usecase.GoogleAuthUseCaseOptions{
+		UserRepository:  userRepository,
+		NonceRepository: nonceRepository,
+		Provider: oauth.NewProvider(&oauth.Config{
+			ClientID:     config.GoogleClientId,
+			ClientSecret: config.GoogleClientSecret,
+			AuthURL:      config.GoogleAuthUrl,
+			TokenURL:     config.GoogleTokenUrl,
+			UserInfoURL:  config.GoogleUserInfoUrl,
+			RedirectURL:  config.GoogleRedirectUrl,
+			Scopes:       []string{"openid", "email", "profile"},
+		}),
+	})
+	apiKeyUseCase := usecase.NewApiKeyUseCase(&usecase.ApiKeyUseCaseOptions{
+		ApiKeyRepository: apiKeyRepository,
+	})
+	loginAttemptUseCase := usecase.NewLoginAttemptUseCase(&usecase.LoginAttemptUseCaseOptions{
+		LoginAttemptRepository: loginAttemptRepository,
+		MaxAttempts:            config.LoginMaxAttempts,
+		IPMaxAttempts:          config.LoginIpMaxAttempts,
+		LockoutDuration:        config.LoginLockoutDuration,
+	})
+	chainIndexerUseCase := usecase.NewChainIndexerUseCase(&usecase.ChainIndexerUseCaseOptions{
+		ChainEventRepository: chainEventRepository,
+		ProjectRepository:    projectRepository,
+		UserRepository:       userRepository,
+		ChainClient:          chainClient,
+		FactoryAddress:       config.ChainFactoryAddress,
+		StartBlock:           config.ChainStartBlock,
+		Confirmations:        config.ChainConfirmations,
+	})
 
 	// Task Processor
 	redisOptions := asynq.RedisClientOpt{
@@ -172,14 +318,27 @@ func inject(config *config.ApiServerConfig, datasource datasource.Datasource) *g
 		FromEmailPassword: config.EmailSenderPassword,
 	}
 	go runTaskProcessor(redisOptions, gmailOptions, &worker.TaskProcessorUseCaseOptions{
-		UserUseCase:        userUseCase,
-		VerifyEmailUseCase: verifyEmailUseCase,
-	})
-
-	// Redis
-	redisClient := redis.NewClient(&redis.Options{
-		Addr: config.RedisAddress,
+		UserUseCase:          userUseCase,
+		VerifyEmailUseCase:   verifyEmailUseCase,
+		PasswordResetUseCase: passwordResetUseCase,
+		EmailChangeUseCase:   emailChangeUseCase,
+		AccountUseCase:       accountUseCase,
+		ProjectUseCase:       projectUseCase,
+		ChainIndexerUseCase:  chainIndexerUseCase,
 	})
+	go runTaskScheduler(redisOptions)
+
+	// Readiness checks
+	taskInspector := asynq.NewInspector(redisOptions)
+	checker.Register(health.Check{Name: "postgres", Timeout: sqlCheckTimeout, Probe: datasource.Ping})
+	checker.Register(health.Check{Name: "redis", Timeout: redisCheckTimeout, Probe: func(ctx context.Context) error {
+		return redisClient.Ping(ctx).Err()
+	}})
+	checker.Register(health.Check{Name: "asynq", Timeout: asynqCheckTimeout, Probe: func(ctx context.Context) error {
+		_, err := taskInspector.Queues()
+		return err
+	}})
+	checker.Register(health.Check{Name: "s3", Timeout: s3CheckTimeout, Probe: imageUploader.Ping})
 
 	// Websocket
 	hub := ws.NewWebsocketHub(&ws.Config{
@@ -192,19 +351,34 @@ func inject(config *config.ApiServerConfig, datasource datasource.Datasource) *g
 
 	// Handlers
 	authHandler := handler.NewAuthHandler(&handler.AuthHandlerOptions{
+		UserUseCase:          userUseCase,
+		SessionUseCase:       sessionUseCase,
+		VerifyEmailUseCase:   verifyEmailUseCase,
+		WalletAuthUseCase:    walletAuthUseCase,
+		PasswordResetUseCase: passwordResetUseCase,
+		EmailChangeUseCase:   emailChangeUseCase,
+		LoginAttemptUseCase:  loginAttemptUseCase,
+		GoogleAuthUseCase:    googleAuthUseCase,
+		MfaUseCase:           mfaUseCase,
+		TokenMaker:           jwtMaker,
+		KeySet:               keySet,
+		TaskDistributor:      taskDistributor,
+	})
+	userHandler := handler.NewUserHandler(&handler.UserHandlerOptions{
 		UserUseCase:        userUseCase,
+		WalletAuthUseCase:  walletAuthUseCase,
 		SessionUseCase:     sessionUseCase,
-		VerifyEmailUseCase: verifyEmailUseCase,
-		TokenMaker:         jwtMaker,
+		MfaUseCase:         mfaUseCase,
+		ApiKeyUseCase:      apiKeyUseCase,
+		EmailChangeUseCase: emailChangeUseCase,
+		AccountUseCase:     accountUseCase,
 		TaskDistributor:    taskDistributor,
 	})
-	userHandler := handler.NewUserHandler(&handler.UserHandlerOptions{
-		UserUseCase: userUseCase,
-	})
 	projectHandler := handler.NewProjectHandler(&handler.ProjectHandlerOptions{
 		ProjectUseCase:         projectUseCase,
 		UserUseCase:            userUseCase,
 		ProjectCategoryUseCase: projectCategoryUseCase,
+		TaskDistributor:        taskDistributor,
 	})
 	forumHandler := handler.NewForumHandler(&handler.ForumHandlerOptions{
 		ForumUseCase: forumUseCase,
@@ -214,26 +388,45 @@ func inject(config *config.ApiServerConfig, datasource datasource.Datasource) *g
 		MessageUsecase: messageUseCase,
 		SocketService:  socketService,
 	})
+	healthHandler := handler.NewHealthHandler(&handler.HealthHandlerOptions{
+		Checker: checker,
+	})
 
-	authMiddleware := middleware.AuthMiddleware(jwtMaker)
+	authMiddleware := middleware.AuthMiddleware(jwtMaker, apiKeyUseCase)
+	optionalAuthMiddleware := middleware.OptionalAuthMiddleware(jwtMaker, apiKeyUseCase)
+	fullAccessMiddleware := middleware.RequireFullAccess()
+	adminMiddleware := middleware.RequireRole(entity.RoleAdmin)
+	moderatorMiddleware := middleware.RequireRole(entity.RoleModerator, entity.RoleAdmin)
 
 	router := gin.New()
+	// Handlers pass the gin context on to use cases and the task distributor, so its values must
+	// fall back to the request context where the trace lives.
+	router.ContextWithFallback = true
+
+	// Probes are registered before the middlewares so they are neither traced, logged nor rate
+	// limited. Metrics are not served here but on the internal listener of newMetricsServer.
+	router.GET("/healthz", healthHandler.Healthz)
+	router.GET("/readyz", healthHandler.Readyz)
 
 	c := cors.New(cors.Options{
 		AllowedOrigins:   []string{config.CorsAllowedOrigin},
 		AllowCredentials: config.CorsAllowedCredentials,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
 	})
+	router.Use(otelgin.Middleware(config.TracingServiceName))
 	router.Use(c)
 
 	router.Use(middleware.RequestLogger())
 	router.Use(middleware.ResponseLogger())
+	router.Use(middleware.Metrics())
 	router.Use(registerRateLimiter(redisClient))
 
 	router.GET("/ws", authMiddleware, func(c *gin.Context) {
 		ws.ServeWs(hub, c)
 	})
 
+	router.GET("/.well-known/jwks.json", authHandler.GetJWKS)
+
 	routeV1 := router.Group(config.PathPrefix)
 
 	docs.SwaggerInfo.BasePath = config.PathPrefix
@@ -244,29 +437,72 @@ func inject(config *config.ApiServerConfig, datasource datasource.Datasource) *g
 	{
 		authRoute.POST("/register", authHandler.Register)
 		authRoute.POST("/login", authHandler.Login)
+		authRoute.POST("/mfa/verify", authHandler.VerifyMfa)
 		authRoute.POST("/renew-token", authHandler.RenewAccessToken)
+		authRoute.POST("/logout", authHandler.Logout)
+		authRoute.POST("/logout-all", authMiddleware, fullAccessMiddleware, authHandler.LogoutAll)
 		authRoute.GET("/verify-email", authHandler.VerifyEmail)
 		authRoute.POST("/send-verify-email", authHandler.SendVerifyEmail)
-		// authRoute.POST("/login-with-google", func(c *gin.Context) {
-		// 	c.Redirect(http.StatusTemporaryRedirect, )
-		// })
+		authRoute.POST("/forgot-password", authHandler.ForgotPassword)
+		authRoute.POST("/reset-password", authHandler.ResetPassword)
+		authRoute.POST("/confirm-email-change", authHandler.ConfirmEmailChange)
+		authRoute.GET("/wallet/nonce", authHandler.GetWalletNonce)
+		authRoute.POST("/wallet/verify", authHandler.LoginWithWallet)
+		authRoute.GET("/google", authHandler.LoginWithGoogle)
+		authRoute.POST("/google/callback", authHandler.GoogleCallback)
 	}
 	userRoute := routeV1.Group("/users")
 	{
 		userRoute.GET("/me", authMiddleware, userHandler.GetMe)
+		userRoute.DELETE("/me", authMiddleware, fullAccessMiddleware, userHandler.DeleteMe)
+		userRoute.GET("/me/export", authMiddleware, fullAccessMiddleware, userHandler.ExportData)
+		userRoute.GET("/me/exports/:id", authMiddleware, fullAccessMiddleware, userHandler.GetDataExport)
+		userRoute.POST("/me/email", authMiddleware, fullAccessMiddleware, userHandler.ChangeEmail)
+		userRoute.GET("/me/sessions", authMiddleware, fullAccessMiddleware, userHandler.ListSessions)
+		userRoute.DELETE("/me/sessions/:id", authMiddleware, fullAccessMiddleware, userHandler.RevokeSession)
+		userRoute.GET("/me/api-keys", authMiddleware, fullAccessMiddleware, userHandler.ListApiKeys)
+		userRoute.POST("/me/api-keys", authMiddleware, fullAccessMiddleware, userHandler.CreateApiKey)
+		userRoute.DELETE("/me/api-keys/:id", authMiddleware, fullAccessMiddleware, userHandler.DeleteApiKey)
+		userRoute.POST("/me/mfa/totp", authMiddleware, fullAccessMiddleware, userHandler.EnrollTOTP)
+		userRoute.POST("/me/mfa/totp/confirm", authMiddleware, fullAccessMiddleware, userHandler.ConfirmTOTP)
+		userRoute.POST("/me/mfa/totp/disable", authMiddleware, fullAccessMiddleware, userHandler.DisableTOTP)
+		userRoute.POST("/me/wallets/challenge", authMiddleware, fullAccessMiddleware, userHandler.CreateWalletChallenge)
+		userRoute.POST("/me/wallets", authMiddleware, fullAccessMiddleware, userHandler.LinkWallet)
+		userRoute.DELETE("/me/wallets/:address", authMiddleware, fullAccessMiddleware, userHandler.UnlinkWallet)
 		userRoute.PATCH("/:id", authMiddleware, userHandler.UpdateUser)
+		userRoute.PATCH("/:id/role", authMiddleware, adminMiddleware, fullAccessMiddleware, userHandler.UpdateUserRole)
+	}
+	exportRoute := routeV1.Group("/exports")
+	{
+		exportRoute.GET("/:id", userHandler.DownloadDataExport)
 	}
 	projectRoute := routeV1.Group("/projects")
 	{
-		projectRoute.GET("", projectHandler.ListProjects)
+		projectRoute.GET("", optionalAuthMiddleware, projectHandler.ListProjects)
 		projectRoute.POST("", authMiddleware, projectHandler.CreateProject)
-		projectRoute.GET("/:id", projectHandler.GetProjectByID)
+		projectRoute.GET("/:id", optionalAuthMiddleware, projectHandler.GetProjectByID)
 		projectRoute.GET("/me", authMiddleware, projectHandler.GetOwnProjects)
 		projectRoute.GET("/recommendation", projectHandler.GetRecommendProjects)
 		projectRoute.GET("/categories", projectHandler.ListProjectCategories)
+		projectRoute.POST("/categories", authMiddleware, adminMiddleware, projectHandler.CreateProjectCategory)
 		projectRoute.POST("/:id/ratings", authMiddleware, projectHandler.CreateProjectRating)
 		projectRoute.GET("/:id/ratings/verify", authMiddleware, projectHandler.VerifyProjectRating)
 		projectRoute.POST("/:id/contribute", authMiddleware, projectHandler.ContributeProject)
+		projectRoute.POST("/:id/submit", authMiddleware, projectHandler.SubmitProject)
+		projectRoute.POST("/:id/publish", authMiddleware, moderatorMiddleware, projectHandler.PublishProject)
+		projectRoute.POST("/:id/reject", authMiddleware, moderatorMiddleware, projectHandler.RejectProject)
+		projectRoute.POST("/:id/cancel", authMiddleware, projectHandler.CancelProject)
+		projectRoute.POST("/:id/refunds", authMiddleware, projectHandler.RequestRefund)
+		projectRoute.POST("/:id/refunds/initiate", authMiddleware, projectHandler.InitiateRefunds)
+		projectRoute.GET("/:id/rewards", projectHandler.ListProjectRewards)
+		projectRoute.POST("/:id/rewards", authMiddleware, projectHandler.CreateProjectReward)
+		projectRoute.PATCH("/:id/rewards/:rewardId", authMiddleware, projectHandler.UpdateProjectReward)
+		projectRoute.DELETE("/:id/rewards/:rewardId", authMiddleware, projectHandler.DeleteProjectReward)
+		projectRoute.GET("/:id/updates", optionalAuthMiddleware, projectHandler.ListProjectUpdates)
+		projectRoute.GET("/:id/updates/:updateId", optionalAuthMiddleware, projectHandler.GetProjectUpdate)
+		projectRoute.POST("/:id/updates", authMiddleware, projectHandler.CreateProjectUpdate)
+		projectRoute.PATCH("/:id/updates/:updateId", authMiddleware, projectHandler.UpdateProjectUpdate)
+		projectRoute.DELETE("/:id/updates/:updateId", authMiddleware, projectHandler.DeleteProjectUpdate)
 		projectRoute.GET("/backed", authMiddleware, projectHandler.GetBackedProject)
 	}
 	postRoute := routeV1.Group("/posts")
@@ -274,6 +510,7 @@ func inject(config *config.ApiServerConfig, datasource datasource.Datasource) *g
 		postRoute.GET("", forumHandler.ListPosts)
 		postRoute.POST("", authMiddleware, forumHandler.CreatePost)
 		postRoute.GET("/:id", forumHandler.GetPostByID)
+		postRoute.DELETE("/:id", authMiddleware, moderatorMiddleware, forumHandler.DeletePost)
 		postRoute.POST("/:id/comments", authMiddleware, forumHandler.CreateComment)
 		postRoute.POST("/upload", authMiddleware, forumHandler.UploadImage)
 	}
@@ -312,6 +549,37 @@ func initSwaggerDocs(router *gin.RouterGroup) {
 	router.GET("/openapi/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 }
 
+// newTokenMaker creates the maker for access and refresh tokens. Tokens are signed with the
+// asymmetric key set when a signing key is configured, and with the shared secret otherwise.
+func newTokenMaker(config *config.ApiServerConfig) (*token.KeySet, token.Maker, error) {
+	if config.JwtSigningKey == "" {
+		if config.TokenFormat == "paseto" {
+			return nil, nil, fmt.Errorf("PASETO tokens require JWT_SIGNING_KEY")
+		}
+
+		log.Warn().Msg("JWT_SIGNING_KEY is not set, tokens are signed with the shared JWT_SECRET_KEY")
+		maker, err := token.NewJWTMaker(config.JwtSecretKey)
+		return nil, maker, err
+	}
+
+	keySet, err := token.ParseKeySet(config.JwtSigningKey, config.JwtVerificationKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var maker token.Maker
+	switch config.TokenFormat {
+	case "jwt":
+		maker, err = token.NewEd25519JWTMaker(keySet)
+	case "paseto":
+		maker, err = token.NewPasetoMaker(keySet)
+	default:
+		err = fmt.Errorf("unknown token format %q", config.TokenFormat)
+	}
+
+	return keySet, maker, err
+}
+
 func registerRateLimiter(redisClient *redis.Client) gin.HandlerFunc {
 	rate, err := limiter.NewRateFromFormatted("5-S")
 	if err != nil {
@@ -346,3 +614,12 @@ func runTaskProcessor(
 		log.Fatal().Err(err).Msg("failed to start task processor")
 	}
 }
+
+func runTaskScheduler(redisOptions asynq.RedisClientOpt) {
+	taskScheduler := worker.NewRedisTaskScheduler(redisOptions)
+
+	err := taskScheduler.Start()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to start task scheduler")
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"fund-o/api-server/config"
+	"fund-o/api-server/internal/datasource/driver"
+	"fund-o/api-server/internal/datasource/migration"
+	"fund-o/api-server/pkg/logger"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const usage = `Usage: migrate [flags] <command>
+
+Commands:
+  up            apply all pending migrations
+  down N        revert the N most recently applied migrations
+  status        list migrations and whether they are applied
+  create NAME   create an empty up/down migration pair
+
+Flags:
+`
+
+func init() {
+	logger.InitLogger()
+}
+
+func main() {
+	dir := flag.String("dir", migration.Dir, "directory new migrations are created in")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if args[0] == "create" {
+		if len(args) != 2 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		upPath, downPath, err := migration.Create(*dir, args[1], time.Now())
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to create migration")
+		}
+		fmt.Println(upPath)
+		fmt.Println(downPath)
+		return
+	}
+
+	appConfig, err := config.LoadAppConfig(".")
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load app config")
+	}
+
+	db, err := driver.OpenSQLDB(&appConfig.SqlDBConfig)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to connect to SQL database")
+	}
+
+	migrations, err := migration.Load(migration.Files)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load migrations")
+	}
+	migrator := migration.NewMigrator(db, migrations)
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to apply migrations")
+		}
+		log.Info().Int("applied", len(applied)).Msg("Migrations are up to date")
+	case "down":
+		if len(args) != 2 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		steps, err := strconv.Atoi(args[1])
+		if err != nil || steps < 1 {
+			log.Fatal().Str("steps", args[1]).Msg("Number of migrations to revert must be a positive integer")
+		}
+		reverted, err := migrator.Down(steps)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to revert migrations")
+		}
+		log.Info().Int("reverted", len(reverted)).Msg("Migrations reverted")
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to read migration status")
+		}
+		printStatus(statuses)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func printStatus(statuses []migration.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+	w.Flush()
+}
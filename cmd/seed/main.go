@@ -0,0 +1,117 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"fund-o/api-server/config"
+	"fund-o/api-server/internal/datasource/driver"
+	"fund-o/api-server/internal/datasource/driver/seeds"
+	"fund-o/api-server/internal/datasource/migration"
+	"fund-o/api-server/pkg/logger"
+	"os"
+
+	"github.com/rs/zerolog/log"
+)
+
+const usage = `Usage: seed [flags] [target]
+
+Targets:
+  categories    load the project categories
+  demo          load the categories and the demo dataset (default), whose users share a
+                public password; only in the development and test environments
+
+Flags:
+`
+
+func init() {
+	logger.InitLogger()
+}
+
+func main() {
+	reset := flag.Bool("reset", false, "empty every table before seeding")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	target := "demo"
+	if flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if flag.NArg() == 1 {
+		target = flag.Arg(0)
+	}
+	if target != "categories" && target != "demo" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	appConfig, err := config.LoadAppConfig(".")
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load app config")
+	}
+
+	if *reset && appConfig.AppEnv == "production" {
+		log.Fatal().Msg("Refusing to reset a production database")
+	}
+
+	if target == "demo" && appConfig.AppEnv != "development" && appConfig.AppEnv != "test" {
+		log.Fatal().Str("env", appConfig.AppEnv).Msg("Refusing to seed demo users with a public password outside development and test")
+	}
+
+	db, err := driver.OpenSQLDB(&appConfig.SqlDBConfig)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to connect to SQL database")
+	}
+
+	migrations, err := migration.Load(migration.Files)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load migrations")
+	}
+	pending, err := migration.NewMigrator(db, migrations).Pending()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to read migration status")
+	}
+	if len(pending) > 0 {
+		log.Fatal().Int("pending", len(pending)).Msg("SQL migrations are pending, run the migrate command first")
+	}
+
+	seeder := seeds.NewSeeder(db)
+
+	if *reset {
+		if err := seeder.Reset(); err != nil {
+			log.Fatal().Err(err).Msg("Failed to reset database")
+		}
+		log.Info().Msg("Database reset")
+	}
+
+	switch target {
+	case "categories":
+		if err := seeder.Categories(); err != nil {
+			log.Fatal().Err(err).Msg("Failed to seed categories")
+		}
+		log.Info().Msg("Categories seeded")
+	case "demo":
+		summary, err := seeder.Demo()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to seed demo data")
+		}
+		log.Info().
+			Int64("users", summary.Users).
+			Int64("projects", summary.Projects).
+			Int64("backers", summary.Backers).
+			Int64("ratings", summary.Ratings).
+			Int64("posts", summary.Posts).
+			Int64("comments", summary.Comments).
+			Int64("replies", summary.Replies).
+			Int64("channels", summary.Channels).
+			Int64("messages", summary.Messages).
+			Msg("Demo data seeded")
+		log.Info().
+			Str("domain", seeds.DemoEmailDomain).
+			Str("password", seeds.DemoPassword).
+			Msg("Demo users can sign in with any seeded email and this password")
+	}
+}
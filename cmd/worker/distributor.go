@@ -2,8 +2,15 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"fund-o/api-server/pkg/tracing"
 
 	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type TaskDistributor interface {
@@ -12,6 +19,41 @@ type TaskDistributor interface {
 		payload *PayloadSendVerifyEmail,
 		opts ...asynq.Option,
 	)
+	DistributeTaskVerifyContribution(
+		ctx context.Context,
+		payload *PayloadVerifyContribution,
+		opts ...asynq.Option,
+	)
+	DistributeTaskProcessRefund(
+		ctx context.Context,
+		payload *PayloadProcessRefund,
+		opts ...asynq.Option,
+	)
+	DistributeTaskSendProjectUpdateEmail(
+		ctx context.Context,
+		payload *PayloadSendProjectUpdateEmail,
+		opts ...asynq.Option,
+	)
+	DistributeTaskSendPasswordResetEmail(
+		ctx context.Context,
+		payload *PayloadSendPasswordResetEmail,
+		opts ...asynq.Option,
+	)
+	DistributeTaskSendAccountLockedEmail(
+		ctx context.Context,
+		payload *PayloadSendAccountLockedEmail,
+		opts ...asynq.Option,
+	)
+	DistributeTaskSendEmailChangeEmail(
+		ctx context.Context,
+		payload *PayloadSendEmailChangeEmail,
+		opts ...asynq.Option,
+	)
+	DistributeTaskGenerateDataExport(
+		ctx context.Context,
+		payload *PayloadGenerateDataExport,
+		opts ...asynq.Option,
+	)
 }
 
 type RedisTaskDistributor struct {
@@ -28,3 +70,60 @@ func NewRedisTaskDistributor(redisOpt asynq.RedisClientOpt) TaskDistributor {
 		logger: logger,
 	}
 }
+
+// enqueue publishes a task inside a producer span. The trace context of the span travels in the
+// payload, so the processor continues the trace of the caller.
+func (distributor *RedisTaskDistributor) enqueue(
+	ctx context.Context,
+	taskType string,
+	payload any,
+	opts ...asynq.Option,
+) (*asynq.Task, *asynq.TaskInfo, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "asynq.enqueue "+taskType,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("asynq"),
+			semconv.MessagingOperationPublish,
+			attribute.String("asynq.task.type", taskType),
+		),
+	)
+	defer span.End()
+
+	task, info, err := distributor.newTask(ctx, taskType, payload, opts...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, nil, err
+	}
+
+	span.SetAttributes(
+		semconv.MessagingDestinationName(info.Queue),
+		semconv.MessagingMessageID(info.ID),
+	)
+	return task, info, nil
+}
+
+func (distributor *RedisTaskDistributor) newTask(
+	ctx context.Context,
+	taskType string,
+	payload any,
+	opts ...asynq.Option,
+) (*asynq.Task, *asynq.TaskInfo, error) {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	jsonPayload, err = tracing.InjectPayload(ctx, jsonPayload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to inject trace context: %w", err)
+	}
+
+	task := asynq.NewTask(taskType, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return task, info, nil
+}
@@ -3,6 +3,7 @@ package worker
 import (
 	"context"
 	"fmt"
+	"fund-o/api-server/pkg/logger"
 	"os"
 	"time"
 
@@ -23,7 +24,7 @@ func NewWorkerLogger(role string) *Logger {
 		},
 	}
 
-	log := zerolog.New(output).With().Timestamp().Str("role", role).Logger()
+	log := zerolog.New(output).Hook(logger.TracingHook{}).With().Timestamp().Str("role", role).Logger()
 	return &Logger{log}
 }
 
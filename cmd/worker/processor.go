@@ -4,6 +4,8 @@ import (
 	"context"
 	"fund-o/api-server/internal/usecase"
 	"fund-o/api-server/pkg/mail"
+	"fund-o/api-server/pkg/metrics"
+	"fund-o/api-server/pkg/tracing"
 	"os"
 	"os/signal"
 	"syscall"
@@ -11,6 +13,10 @@ import (
 
 	"github.com/hibiken/asynq"
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -21,6 +27,15 @@ const (
 type TaskProcessor interface {
 	Start() error
 	ProcessTaskSendVerifyEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskCloseExpiredProjects(ctx context.Context, task *asynq.Task) error
+	ProcessTaskVerifyContribution(ctx context.Context, task *asynq.Task) error
+	ProcessTaskIndexChainEvents(ctx context.Context, task *asynq.Task) error
+	ProcessTaskProcessRefund(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendProjectUpdateEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendPasswordResetEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendAccountLockedEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendEmailChangeEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskGenerateDataExport(ctx context.Context, task *asynq.Task) error
 }
 
 type RedisTaskProcessor struct {
@@ -37,8 +52,13 @@ type RedisTaskProcessorOptions struct {
 }
 
 type TaskProcessorUseCaseOptions struct {
-	UserUseCase        usecase.UserUseCase
-	VerifyEmailUseCase usecase.VerifyEmailUseCase
+	UserUseCase          usecase.UserUseCase
+	VerifyEmailUseCase   usecase.VerifyEmailUseCase
+	PasswordResetUseCase usecase.PasswordResetUseCase
+	EmailChangeUseCase   usecase.EmailChangeUseCase
+	AccountUseCase       usecase.AccountUseCase
+	ProjectUseCase       usecase.ProjectUseCase
+	ChainIndexerUseCase  usecase.ChainIndexerUseCase
 }
 
 func NewRedisTaskProcessor(options *RedisTaskProcessorOptions) TaskProcessor {
@@ -66,8 +86,13 @@ func NewRedisTaskProcessor(options *RedisTaskProcessorOptions) TaskProcessor {
 		server: server,
 		mailer: options.Mailer,
 		useCases: &TaskProcessorUseCaseOptions{
-			UserUseCase:        options.UseCases.UserUseCase,
-			VerifyEmailUseCase: options.UseCases.VerifyEmailUseCase,
+			UserUseCase:          options.UseCases.UserUseCase,
+			VerifyEmailUseCase:   options.UseCases.VerifyEmailUseCase,
+			PasswordResetUseCase: options.UseCases.PasswordResetUseCase,
+			EmailChangeUseCase:   options.UseCases.EmailChangeUseCase,
+			AccountUseCase:       options.UseCases.AccountUseCase,
+			ProjectUseCase:       options.UseCases.ProjectUseCase,
+			ChainIndexerUseCase:  options.UseCases.ChainIndexerUseCase,
 		},
 		logger: logger,
 	}
@@ -77,7 +102,17 @@ func (processor *RedisTaskProcessor) Start() error {
 	log := processor.logger.log
 
 	mux := asynq.NewServeMux()
+	mux.Use(traceTask, recordTaskMetrics)
 	mux.HandleFunc(TaskSendVerifyEmail, processor.ProcessTaskSendVerifyEmail)
+	mux.HandleFunc(TaskCloseExpiredProjects, processor.ProcessTaskCloseExpiredProjects)
+	mux.HandleFunc(TaskVerifyContribution, processor.ProcessTaskVerifyContribution)
+	mux.HandleFunc(TaskIndexChainEvents, processor.ProcessTaskIndexChainEvents)
+	mux.HandleFunc(TaskProcessRefund, processor.ProcessTaskProcessRefund)
+	mux.HandleFunc(TaskSendProjectUpdateEmail, processor.ProcessTaskSendProjectUpdateEmail)
+	mux.HandleFunc(TaskSendPasswordResetEmail, processor.ProcessTaskSendPasswordResetEmail)
+	mux.HandleFunc(TaskSendAccountLockedEmail, processor.ProcessTaskSendAccountLockedEmail)
+	mux.HandleFunc(TaskSendEmailChangeEmail, processor.ProcessTaskSendEmailChangeEmail)
+	mux.HandleFunc(TaskGenerateDataExport, processor.ProcessTaskGenerateDataExport)
 
 	log.Info().Msg("Starting task processor...")
 	go func() {
@@ -101,3 +136,52 @@ func (processor *RedisTaskProcessor) Start() error {
 
 	return nil
 }
+
+// recordTaskMetrics counts every processed task, and every failed one, by task type.
+func recordTaskMetrics(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		err := next.ProcessTask(ctx, task)
+
+		metrics.TasksProcessed.WithLabelValues(task.Type()).Inc()
+		if err != nil {
+			metrics.TasksFailed.WithLabelValues(task.Type()).Inc()
+		}
+
+		return err
+	})
+}
+
+// traceTask processes every task inside a consumer span that continues the trace carried by the
+// payload, if any.
+func traceTask(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		ctx = tracing.ExtractPayload(ctx, task.Payload())
+		ctx, span := tracing.Tracer().Start(ctx, "asynq.process "+task.Type(),
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				semconv.MessagingSystemKey.String("asynq"),
+				semconv.MessagingOperationReceive,
+				attribute.String("asynq.task.type", task.Type()),
+			),
+		)
+		defer span.End()
+
+		if id, ok := asynq.GetTaskID(ctx); ok {
+			span.SetAttributes(semconv.MessagingMessageID(id))
+		}
+		if queue, ok := asynq.GetQueueName(ctx); ok {
+			span.SetAttributes(semconv.MessagingDestinationName(queue))
+		}
+		if retryCount, ok := asynq.GetRetryCount(ctx); ok {
+			span.SetAttributes(attribute.Int("asynq.task.retry_count", retryCount))
+		}
+
+		err := next.ProcessTask(ctx, task)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		return err
+	})
+}
@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+const (
+	closeExpiredProjectsCronSpec = "@every 1m"
+	indexChainEventsCronSpec     = "@every 15s"
+)
+
+type TaskScheduler interface {
+	Start() error
+}
+
+type RedisTaskScheduler struct {
+	scheduler *asynq.Scheduler
+	logger    *Logger
+}
+
+func NewRedisTaskScheduler(redisOpt asynq.RedisClientOpt) TaskScheduler {
+	logger := NewWorkerLogger("scheduler")
+
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+		Logger: logger,
+	})
+
+	return &RedisTaskScheduler{
+		scheduler: scheduler,
+		logger:    logger,
+	}
+}
+
+// Start registers the periodic tasks and blocks until the process receives a shutdown signal.
+func (s *RedisTaskScheduler) Start() error {
+	log := s.logger.log
+
+	_, err := s.scheduler.Register(
+		closeExpiredProjectsCronSpec,
+		asynq.NewTask(TaskCloseExpiredProjects, nil),
+		asynq.Queue(QueueDefault),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Rounds must not overlap since each one moves the same block cursor.
+	_, err = s.scheduler.Register(
+		indexChainEventsCronSpec,
+		asynq.NewTask(TaskIndexChainEvents, nil),
+		asynq.Queue(QueueDefault),
+		asynq.MaxRetry(0),
+		asynq.Unique(time.Minute),
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Info().Msg("Starting task scheduler...")
+	return s.scheduler.Run()
+}
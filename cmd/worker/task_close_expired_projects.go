@@ -0,0 +1,24 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+)
+
+const TaskCloseExpiredProjects = "task:close_expired_projects"
+
+func (processor *RedisTaskProcessor) ProcessTaskCloseExpiredProjects(ctx context.Context, task *asynq.Task) error {
+	closed, err := processor.useCases.ProjectUseCase.CloseExpiredProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to close expired projects: %w", err)
+	}
+
+	processor.logger.log.Info().
+		Ctx(ctx).
+		Str("type", task.Type()).
+		Int64("closed", closed).
+		Msg("processed task")
+	return nil
+}
@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fund-o/api-server/internal/entity"
+	"fund-o/api-server/pkg/apperrors"
+	"fund-o/api-server/pkg/mail"
+	"strings"
+
+	"github.com/hibiken/asynq"
+)
+
+const TaskGenerateDataExport = "task:generate_data_export"
+
+type PayloadGenerateDataExport struct {
+	ExportID string `json:"export_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskGenerateDataExport(
+	ctx context.Context,
+	payload *PayloadGenerateDataExport,
+	opts ...asynq.Option,
+) {
+	log := distributor.logger.log
+	task, info, err := distributor.enqueue(ctx, TaskGenerateDataExport, payload, opts...)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to enqueue task")
+		return
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskGenerateDataExport(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadGenerateDataExport
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	link, err := processor.useCases.AccountUseCase.GenerateDataExport(ctx, payload.ExportID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidDataExport) || errors.Is(err, apperrors.ErrUserNotFound) {
+			// The export or its user is gone.
+			return fmt.Errorf("failed to generate data export: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to generate data export: %w", err)
+	}
+
+	switch {
+	case strings.HasSuffix(link.Email, "@"+entity.DeletedEmailDomain):
+		// The account may have been deleted while the export was waiting.
+		return fmt.Errorf("failed to send data export email: %w", asynq.SkipRetry)
+	case strings.HasSuffix(link.Email, "@"+entity.WalletEmailDomain):
+		// Wallet-only accounts get the download link from the export status instead.
+	default:
+		subject := "Your FundO data export is ready"
+		content := mail.NewDataExportTemplate(link.URL)
+		to := []string{link.Email}
+
+		err = processor.mailer.SendEmail(subject, content, to, nil, nil)
+		if err != nil {
+			return fmt.Errorf("failed to send data export email: %w", err)
+		}
+	}
+
+	processor.logger.log.Info().
+		Ctx(ctx).
+		Str("type", task.Type()).
+		Msg("processed task")
+	return nil
+}
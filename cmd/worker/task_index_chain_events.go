@@ -0,0 +1,28 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+)
+
+const TaskIndexChainEvents = "task:index_chain_events"
+
+func (processor *RedisTaskProcessor) ProcessTaskIndexChainEvents(ctx context.Context, task *asynq.Task) error {
+	result, err := processor.useCases.ChainIndexerUseCase.IndexEvents(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to index chain events: %w", err)
+	}
+
+	processor.logger.log.Info().
+		Ctx(ctx).
+		Str("type", task.Type()).
+		Uint64("from_block", result.FromBlock).
+		Uint64("to_block", result.ToBlock).
+		Int("indexed", result.Indexed).
+		Int("confirmed", result.Confirmed).
+		Int64("rolled_back", result.RolledBack).
+		Msg("processed task")
+	return nil
+}
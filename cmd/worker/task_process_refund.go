@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fund-o/api-server/pkg/apperrors"
+
+	"github.com/hibiken/asynq"
+)
+
+const TaskProcessRefund = "task:process_refund"
+
+type PayloadProcessRefund struct {
+	RefundID string `json:"refund_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskProcessRefund(
+	ctx context.Context,
+	payload *PayloadProcessRefund,
+	opts ...asynq.Option,
+) {
+	log := distributor.logger.log
+	task, info, err := distributor.enqueue(ctx, TaskProcessRefund, payload, opts...)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to enqueue task")
+		return
+	}
+
+	log.Info().
+		Ctx(ctx).
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskProcessRefund(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadProcessRefund
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	err := processor.useCases.ProjectUseCase.ProcessRefund(ctx, payload.RefundID)
+	if err != nil {
+		// This was the last attempt, so record why the refund could not be settled.
+		retried, _ := asynq.GetRetryCount(ctx)
+		maxRetry, _ := asynq.GetMaxRetry(ctx)
+		if retried >= maxRetry {
+			if failErr := processor.useCases.ProjectUseCase.FailRefund(ctx, payload.RefundID, err.Error()); failErr != nil {
+				return fmt.Errorf("failed to mark refund as failed: %w", failErr)
+			}
+		}
+
+		if errors.Is(err, apperrors.ErrRefundPending) {
+			// The refund is not paid out on chain yet; asynq retries the task with backoff.
+			return err
+		}
+
+		return fmt.Errorf("failed to process refund: %w", err)
+	}
+
+	processor.logger.log.Info().
+		Ctx(ctx).
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("refund_id", payload.RefundID).
+		Msg("processed task")
+	return nil
+}
@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fund-o/api-server/internal/entity"
+	"fund-o/api-server/pkg/mail"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"gorm.io/gorm"
+)
+
+const TaskSendAccountLockedEmail = "task:send_account_locked_email"
+
+type PayloadSendAccountLockedEmail struct {
+	Email       string    `json:"email"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendAccountLockedEmail(
+	ctx context.Context,
+	payload *PayloadSendAccountLockedEmail,
+	opts ...asynq.Option,
+) {
+	log := distributor.logger.log
+	task, info, err := distributor.enqueue(ctx, TaskSendAccountLockedEmail, payload, opts...)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to enqueue task")
+		return
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendAccountLockedEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendAccountLockedEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	user, err := processor.useCases.UserUseCase.GetUserByEmail(ctx, payload.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Logins are locked for unknown emails too, but there is nobody to tell.
+			return fmt.Errorf("failed to get user: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if strings.HasSuffix(user.Email, "@"+entity.WalletEmailDomain) {
+		return fmt.Errorf("wallet account has no email: %w", asynq.SkipRetry)
+	}
+
+	subject := "Your FundO account has been locked"
+	content := mail.NewAccountLockedTemplate(payload.LockedUntil)
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send account locked email: %w", err)
+	}
+
+	processor.logger.log.Info().
+		Ctx(ctx).
+		Str("type", task.Type()).
+		Msg("processed task")
+	return nil
+}
@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fund-o/api-server/internal/entity"
+	"fund-o/api-server/pkg/apperrors"
+	"fund-o/api-server/pkg/mail"
+	"strings"
+
+	"github.com/hibiken/asynq"
+)
+
+const TaskSendEmailChangeEmail = "task:send_email_change_email"
+
+type PayloadSendEmailChangeEmail struct {
+	UserID   string `json:"user_id"`
+	NewEmail string `json:"new_email"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendEmailChangeEmail(
+	ctx context.Context,
+	payload *PayloadSendEmailChangeEmail,
+	opts ...asynq.Option,
+) {
+	log := distributor.logger.log
+	task, info, err := distributor.enqueue(ctx, TaskSendEmailChangeEmail, payload, opts...)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to enqueue task")
+		return
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendEmailChangeEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendEmailChangeEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	link, err := processor.useCases.EmailChangeUseCase.CreateEmailChange(ctx, payload.UserID, payload.NewEmail)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) ||
+			errors.Is(err, apperrors.ErrInvalidUserID) ||
+			errors.Is(err, apperrors.ErrEmailAlreadyUsed) {
+			return fmt.Errorf("failed to create email change: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to create email change: %w", err)
+	}
+
+	subject := "Confirm your new FundO email"
+	content := mail.NewEmailChangeTemplate(link.URL)
+	to := []string{link.NewEmail}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send email change email: %w", err)
+	}
+
+	// Wallet-only accounts have a placeholder email that cannot receive the notice.
+	if !strings.HasSuffix(link.OldEmail, "@"+entity.WalletEmailDomain) {
+		subject = "Your FundO email is being changed"
+		content = mail.NewEmailChangeNoticeTemplate(link.NewEmail)
+		to = []string{link.OldEmail}
+
+		// The confirmation link is already out, so retrying would send another one.
+		if err := processor.mailer.SendEmail(subject, content, to, nil, nil); err != nil {
+			processor.logger.log.Error().Ctx(ctx).Err(err).Msg("failed to send email change notice")
+		}
+	}
+
+	processor.logger.log.Info().
+		Ctx(ctx).
+		Str("type", task.Type()).
+		Msg("processed task")
+	return nil
+}
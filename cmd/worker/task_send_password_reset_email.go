@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fund-o/api-server/pkg/apperrors"
+	"fund-o/api-server/pkg/mail"
+
+	"github.com/hibiken/asynq"
+)
+
+const TaskSendPasswordResetEmail = "task:send_password_reset_email"
+
+type PayloadSendPasswordResetEmail struct {
+	Email string `json:"email"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendPasswordResetEmail(
+	ctx context.Context,
+	payload *PayloadSendPasswordResetEmail,
+	opts ...asynq.Option,
+) {
+	log := distributor.logger.log
+	task, info, err := distributor.enqueue(ctx, TaskSendPasswordResetEmail, payload, opts...)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to enqueue task")
+		return
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendPasswordResetEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendPasswordResetEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	link, err := processor.useCases.PasswordResetUseCase.CreatePasswordReset(ctx, payload.Email)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			// Nobody can sign in with this email, so there is nothing to reset.
+			return fmt.Errorf("failed to create password reset: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to create password reset: %w", err)
+	}
+
+	subject := "Reset your FundO password"
+	content := mail.NewPasswordResetTemplate(link.URL)
+	to := []string{link.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+
+	processor.logger.log.Info().
+		Ctx(ctx).
+		Str("type", task.Type()).
+		Msg("processed task")
+	return nil
+}
@@ -0,0 +1,74 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fund-o/api-server/pkg/mail"
+
+	"github.com/hibiken/asynq"
+	"gorm.io/gorm"
+)
+
+const TaskSendProjectUpdateEmail = "task:send_project_update_email"
+
+type PayloadSendProjectUpdateEmail struct {
+	UpdateID string `json:"update_id"`
+	Email    string `json:"email"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendProjectUpdateEmail(
+	ctx context.Context,
+	payload *PayloadSendProjectUpdateEmail,
+	opts ...asynq.Option,
+) {
+	log := distributor.logger.log
+	task, info, err := distributor.enqueue(ctx, TaskSendProjectUpdateEmail, payload, opts...)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to enqueue task")
+		return
+	}
+
+	log.Info().
+		Ctx(ctx).
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendProjectUpdateEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendProjectUpdateEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	notification, err := processor.useCases.ProjectUseCase.GetProjectUpdateNotification(ctx, payload.UpdateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// The update was deleted before the email went out.
+			return fmt.Errorf("failed to get project update: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to get project update: %w", err)
+	}
+
+	subject := fmt.Sprintf("New update from %s", notification.ProjectTitle)
+	content := mail.NewProjectUpdateTemplate(notification.ProjectTitle, notification.UpdateTitle)
+	to := []string{payload.Email}
+
+	err = processor.mailer.SendEmail(subject, content, to, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send project update email: %w", err)
+	}
+
+	processor.logger.log.Info().
+		Ctx(ctx).
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("email", payload.Email).
+		Msg("processed task")
+	return nil
+}
@@ -23,18 +23,14 @@ func (distributor *RedisTaskDistributor) DistributeTaskSendVerifyEmail(
 	opts ...asynq.Option,
 ) {
 	log := distributor.logger.log
-	jsonPayload, err := json.Marshal(payload)
+	task, info, err := distributor.enqueue(ctx, TaskSendVerifyEmail, payload, opts...)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to marshal task payload")
-	}
-
-	task := asynq.NewTask(TaskSendVerifyEmail, jsonPayload, opts...)
-	info, err := distributor.client.EnqueueContext(ctx, task)
-	if err != nil {
-		log.Error().Err(err).Msg("failed to enqueue task")
+		log.Error().Ctx(ctx).Err(err).Msg("failed to enqueue task")
+		return
 	}
 
 	log.Info().
+		Ctx(ctx).
 		Str("type", task.Type()).
 		Bytes("payload", task.Payload()).
 		Str("queue", info.Queue).
@@ -42,18 +38,18 @@ func (distributor *RedisTaskDistributor) DistributeTaskSendVerifyEmail(
 		Msg("enqueued task")
 }
 
-func (processor *RedisTaskProcessor) ProcessTaskSendVerifyEmail(_ context.Context, task *asynq.Task) error {
+func (processor *RedisTaskProcessor) ProcessTaskSendVerifyEmail(ctx context.Context, task *asynq.Task) error {
 	var payload PayloadSendVerifyEmail
 	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
 		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
 	}
 
-	user, err := processor.useCases.UserUseCase.GetUserByEmail(payload.Email)
+	user, err := processor.useCases.UserUseCase.GetUserByEmail(ctx, payload.Email)
 	if err != nil {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 
-	verifyEmail, err := processor.useCases.VerifyEmailUseCase.CreateVerifyEmail(&entity.VerifyEmailCreatePayload{
+	verifyEmail, err := processor.useCases.VerifyEmailUseCase.CreateVerifyEmail(ctx, &entity.VerifyEmailCreatePayload{
 		Email:      user.Email,
 		SecretCode: random.NewString(32),
 	})
@@ -75,6 +71,7 @@ func (processor *RedisTaskProcessor) ProcessTaskSendVerifyEmail(_ context.Contex
 	}
 
 	processor.logger.log.Info().
+		Ctx(ctx).
 		Str("type", task.Type()).
 		Bytes("payload", task.Payload()).
 		Str("email", user.Email).
@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fund-o/api-server/pkg/apperrors"
+
+	"github.com/hibiken/asynq"
+)
+
+const TaskVerifyContribution = "task:verify_contribution"
+
+type PayloadVerifyContribution struct {
+	BackerID string `json:"backer_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskVerifyContribution(
+	ctx context.Context,
+	payload *PayloadVerifyContribution,
+	opts ...asynq.Option,
+) {
+	log := distributor.logger.log
+	task, info, err := distributor.enqueue(ctx, TaskVerifyContribution, payload, opts...)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Msg("failed to enqueue task")
+		return
+	}
+
+	log.Info().
+		Ctx(ctx).
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskVerifyContribution(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadVerifyContribution
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	err := processor.useCases.ProjectUseCase.VerifyContribution(ctx, payload.BackerID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrContributionPending) {
+			// The transaction is not mined yet; asynq retries the task with backoff.
+			return err
+		}
+
+		return fmt.Errorf("failed to verify contribution: %w", err)
+	}
+
+	processor.logger.log.Info().
+		Ctx(ctx).
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("backer_id", payload.BackerID).
+		Msg("processed task")
+	return nil
+}
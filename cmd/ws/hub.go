@@ -1,6 +1,10 @@
 package ws
 
-import "github.com/redis/go-redis/v9"
+import (
+	"fund-o/api-server/pkg/metrics"
+
+	"github.com/redis/go-redis/v9"
+)
 
 type Hub struct {
 	clients     map[*Client]bool
@@ -42,10 +46,12 @@ func (hub *Hub) Run() {
 
 func (hub *Hub) registerClient(client *Client) {
 	hub.clients[client] = true
+	metrics.WsClients.Set(float64(len(hub.clients)))
 }
 
 func (hub *Hub) unregisterClient(client *Client) {
 	delete(hub.clients, client)
+	metrics.WsClients.Set(float64(len(hub.clients)))
 }
 
 func (hub *Hub) broadcastToClients(message []byte) {
@@ -77,6 +83,7 @@ func (hub *Hub) createRoom(id string) *Room {
 	room := NewRoom(id, hub.redisClient)
 	go room.RunRoom()
 	hub.rooms[room] = true
+	metrics.WsRooms.Set(float64(len(hub.rooms)))
 
 	return room
 }
@@ -1,26 +1,57 @@
 package config
 
+import "time"
+
 type ApiServerConfig struct {
-	Host                   string `mapstructure:"APP_HOST"`
-	Port                   int    `mapstructure:"APP_PORT"`
-	PathPrefix             string `mapstructure:"APP_PATH_PREFIX"`
-	RequestIdHeader        string `mapstructure:"APP_REQUEST_ID_HEADER"`
-	TrustProxy             string `mapstructure:"APP_TRUST_PROXY"`
-	CorsEnabled            bool   `mapstructure:"APP_CORS_ENABLED"`
-	CorsAllowedOrigin      string `mapstructure:"APP_CORS_ALLOWED_ORIGIN"`
-	CorsAllowedCredentials bool   `mapstructure:"APP_CORS_ALLOWED_CREDENTIALS"`
-	CorsMaxAge             int    `mapstructure:"APP_CORS_MAX_AGE"`
-	ReadOnly               bool   `mapstructure:"APP_READ_ONLY"`
-	LogRequest             bool   `mapstructure:"LOG_REQUEST"`
-	JwtSecretKey           string `mapstructure:"JWT_SECRET_KEY"`
-	GoogleClientId         string `mapstructure:"GOOGLE_CLIENT_ID"`
-	GoogleClientSecret     string `mapstructure:"GOOGLE_CLIENT_SECRET"`
-	RedisAddress           string `mapstructure:"REDIS_ADDRESS"`
-	EmailSenderName        string `mapstructure:"EMAIL_SENDER_NAME"`
-	EmailSenderAddress     string `mapstructure:"EMAIL_SENDER_ADDRESS"`
-	EmailSenderPassword    string `mapstructure:"EMAIL_SENDER_PASSWORD"`
-	AwsRegion              string `mapstructure:"AWS_REGION"`
-	AwsBucketName          string `mapstructure:"AWS_BUCKET_NAME"`
-	AwsAccessKeyID         string `mapstructure:"AWS_ACCESS_KEY_ID"`
-	AwsSecretAccessKey     string `mapstructure:"AWS_SECRET_ACCESS_KEY"`
+	Host                   string        `mapstructure:"APP_HOST"`
+	Port                   int           `mapstructure:"APP_PORT"`
+	PathPrefix             string        `mapstructure:"APP_PATH_PREFIX"`
+	RequestIdHeader        string        `mapstructure:"APP_REQUEST_ID_HEADER"`
+	TrustProxy             string        `mapstructure:"APP_TRUST_PROXY"`
+	CorsEnabled            bool          `mapstructure:"APP_CORS_ENABLED"`
+	CorsAllowedOrigin      string        `mapstructure:"APP_CORS_ALLOWED_ORIGIN"`
+	CorsAllowedCredentials bool          `mapstructure:"APP_CORS_ALLOWED_CREDENTIALS"`
+	CorsMaxAge             int           `mapstructure:"APP_CORS_MAX_AGE"`
+	ReadOnly               bool          `mapstructure:"APP_READ_ONLY"`
+	LogRequest             bool          `mapstructure:"LOG_REQUEST"`
+	JwtSecretKey           string        `mapstructure:"JWT_SECRET_KEY"`
+	JwtSigningKey          string        `mapstructure:"JWT_SIGNING_KEY"`
+	JwtVerificationKeys    string        `mapstructure:"JWT_VERIFICATION_KEYS"`
+	TokenFormat            string        `mapstructure:"TOKEN_FORMAT"`
+	GoogleClientId         string        `mapstructure:"GOOGLE_CLIENT_ID"`
+	GoogleClientSecret     string        `mapstructure:"GOOGLE_CLIENT_SECRET"`
+	GoogleAuthUrl          string        `mapstructure:"GOOGLE_AUTH_URL"`
+	GoogleTokenUrl         string        `mapstructure:"GOOGLE_TOKEN_URL"`
+	GoogleUserInfoUrl      string        `mapstructure:"GOOGLE_USERINFO_URL"`
+	GoogleRedirectUrl      string        `mapstructure:"GOOGLE_REDIRECT_URL"`
+	RedisAddress           string        `mapstructure:"REDIS_ADDRESS"`
+	EmailSenderName        string        `mapstructure:"EMAIL_SENDER_NAME"`
+	EmailSenderAddress     string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
+	EmailSenderPassword    string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
+	AwsRegion              string        `mapstructure:"AWS_REGION"`
+	AwsBucketName          string        `mapstructure:"AWS_BUCKET_NAME"`
+	AwsAccessKeyID         string        `mapstructure:"AWS_ACCESS_KEY_ID"`
+	AwsSecretAccessKey     string        `mapstructure:"AWS_SECRET_ACCESS_KEY"`
+	ChainRpcUrl            string        `mapstructure:"CHAIN_RPC_URL"`
+	ChainFactoryAddress    string        `mapstructure:"CHAIN_FACTORY_ADDRESS"`
+	ChainStartBlock        uint64        `mapstructure:"CHAIN_START_BLOCK"`
+	ChainConfirmations     uint64        `mapstructure:"CHAIN_CONFIRMATIONS"`
+	ChainID                int64         `mapstructure:"CHAIN_ID"`
+	SiweDomain             string        `mapstructure:"SIWE_DOMAIN"`
+	PasswordResetUrl       string        `mapstructure:"PASSWORD_RESET_URL"`
+	EmailChangeUrl         string        `mapstructure:"EMAIL_CHANGE_URL"`
+	DataExportUrl          string        `mapstructure:"DATA_EXPORT_URL"`
+	LoginMaxAttempts       int           `mapstructure:"LOGIN_MAX_ATTEMPTS"`
+	LoginIpMaxAttempts     int           `mapstructure:"LOGIN_IP_MAX_ATTEMPTS"`
+	LoginLockoutDuration   time.Duration `mapstructure:"LOGIN_LOCKOUT_DURATION"`
+	ShutdownDrainDelay     time.Duration `mapstructure:"SHUTDOWN_DRAIN_DELAY"`
+	TracingServiceName     string        `mapstructure:"TRACING_SERVICE_NAME"`
+	TracingExporter        string        `mapstructure:"TRACING_EXPORTER"`
+	TracingOtlpEndpoint    string        `mapstructure:"TRACING_OTLP_ENDPOINT"`
+	TracingOtlpInsecure    bool          `mapstructure:"TRACING_OTLP_INSECURE"`
+	TracingSampleRatio     float64       `mapstructure:"TRACING_SAMPLE_RATIO"`
+	// MetricsHost and MetricsPort are the address of the internal listener serving /metrics, kept
+	// apart from the public API. A port of 0 turns it off.
+	MetricsHost string `mapstructure:"METRICS_HOST"`
+	MetricsPort int    `mapstructure:"METRICS_PORT"`
 }
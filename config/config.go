@@ -51,6 +51,29 @@ func makeDefaultAppConfig() {
 	viper.SetDefault("ApiServerConfig.APP_CORS_MAX_AGE", 300)
 	viper.SetDefault("ApiServerConfig.APP_READ_ONLY", false)
 	viper.SetDefault("ApiServerConfig.LOG_REQUEST", true)
+	viper.SetDefault("ApiServerConfig.TOKEN_FORMAT", "jwt")
+	viper.SetDefault("ApiServerConfig.CHAIN_START_BLOCK", 0)
+	viper.SetDefault("ApiServerConfig.CHAIN_CONFIRMATIONS", 12)
+	viper.SetDefault("ApiServerConfig.CHAIN_ID", 1)
+	viper.SetDefault("ApiServerConfig.SIWE_DOMAIN", "localhost:3000")
+	viper.SetDefault("ApiServerConfig.PASSWORD_RESET_URL", "http://localhost:5173/reset-password")
+	viper.SetDefault("ApiServerConfig.EMAIL_CHANGE_URL", "http://localhost:5173/confirm-email")
+	viper.SetDefault("ApiServerConfig.DATA_EXPORT_URL", "http://localhost:3000/api/v1/exports")
+	viper.SetDefault("ApiServerConfig.GOOGLE_AUTH_URL", "https://accounts.google.com/o/oauth2/v2/auth")
+	viper.SetDefault("ApiServerConfig.GOOGLE_TOKEN_URL", "https://oauth2.googleapis.com/token")
+	viper.SetDefault("ApiServerConfig.GOOGLE_USERINFO_URL", "https://openidconnect.googleapis.com/v1/userinfo")
+	viper.SetDefault("ApiServerConfig.GOOGLE_REDIRECT_URL", "http://localhost:5173/auth/google/callback")
+	viper.SetDefault("ApiServerConfig.LOGIN_MAX_ATTEMPTS", 5)
+	viper.SetDefault("ApiServerConfig.LOGIN_IP_MAX_ATTEMPTS", 50)
+	viper.SetDefault("ApiServerConfig.LOGIN_LOCKOUT_DURATION", "15m")
+	viper.SetDefault("ApiServerConfig.SHUTDOWN_DRAIN_DELAY", "5s")
+	viper.SetDefault("ApiServerConfig.METRICS_HOST", "localhost")
+	viper.SetDefault("ApiServerConfig.METRICS_PORT", 9615)
+	viper.SetDefault("ApiServerConfig.TRACING_SERVICE_NAME", "fund-o-api")
+	viper.SetDefault("ApiServerConfig.TRACING_EXPORTER", "none")
+	viper.SetDefault("ApiServerConfig.TRACING_OTLP_ENDPOINT", "localhost:4318")
+	viper.SetDefault("ApiServerConfig.TRACING_OTLP_INSECURE", true)
+	viper.SetDefault("ApiServerConfig.TRACING_SAMPLE_RATIO", 1.0)
 
 	// Set default values for sql db configuration
 	viper.SetDefault("DatasourceConfig.SqlDBConfig.SQL_HOST", "localhost")
@@ -58,4 +81,5 @@ func makeDefaultAppConfig() {
 	viper.SetDefault("DatasourceConfig.SqlDBConfig.SQL_PASSWORD", "secret")
 	viper.SetDefault("DatasourceConfig.SqlDBConfig.SQL_PORT", 5432)
 	viper.SetDefault("DatasourceConfig.SqlDBConfig.SQL_DATABASE", "fundo")
+	viper.SetDefault("DatasourceConfig.SqlDBConfig.SQL_MIGRATION_MODE", "check")
 }
@@ -1,6 +1,7 @@
 package datasource
 
 import (
+	"context"
 	"fund-o/api-server/internal/datasource/driver"
 
 	"github.com/rs/zerolog/log"
@@ -13,6 +14,7 @@ type DatasourceConfig struct {
 
 type Datasource interface {
 	GetSqlDB() *gorm.DB
+	Ping(ctx context.Context) error
 	Close() error
 }
 
@@ -37,6 +39,10 @@ func (ds *datasource) GetSqlDB() *gorm.DB {
 	return ds.sql.DB()
 }
 
+func (ds *datasource) Ping(ctx context.Context) error {
+	return ds.sql.Ping(ctx)
+}
+
 func (ds *datasource) Close() error {
 	err := ds.sql.Disconnect()
 	return err
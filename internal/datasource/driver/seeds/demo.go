@@ -0,0 +1,341 @@
+package seeds
+
+import (
+	"fmt"
+	"fund-o/api-server/internal/entity"
+	"fund-o/api-server/pkg/password"
+	"fund-o/api-server/pkg/random"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// DemoPassword is the password of every demo user. It is public, so none of them is an admin
+	// and the demo dataset is only seeded in development and test.
+	DemoPassword = "@Password123"
+	// DemoEmailDomain is the domain of every demo user's email.
+	DemoEmailDomain = "demo.fund-o.local"
+
+	demoSeed         = 20240101
+	demoUserCount    = 12
+	demoCreatorCount = 4
+)
+
+// demoNamespace derives the IDs of demo records, so every run addresses the
+// same rows and re-running only fills in what is missing.
+var demoNamespace = uuid.MustParse("4f1c8a52-3b0e-4b8e-9a55-2f0d6c7e1a90")
+
+var (
+	demoFirstnames = []string{"Anan", "Malee", "James", "Sofia", "Kenji", "Amara", "Lucas", "Nina", "Omar", "Priya", "Tom", "Yui"}
+	demoLastnames  = []string{"Srisuk", "Tanaka", "Miller", "Rossi", "Okafor", "Larsen", "Chen", "Garcia", "Haddad", "Patel", "Novak", "Kim"}
+	demoLocations  = []string{"Bangkok, Thailand", "Chiang Mai, Thailand", "Tokyo, Japan", "Berlin, Germany", "Lisbon, Portugal", "Austin, USA"}
+	demoProjects   = []struct {
+		Title    string
+		SubTitle string
+	}{
+		{"Solar Lantern Kit", "Bring light to off-grid villages with a lantern you can build in an hour"},
+		{"Pocket Synth", "A credit-card sized synthesizer with eight voices and a built-in sequencer"},
+		{"River Cleanup Drone", "An autonomous boat that collects floating plastic before it reaches the sea"},
+		{"Night Market Cookbook", "Sixty street food recipes collected from night markets across Asia"},
+		{"Open Source Braille Printer", "An affordable braille embosser anyone can build from printed parts"},
+		{"Indie Pixel Adventure", "A hand-drawn pixel art adventure about a lighthouse keeper and a lost whale"},
+		{"Community Ceramics Studio", "A shared kiln and wheel studio open to the whole neighbourhood"},
+		{"Graphic Novel: Monsoon", "A 200 page graphic novel about growing up during the rainy season"},
+		{"Urban Beehive Network", "Rooftop hives that track colony health and share data with local farmers"},
+		{"Documentary: Last Ferry", "A feature documentary about the final year of an island ferry service"},
+	}
+	demoPostTitles = []string{"Thank you, backers!", "Production update", "Behind the scenes", "We hit our first milestone", "Shipping schedule"}
+	demoComments   = []string{"Can't wait for this!", "Is international shipping included?", "Love the progress so far.", "Will there be a second batch?", "Great update, thanks for sharing."}
+	demoReplies    = []string{"Thank you for the support!", "Yes, we ship worldwide.", "We'll share more details next week.", "Good question, we're looking into it."}
+	demoMessages   = []string{"Hi! I just backed your project.", "Thanks so much, that means a lot!", "When do you expect the first shipment?", "Around the end of next quarter.", "Sounds great, good luck!"}
+)
+
+// DemoData is a complete demo dataset, ready to be inserted in field order.
+type DemoData struct {
+	Users          []entity.User
+	Projects       []entity.Project
+	Backers        []entity.ProjectBacker
+	Ratings        []entity.ProjectRating
+	Posts          []entity.Post
+	Comments       []entity.Comment
+	Replies        []entity.Reply
+	Channels       []entity.Channel
+	ChannelMembers []ChannelMember
+	Messages       []entity.Message
+}
+
+type ChannelMember struct {
+	ChannelID uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (ChannelMember) TableName() string {
+	return "channel_members"
+}
+
+// DemoSummary counts the rows a demo run inserted; it is all zeros when the
+// dataset was already complete.
+type DemoSummary struct {
+	Users    int64
+	Projects int64
+	Backers  int64
+	Ratings  int64
+	Posts    int64
+	Comments int64
+	Replies  int64
+	Channels int64
+	Messages int64
+}
+
+// NewDemoData generates the demo dataset. The same categories give the same
+// records and IDs; only the dates move along with now.
+func NewDemoData(categories []entity.ProjectCategory, now time.Time) *DemoData {
+	g := random.NewGenerator(demoSeed)
+	data := &DemoData{}
+	today := now.Truncate(24 * time.Hour)
+
+	for i := 0; i < demoUserCount; i++ {
+		role := entity.RoleUser
+		if i > 0 && i <= demoCreatorCount {
+			role = entity.RoleCreator
+		}
+
+		firstname := demoFirstnames[i%len(demoFirstnames)]
+		lastname := demoLastnames[i%len(demoLastnames)]
+		data.Users = append(data.Users, entity.User{
+			Base:            entity.Base{ID: demoID("user", i)},
+			Email:           fmt.Sprintf("%s.%s@%s", strings.ToLower(firstname), strings.ToLower(lastname), DemoEmailDomain),
+			Firstname:       firstname,
+			Lastname:        lastname,
+			DisplayName:     strings.ToLower(firstname) + g.String(4),
+			BirthDate:       time.Date(g.Int(1970, 2004), time.Month(g.Int(1, 13)), g.Int(1, 29), 0, 0, 0, 0, time.UTC),
+			Gender:          entity.Gender(g.Int(1, 4)),
+			IsEmailVerified: true,
+			Role:            role,
+		})
+	}
+
+	var withSubCategories []entity.ProjectCategory
+	for _, category := range categories {
+		if len(category.SubCategories) > 0 {
+			withSubCategories = append(withSubCategories, category)
+		}
+	}
+	if len(withSubCategories) == 0 {
+		return data
+	}
+
+	statuses := []entity.ProjectStatus{entity.ProjectLive, entity.ProjectLive, entity.ProjectLive, entity.ProjectSucceeded, entity.ProjectDraft}
+	for i, seed := range demoProjects {
+		owner := data.Users[1+i%demoCreatorCount]
+		category := withSubCategories[g.Int(0, len(withSubCategories))]
+		subCategory := category.SubCategories[g.Int(0, len(category.SubCategories))]
+		status := statuses[i%len(statuses)]
+
+		startDate := today.AddDate(0, 0, -g.Int(5, 30))
+		endDate := today.AddDate(0, 0, g.Int(10, 60))
+		if status == entity.ProjectSucceeded {
+			startDate = today.AddDate(0, 0, -g.Int(60, 90))
+			endDate = today.AddDate(0, 0, -g.Int(1, 20))
+		} else if status == entity.ProjectDraft {
+			startDate = today.AddDate(0, 0, g.Int(5, 15))
+		}
+
+		project := entity.Project{
+			Base:              entity.Base{ID: demoID("project", i)},
+			ProjectContractID: "0x" + g.Hex(40),
+			Title:             seed.Title,
+			SubTitle:          seed.SubTitle,
+			CategoryID:        category.ID,
+			Description:       seed.SubTitle + ". This is demo data generated by the seed command.",
+			SubCategoryID:     subCategory.ID,
+			Location:          g.Pick(demoLocations),
+			StartDate:         startDate,
+			EndDate:           endDate,
+			Status:            status,
+			Goal:              decimal.NewFromInt(int64(g.Int(5, 50))),
+			Currency:          "ETH",
+			OwnerID:           owner.ID,
+		}
+		data.Projects = append(data.Projects, project)
+
+		if status == entity.ProjectDraft {
+			continue
+		}
+
+		backerCount := g.Int(2, 7)
+		for j := 0; j < backerCount; j++ {
+			backer := data.Users[demoBackerIndex(i+j)]
+			txHash := "0x" + g.Hex(64)
+			data.Backers = append(data.Backers, entity.ProjectBacker{
+				Base:      entity.Base{ID: demoID("backer", i, j)},
+				ProjectID: project.ID,
+				UserID:    backer.ID,
+				Amount:    decimal.NewFromFloat(float64(g.Float32(0.05, 2))).Round(4),
+				TxHash:    &txHash,
+				Status:    entity.BackerConfirmed,
+			})
+
+			if j%2 == 0 {
+				data.Ratings = append(data.Ratings, entity.ProjectRating{
+					Base:      entity.Base{ID: demoID("rating", i, j)},
+					Rating:    float32(g.Int(3, 6)),
+					ProjectID: project.ID,
+					UserID:    backer.ID,
+				})
+			}
+		}
+
+		post := entity.Post{
+			Base:        entity.Base{ID: demoID("post", i)},
+			Title:       g.Pick(demoPostTitles),
+			Description: fmt.Sprintf("News from the %s team", seed.Title),
+			Content:     fmt.Sprintf("Here is what the %s team has been working on since the campaign started.", seed.Title),
+			AuthorID:    owner.ID,
+			ProjectID:   project.ID,
+		}
+		data.Posts = append(data.Posts, post)
+
+		commentCount := g.Int(1, 4)
+		for j := 0; j < commentCount; j++ {
+			comment := entity.Comment{
+				Base:     entity.Base{ID: demoID("comment", i, j)},
+				Content:  g.Pick(demoComments),
+				AuthorID: data.Users[demoBackerIndex(i+j)].ID,
+				PostID:   post.ID,
+			}
+			data.Comments = append(data.Comments, comment)
+
+			data.Replies = append(data.Replies, entity.Reply{
+				Base:      entity.Base{ID: demoID("reply", i, j)},
+				Content:   g.Pick(demoReplies),
+				AuthorID:  owner.ID,
+				CommentID: comment.ID,
+			})
+		}
+	}
+
+	for i := 0; i < demoCreatorCount; i++ {
+		creator := data.Users[1+i]
+		backer := data.Users[demoBackerIndex(i)]
+		channel := entity.Channel{
+			Base: entity.Base{ID: demoID("channel", i)},
+			Name: fmt.Sprintf("%s - %s", creator.DisplayName, backer.DisplayName),
+		}
+		data.Channels = append(data.Channels, channel)
+		data.ChannelMembers = append(data.ChannelMembers,
+			ChannelMember{ChannelID: channel.ID, UserID: creator.ID},
+			ChannelMember{ChannelID: channel.ID, UserID: backer.ID},
+		)
+
+		for j, text := range demoMessages {
+			author := backer
+			if j%2 == 1 {
+				author = creator
+			}
+			data.Messages = append(data.Messages, entity.Message{
+				Base:      entity.Base{ID: demoID("message", i, j), CreatedAt: now.Add(time.Duration(j-len(demoMessages)) * time.Hour)},
+				Text:      &text,
+				ChannelID: channel.ID,
+				AuthorID:  author.ID,
+			})
+		}
+	}
+
+	return data
+}
+
+// Demo seeds the categories and then inserts the demo dataset. Records that
+// already exist are left untouched, so running it twice changes nothing.
+func (s *seeder) Demo() (*DemoSummary, error) {
+	if err := s.Categories(); err != nil {
+		return nil, err
+	}
+
+	var categories []entity.ProjectCategory
+	result := s.db.
+		Preload("SubCategories", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name ASC")
+		}).
+		Order("name ASC").
+		Find(&categories)
+	if result.Error != nil {
+		s.logger.Error().Err(result.Error).Msg("failed to find categories")
+		return nil, result.Error
+	}
+
+	data := NewDemoData(categories, time.Now())
+
+	hashedPassword, err := password.HashPassword(DemoPassword)
+	if err != nil {
+		return nil, err
+	}
+	for i := range data.Users {
+		data.Users[i].HashedPassword = hashedPassword
+	}
+
+	summary := &DemoSummary{}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		steps := []struct {
+			name  string
+			rows  int
+			value interface{}
+			count *int64
+		}{
+			{"users", len(data.Users), &data.Users, &summary.Users},
+			{"projects", len(data.Projects), &data.Projects, &summary.Projects},
+			{"backers", len(data.Backers), &data.Backers, &summary.Backers},
+			{"ratings", len(data.Ratings), &data.Ratings, &summary.Ratings},
+			{"posts", len(data.Posts), &data.Posts, &summary.Posts},
+			{"comments", len(data.Comments), &data.Comments, &summary.Comments},
+			{"replies", len(data.Replies), &data.Replies, &summary.Replies},
+			{"channels", len(data.Channels), &data.Channels, &summary.Channels},
+			{"channel members", len(data.ChannelMembers), &data.ChannelMembers, nil},
+			{"messages", len(data.Messages), &data.Messages, &summary.Messages},
+		}
+
+		for _, step := range steps {
+			if step.rows == 0 {
+				continue
+			}
+			result := tx.
+				Omit(clause.Associations).
+				Clauses(clause.OnConflict{DoNothing: true}).
+				Create(step.value)
+			if result.Error != nil {
+				s.logger.Error().Err(result.Error).Msg("failed to seed demo " + step.name)
+				return result.Error
+			}
+			if step.count != nil {
+				*step.count = result.RowsAffected
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// demoBackerIndex maps n onto the users that are neither the admin nor a
+// creator.
+func demoBackerIndex(n int) int {
+	first := 1 + demoCreatorCount
+	return first + n%(demoUserCount-first)
+}
+
+func demoID(kind string, indexes ...int) uuid.UUID {
+	name := kind
+	for _, index := range indexes {
+		name += fmt.Sprintf("/%d", index)
+	}
+	return uuid.NewSHA1(demoNamespace, []byte(name))
+}
@@ -0,0 +1,88 @@
+package seeds
+
+import (
+	"fund-o/api-server/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func demoCategories() []entity.ProjectCategory {
+	var categories []entity.ProjectCategory
+	for _, seed := range ProjectCategorySeed {
+		category := entity.ProjectCategory{Base: entity.Base{ID: uuid.New()}, Name: seed.Name}
+		for _, subSeed := range seed.SubCategories {
+			category.SubCategories = append(category.SubCategories, entity.ProjectSubCategory{
+				Base:       entity.Base{ID: uuid.New()},
+				Name:       subSeed.Name,
+				CategoryID: category.ID,
+			})
+		}
+		categories = append(categories, category)
+	}
+	return categories
+}
+
+func TestNewDemoData(t *testing.T) {
+	categories := demoCategories()
+	now := time.Now()
+
+	t.Run("Test same input gives same data", func(t *testing.T) {
+		require.Equal(t, NewDemoData(categories, now), NewDemoData(categories, now))
+	})
+
+	t.Run("Test records reference each other", func(t *testing.T) {
+		data := NewDemoData(categories, now)
+		require.NotEmpty(t, data.Users)
+		require.NotEmpty(t, data.Projects)
+		require.NotEmpty(t, data.Backers)
+		require.NotEmpty(t, data.Ratings)
+		require.NotEmpty(t, data.Posts)
+		require.NotEmpty(t, data.Comments)
+		require.NotEmpty(t, data.Replies)
+		require.NotEmpty(t, data.Channels)
+		require.NotEmpty(t, data.Messages)
+
+		users := map[uuid.UUID]bool{}
+		emails := map[string]bool{}
+		for _, user := range data.Users {
+			users[user.ID] = true
+			require.False(t, emails[user.Email], "duplicate email %s", user.Email)
+			emails[user.Email] = true
+		}
+
+		projects := map[uuid.UUID]bool{}
+		for _, project := range data.Projects {
+			projects[project.ID] = true
+			require.True(t, users[project.OwnerID])
+			require.NotEqual(t, uuid.Nil, project.CategoryID)
+			require.NotEqual(t, uuid.Nil, project.SubCategoryID)
+			if project.Status == entity.ProjectLive {
+				require.True(t, project.EndDate.After(now))
+			}
+		}
+
+		for _, backer := range data.Backers {
+			require.True(t, projects[backer.ProjectID])
+			require.True(t, users[backer.UserID])
+		}
+
+		for _, member := range data.ChannelMembers {
+			require.True(t, users[member.UserID])
+		}
+	})
+
+	t.Run("Test no admins", func(t *testing.T) {
+		for _, user := range NewDemoData(categories, now).Users {
+			require.NotEqual(t, entity.RoleAdmin, user.Role, "demo user %s is an admin", user.Email)
+		}
+	})
+
+	t.Run("Test without categories", func(t *testing.T) {
+		data := NewDemoData(nil, now)
+		require.NotEmpty(t, data.Users)
+		require.Empty(t, data.Projects)
+	})
+}
@@ -0,0 +1,100 @@
+package seeds
+
+import (
+	"errors"
+	"fmt"
+	"fund-o/api-server/internal/entity"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type Seeder interface {
+	Categories() error
+	Demo() (*DemoSummary, error)
+	Reset() error
+}
+
+type seeder struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewSeeder(db *gorm.DB) Seeder {
+	logger := log.With().Str("module", "seeder").Logger()
+	return &seeder{db, logger}
+}
+
+// categoriesLockKey identifies the Postgres advisory lock held while seeding
+// categories, so replicas starting at the same time do not insert them twice.
+const categoriesLockKey int64 = 7_240_917_357
+
+// Categories inserts every category and sub category of ProjectCategorySeed
+// that does not exist yet, matching by name, so it is safe to run against a
+// database that already has some or all of them.
+func (s *seeder) Categories() error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", categoriesLockKey).Error; err != nil {
+			s.logger.Error().Err(err).Msg("failed to acquire category seed lock")
+			return err
+		}
+
+		for _, seed := range ProjectCategorySeed {
+			var category entity.ProjectCategory
+			err := tx.Where("name = ?", seed.Name).First(&category).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				category = entity.ProjectCategory{Name: seed.Name}
+				err = tx.Omit("SubCategories").Create(&category).Error
+			}
+			if err != nil {
+				s.logger.Error().Err(err).Msg("failed to seed category: " + seed.Name)
+				return err
+			}
+
+			for _, subSeed := range seed.SubCategories {
+				var subCategory entity.ProjectSubCategory
+				err := tx.Where("category_id = ? AND name = ?", category.ID, subSeed.Name).First(&subCategory).Error
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					err = tx.Create(&entity.ProjectSubCategory{Name: subSeed.Name, CategoryID: category.ID}).Error
+				}
+				if err != nil {
+					s.logger.Error().Err(err).Msg("failed to seed sub category: " + subSeed.Name)
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// Reset empties every table except the migration history, leaving the schema
+// in place for the next seed run.
+func (s *seeder) Reset() error {
+	var tables []string
+	result := s.db.Raw(
+		"SELECT tablename FROM pg_tables WHERE schemaname = current_schema() AND tablename <> ?",
+		"schema_migrations",
+	).Scan(&tables)
+	if result.Error != nil {
+		s.logger.Error().Err(result.Error).Msg("failed to list tables")
+		return result.Error
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+
+	quoted := make([]string, len(tables))
+	for i, table := range tables {
+		quoted[i] = s.db.Statement.Quote(table)
+	}
+
+	if err := s.db.Exec(fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", strings.Join(quoted, ", "))).Error; err != nil {
+		s.logger.Error().Err(err).Msg("failed to truncate tables")
+		return err
+	}
+
+	return nil
+}
@@ -1,10 +1,13 @@
 package driver
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"fund-o/api-server/internal/datasource/driver/seeds"
+	"fund-o/api-server/internal/datasource/migration"
 	"fund-o/api-server/internal/entity"
+	"fund-o/api-server/pkg/metrics"
+	"fund-o/api-server/pkg/tracing"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"gorm.io/driver/postgres"
@@ -19,29 +22,35 @@ type SqlDBConfig struct {
 	SQL_PASSWORD string `mapstructure:"SQL_PASSWORD"`
 	SQL_PORT     int    `mapstructure:"SQL_PORT"`
 	SQL_DATABASE string `mapstructure:"SQL_DATABASE"`
+	// SQL_MIGRATION_MODE decides what happens on connect when migrations
+	// are pending: "check" refuses to start, "apply" runs them and
+	// "ignore" only logs a warning.
+	SQL_MIGRATION_MODE string `mapstructure:"SQL_MIGRATION_MODE"`
 }
 
+const (
+	MigrationModeCheck  = "check"
+	MigrationModeApply  = "apply"
+	MigrationModeIgnore = "ignore"
+)
+
 type SQLContext interface {
 	Connect() error
 	Disconnect() error
+	Ping(ctx context.Context) error
 	DB() *gorm.DB
 }
 
 type sqlContext struct {
-	dsn    string
-	db     *gorm.DB
-	logger zerolog.Logger
+	dsn           string
+	name          string
+	migrationMode string
+	db            *gorm.DB
+	logger        zerolog.Logger
 }
 
 func NewSQLContext(config *SqlDBConfig) SQLContext {
-	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%d sslmode=disable TimeZone=Asia/Bangkok",
-		config.SQL_HOST,
-		config.SQL_USERNAME,
-		config.SQL_PASSWORD,
-		config.SQL_DATABASE,
-		config.SQL_PORT,
-	)
+	dsn := makeDSN(config)
 
 	logger := log.With().
 		Str("context", "sql").
@@ -54,29 +63,70 @@ func NewSQLContext(config *SqlDBConfig) SQLContext {
 			config.SQL_DATABASE,
 		)).Logger()
 
-	return &sqlContext{dsn: dsn, logger: logger}
+	migrationMode := config.SQL_MIGRATION_MODE
+	if migrationMode == "" {
+		migrationMode = MigrationModeCheck
+	}
+
+	return &sqlContext{dsn: dsn, name: config.SQL_DATABASE, migrationMode: migrationMode, logger: logger}
 }
 
-func (sql *sqlContext) Connect() error {
-	sql.logger.Info().Msg("Connecting to SQL database...")
+// OpenSQLDB connects to the database without touching its schema. It is
+// meant for tooling such as the migrate command, which must work while
+// migrations are still pending.
+func OpenSQLDB(config *SqlDBConfig) (*gorm.DB, error) {
+	return openSQLDB(makeDSN(config))
+}
 
-	if sql.dsn == "" {
-		return fmt.Errorf("failed to connect to SQL database: DSN is empty")
+func makeDSN(config *SqlDBConfig) string {
+	return fmt.Sprintf(
+		"host=%s user=%s password=%s dbname=%s port=%d sslmode=disable TimeZone=Asia/Bangkok",
+		config.SQL_HOST,
+		config.SQL_USERNAME,
+		config.SQL_PASSWORD,
+		config.SQL_DATABASE,
+		config.SQL_PORT,
+	)
+}
+
+func openSQLDB(dsn string) (*gorm.DB, error) {
+	if dsn == "" {
+		return nil, fmt.Errorf("failed to connect to SQL database: DSN is empty")
 	}
 
-	db, err := gorm.Open(postgres.New(postgres.Config{
-		DSN: sql.dsn,
+	return gorm.Open(postgres.New(postgres.Config{
+		DSN: dsn,
 	}), &gorm.Config{
 		Logger: gormLogger.Default.LogMode(gormLogger.Silent),
+		// Constraint violations come back as gorm.ErrDuplicatedKey and
+		// gorm.ErrForeignKeyViolated instead of driver specific errors.
+		TranslateError: true,
 	})
+}
+
+func (sql *sqlContext) Connect() error {
+	sql.logger.Info().Msg("Connecting to SQL database...")
+
+	db, err := openSQLDB(sql.dsn)
 	if err != nil {
 		return err
 	}
 	sql.logger.Info().Msg("Connecting to SQL database completed")
 
+	if err := db.Use(metrics.NewGormPlugin(sql.name)); err != nil {
+		return err
+	}
+	if err := db.Use(tracing.NewGormPlugin(sql.name)); err != nil {
+		return err
+	}
+
 	sql.db = db
 
-	if err := sql.autoMigrateUp(); err != nil {
+	if err := sql.migrate(); err != nil {
+		return err
+	}
+
+	if err := sql.seed(); err != nil {
 		return err
 	}
 
@@ -102,39 +152,63 @@ func (sql *sqlContext) Disconnect() error {
 	return nil
 }
 
+func (sql *sqlContext) Ping(ctx context.Context) error {
+	if sql.db == nil {
+		return fmt.Errorf("SQL database is not connected")
+	}
+
+	db, err := sql.db.DB()
+	if err != nil {
+		return err
+	}
+
+	return db.PingContext(ctx)
+}
+
 func (sql *sqlContext) DB() *gorm.DB {
 	return sql.db
 }
 
-func (sql *sqlContext) autoMigrateUp() error {
-	db := sql.db
-	if err := db.AutoMigrate(
-		&entity.User{},
-		&entity.Session{},
-		&entity.Project{},
-		&entity.ProjectCategory{},
-		&entity.ProjectSubCategory{},
-		&entity.ProjectRating{},
-		&entity.ProjectBacker{},
-		&entity.VerifyEmail{},
-		&entity.Post{},
-		&entity.Comment{},
-		&entity.Reply{},
-		&entity.Channel{},
-		&entity.Message{},
-	); err != nil {
+func (sql *sqlContext) migrate() error {
+	migrations, err := migration.Load(migration.Files)
+	if err != nil {
 		return err
 	}
+	migrator := migration.NewMigrator(sql.db, migrations)
 
-	if err := db.AutoMigrate(&entity.ProjectCategory{}); err == nil && db.Migrator().HasTable(&entity.ProjectCategory{}) {
-		if err := db.First(&entity.ProjectCategory{}).Error; errors.Is(err, gorm.ErrRecordNotFound) {
-			for _, category := range seeds.ProjectCategorySeed {
-				if result := db.Create(&category); result.Error != nil {
-					return result.Error
-				}
-			}
+	switch sql.migrationMode {
+	case MigrationModeApply:
+		applied, err := migrator.Up()
+		if err != nil {
+			return err
+		}
+		sql.logger.Info().Int("applied", len(applied)).Msg("SQL migrations are up to date")
+		return nil
+	case MigrationModeCheck, MigrationModeIgnore:
+		pending, err := migrator.Pending()
+		if err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			return nil
 		}
+		if sql.migrationMode == MigrationModeIgnore {
+			sql.logger.Warn().Int("pending", len(pending)).Str("next", pending[0].String()).Msg("SQL migrations are pending")
+			return nil
+		}
+		return fmt.Errorf("%d SQL migrations are pending, starting with %s: run the migrate command or set SQL_MIGRATION_MODE", len(pending), pending[0])
+	default:
+		return fmt.Errorf("unknown SQL migration mode: %s", sql.migrationMode)
 	}
+}
 
-	return nil
+// seed loads the project categories the application cannot work without. It
+// only adds the missing ones, so it runs on every connect; the demo dataset is
+// left to the seed command.
+func (sql *sqlContext) seed() error {
+	if !sql.db.Migrator().HasTable(&entity.ProjectCategory{}) {
+		return nil
+	}
+
+	return seeds.NewSeeder(sql.db).Categories()
 }
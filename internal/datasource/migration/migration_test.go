@@ -0,0 +1,103 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("Test embedded migrations", func(t *testing.T) {
+		migrations, err := Load(Files)
+		require.NoError(t, err)
+		require.NotEmpty(t, migrations)
+		require.Equal(t, "init_schema", migrations[0].Name)
+	})
+
+	t.Run("Test migrations are ordered by version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"sql/20240201000000_add_index.up.sql":      {Data: []byte("CREATE INDEX a ON b (c);")},
+			"sql/20240201000000_add_index.down.sql":    {Data: []byte("DROP INDEX a;")},
+			"sql/20240101000000_create_table.up.sql":   {Data: []byte("CREATE TABLE b (c int);")},
+			"sql/20240101000000_create_table.down.sql": {Data: []byte("DROP TABLE b;")},
+		}
+
+		migrations, err := Load(fsys)
+		require.NoError(t, err)
+		require.Len(t, migrations, 2)
+		require.Equal(t, int64(20240101000000), migrations[0].Version)
+		require.Equal(t, "create_table", migrations[0].Name)
+		require.Equal(t, "DROP TABLE b;", migrations[0].Down)
+		require.Equal(t, int64(20240201000000), migrations[1].Version)
+	})
+
+	t.Run("Test empty migration files", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"sql/20240101000000_noop.up.sql":   {Data: []byte{}},
+			"sql/20240101000000_noop.down.sql": {Data: []byte{}},
+		}
+
+		migrations, err := Load(fsys)
+		require.NoError(t, err)
+		require.Len(t, migrations, 1)
+	})
+
+	t.Run("Test missing down file", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"sql/20240101000000_create_table.up.sql": {Data: []byte("CREATE TABLE b (c int);")},
+		}
+
+		_, err := Load(fsys)
+		require.Error(t, err)
+	})
+
+	t.Run("Test invalid file name", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"sql/create_table.sql": {Data: []byte("CREATE TABLE b (c int);")},
+		}
+
+		_, err := Load(fsys)
+		require.Error(t, err)
+	})
+
+	t.Run("Test conflicting names", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"sql/20240101000000_create_table.up.sql": {Data: []byte("CREATE TABLE b (c int);")},
+			"sql/20240101000000_drop_table.down.sql": {Data: []byte("DROP TABLE b;")},
+		}
+
+		_, err := Load(fsys)
+		require.Error(t, err)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	t.Run("Test create migration pair", func(t *testing.T) {
+		upPath, downPath, err := Create(dir, "add_users_phone", now)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "20240304050607_add_users_phone.up.sql"), upPath)
+		require.Equal(t, filepath.Join(dir, "20240304050607_add_users_phone.down.sql"), downPath)
+
+		_, err = os.Stat(upPath)
+		require.NoError(t, err)
+		_, err = os.Stat(downPath)
+		require.NoError(t, err)
+	})
+
+	t.Run("Test existing migration", func(t *testing.T) {
+		_, _, err := Create(dir, "add_users_phone", now)
+		require.Error(t, err)
+	})
+
+	t.Run("Test invalid name", func(t *testing.T) {
+		_, _, err := Create(dir, "Add Users Phone", now)
+		require.Error(t, err)
+	})
+}
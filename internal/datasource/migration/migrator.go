@@ -0,0 +1,211 @@
+package migration
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// lockKey identifies the Postgres advisory lock taken while migrating, so
+// replicas starting at the same time apply migrations one after another.
+const lockKey int64 = 7_240_917_356
+
+type SchemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type Migrator interface {
+	Up() ([]Migration, error)
+	Down(steps int) ([]Migration, error)
+	Status() ([]Status, error)
+	Pending() ([]Migration, error)
+}
+
+type migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+	logger     zerolog.Logger
+}
+
+func NewMigrator(db *gorm.DB, migrations []Migration) Migrator {
+	logger := log.With().Str("module", "migrator").Logger()
+	return &migrator{db, migrations, logger}
+}
+
+// Up applies every pending migration in version order. Each migration runs in
+// its own transaction, so a failure leaves earlier ones applied.
+func (m *migrator) Up() ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(func(conn *gorm.DB) error {
+		versions, err := m.appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+
+			m.logger.Info().Str("migration", migration.String()).Msg("applying migration")
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := exec(tx, migration.Up); err != nil {
+					return err
+				}
+				return tx.Create(&SchemaMigration{
+					Version:   migration.Version,
+					Name:      migration.Name,
+					AppliedAt: time.Now(),
+				}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("failed to apply migration %s: %w", migration, err)
+			}
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the latest steps applied migrations, newest first.
+func (m *migrator) Down(steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("invalid number of migrations to revert: %d", steps)
+	}
+
+	byVersion := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	var reverted []Migration
+	err := m.withLock(func(conn *gorm.DB) error {
+		var records []SchemaMigration
+		if err := conn.Order("version DESC").Limit(steps).Find(&records).Error; err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			migration, ok := byVersion[record.Version]
+			if !ok {
+				return fmt.Errorf("applied migration %d_%s is not known to this binary", record.Version, record.Name)
+			}
+
+			m.logger.Info().Str("migration", migration.String()).Msg("reverting migration")
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := exec(tx, migration.Down); err != nil {
+					return err
+				}
+				return tx.Delete(&SchemaMigration{}, "version = ?", migration.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("failed to revert migration %s: %w", migration, err)
+			}
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status lists every known migration together with when it was applied, if
+// at all.
+func (m *migrator) Status() ([]Status, error) {
+	versions, err := m.appliedVersions(m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Migration: migration}
+		if appliedAt, ok := versions[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (m *migrator) Pending() ([]Migration, error) {
+	statuses, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending = append(pending, status.Migration)
+		}
+	}
+
+	return pending, nil
+}
+
+// withLock runs fn on a single pooled connection holding the advisory lock.
+// The lock is session scoped, so it has to be taken and released on the
+// same connection the migrations run on.
+func (m *migrator) withLock(fn func(conn *gorm.DB) error) error {
+	return m.db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", lockKey).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		defer func() {
+			if err := conn.Exec("SELECT pg_advisory_unlock(?)", lockKey).Error; err != nil {
+				m.logger.Error().Err(err).Msg("failed to release migration lock")
+			}
+		}()
+
+		if !conn.Migrator().HasTable(&SchemaMigration{}) {
+			if err := conn.Migrator().CreateTable(&SchemaMigration{}); err != nil {
+				return err
+			}
+		}
+
+		return fn(conn)
+	})
+}
+
+func (m *migrator) appliedVersions(db *gorm.DB) (map[int64]time.Time, error) {
+	versions := map[int64]time.Time{}
+	if !db.Migrator().HasTable(&SchemaMigration{}) {
+		return versions, nil
+	}
+
+	var records []SchemaMigration
+	if err := db.Find(&records).Error; err != nil {
+		m.logger.Error().Err(err).Msg("failed to find applied migrations")
+		return nil, err
+	}
+
+	for _, record := range records {
+		versions[record.Version] = record.AppliedAt
+	}
+
+	return versions, nil
+}
+
+func exec(tx *gorm.DB, sql string) error {
+	if strings.TrimSpace(sql) == "" {
+		return nil
+	}
+	return tx.Exec(sql).Error
+}
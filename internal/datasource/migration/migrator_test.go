@@ -0,0 +1,316 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// recordingDriver stands in for Postgres. It keeps the schema_migrations rows in memory, records
+// every other statement in the order it ran and fails statements containing "FAIL".
+type recordingDriver struct {
+	mu         sync.Mutex
+	statements []string
+	hasTable   bool
+	records    map[int64]SchemaMigration
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) {
+	return &recordingConn{d}, nil
+}
+
+func (d *recordingDriver) record(statement string) {
+	d.statements = append(d.statements, statement)
+}
+
+type recordingConn struct {
+	driver *recordingDriver
+}
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *recordingConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+	c.driver.record("BEGIN")
+	return &recordingTx{c.driver}, nil
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	d := c.driver
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(query, "SELECT pg_advisory_lock"):
+		d.record("LOCK")
+	case strings.HasPrefix(query, "SELECT pg_advisory_unlock"):
+		d.record("UNLOCK")
+	case strings.HasPrefix(query, `CREATE TABLE "schema_migrations"`):
+		d.record("CREATE schema_migrations")
+		d.hasTable = true
+	case strings.HasPrefix(query, `INSERT INTO "schema_migrations"`):
+		record := SchemaMigration{
+			Version:   args[0].Value.(int64),
+			Name:      args[1].Value.(string),
+			AppliedAt: args[2].Value.(time.Time),
+		}
+		d.record("INSERT " + record.Name)
+		d.records[record.Version] = record
+	case strings.HasPrefix(query, `DELETE FROM "schema_migrations"`):
+		version := args[0].Value.(int64)
+		d.record("DELETE " + d.records[version].Name)
+		delete(d.records, version)
+	case strings.Contains(query, "FAIL"):
+		return nil, errors.New("syntax error")
+	default:
+		d.record(query)
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+func (c *recordingConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	d := c.driver
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if strings.Contains(query, "information_schema.tables") {
+		count := int64(0)
+		if d.hasTable {
+			count = 1
+		}
+		return &recordingRows{columns: []string{"count"}, values: [][]driver.Value{{count}}}, nil
+	}
+
+	if !strings.Contains(query, `FROM "schema_migrations"`) {
+		return nil, errors.New("unexpected query: " + query)
+	}
+
+	records := make([]SchemaMigration, 0, len(d.records))
+	for _, record := range d.records {
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Version < records[j].Version })
+	if strings.Contains(query, "ORDER BY version DESC") {
+		sort.Slice(records, func(i, j int) bool { return records[i].Version > records[j].Version })
+	}
+	if strings.Contains(query, "LIMIT") {
+		limit := int(args[len(args)-1].Value.(int64))
+		if limit < len(records) {
+			records = records[:limit]
+		}
+	}
+
+	rows := &recordingRows{columns: []string{"version", "name", "applied_at"}}
+	for _, record := range records {
+		rows.values = append(rows.values, []driver.Value{record.Version, record.Name, record.AppliedAt})
+	}
+	return rows, nil
+}
+
+type recordingTx struct {
+	driver *recordingDriver
+}
+
+func (tx *recordingTx) Commit() error {
+	tx.driver.mu.Lock()
+	defer tx.driver.mu.Unlock()
+	tx.driver.record("COMMIT")
+	return nil
+}
+
+func (tx *recordingTx) Rollback() error {
+	tx.driver.mu.Lock()
+	defer tx.driver.mu.Unlock()
+	tx.driver.record("ROLLBACK")
+	return nil
+}
+
+type recordingRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *recordingRows) Columns() []string {
+	return r.columns
+}
+
+func (r *recordingRows) Close() error {
+	return nil
+}
+
+func (r *recordingRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func newRecordingDB(t *testing.T) (*gorm.DB, *recordingDriver) {
+	recorder := &recordingDriver{records: map[int64]SchemaMigration{}}
+	sqlDB := sql.OpenDB(driverConnector{recorder})
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	return db, recorder
+}
+
+type driverConnector struct {
+	driver *recordingDriver
+}
+
+func (c driverConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open("")
+}
+
+func (c driverConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+func TestMigrator(t *testing.T) {
+	migrations := []Migration{
+		{Version: 20240101000000, Name: "create_table", Up: "CREATE TABLE b (c int);", Down: "DROP TABLE b;"},
+		{Version: 20240201000000, Name: "noop"},
+		{Version: 20240301000000, Name: "add_index", Up: "CREATE INDEX a ON b (c);", Down: "DROP INDEX a;"},
+	}
+
+	t.Run("Test up applies pending migrations in order", func(t *testing.T) {
+		db, recorder := newRecordingDB(t)
+		migrator := NewMigrator(db, migrations)
+
+		applied, err := migrator.Up()
+		require.NoError(t, err)
+		require.Equal(t, migrations, applied)
+		require.Equal(t, []string{
+			"LOCK",
+			"CREATE schema_migrations",
+			"BEGIN", "CREATE TABLE b (c int);", "INSERT create_table", "COMMIT",
+			"BEGIN", "INSERT noop", "COMMIT",
+			"BEGIN", "CREATE INDEX a ON b (c);", "INSERT add_index", "COMMIT",
+			"UNLOCK",
+		}, recorder.statements)
+
+		pending, err := migrator.Pending()
+		require.NoError(t, err)
+		require.Empty(t, pending)
+	})
+
+	t.Run("Test up skips applied migrations", func(t *testing.T) {
+		db, recorder := newRecordingDB(t)
+		_, err := NewMigrator(db, migrations[:1]).Up()
+		require.NoError(t, err)
+		recorder.statements = nil
+
+		migrator := NewMigrator(db, migrations)
+		applied, err := migrator.Up()
+		require.NoError(t, err)
+		require.Equal(t, migrations[1:], applied)
+		require.Equal(t, []string{
+			"LOCK",
+			"BEGIN", "INSERT noop", "COMMIT",
+			"BEGIN", "CREATE INDEX a ON b (c);", "INSERT add_index", "COMMIT",
+			"UNLOCK",
+		}, recorder.statements)
+
+		statuses, err := migrator.Status()
+		require.NoError(t, err)
+		require.Len(t, statuses, 3)
+		for _, status := range statuses {
+			require.NotNil(t, status.AppliedAt)
+		}
+	})
+
+	t.Run("Test up stops at a failing migration", func(t *testing.T) {
+		failing := append(append([]Migration{}, migrations[:1]...),
+			Migration{Version: 20240201000000, Name: "broken", Up: "FAIL", Down: ""},
+			migrations[2],
+		)
+
+		db, recorder := newRecordingDB(t)
+		migrator := NewMigrator(db, failing)
+		applied, err := migrator.Up()
+		require.Error(t, err)
+		require.Equal(t, failing[:1], applied)
+		require.Equal(t, []string{
+			"LOCK",
+			"CREATE schema_migrations",
+			"BEGIN", "CREATE TABLE b (c int);", "INSERT create_table", "COMMIT",
+			"BEGIN", "ROLLBACK",
+			"UNLOCK",
+		}, recorder.statements)
+
+		pending, err := migrator.Pending()
+		require.NoError(t, err)
+		require.Equal(t, failing[1:], pending)
+	})
+
+	t.Run("Test down reverts the latest migrations newest first", func(t *testing.T) {
+		db, recorder := newRecordingDB(t)
+		migrator := NewMigrator(db, migrations)
+		_, err := migrator.Up()
+		require.NoError(t, err)
+		recorder.statements = nil
+
+		reverted, err := migrator.Down(2)
+		require.NoError(t, err)
+		require.Equal(t, []Migration{migrations[2], migrations[1]}, reverted)
+		require.Equal(t, []string{
+			"LOCK",
+			"BEGIN", "DROP INDEX a;", "DELETE add_index", "COMMIT",
+			"BEGIN", "DELETE noop", "COMMIT",
+			"UNLOCK",
+		}, recorder.statements)
+
+		pending, err := migrator.Pending()
+		require.NoError(t, err)
+		require.Equal(t, migrations[1:], pending)
+	})
+
+	t.Run("Test down rejects unknown migrations", func(t *testing.T) {
+		db, recorder := newRecordingDB(t)
+		_, err := NewMigrator(db, migrations).Up()
+		require.NoError(t, err)
+		recorder.statements = nil
+
+		_, err = NewMigrator(db, migrations[:2]).Down(1)
+		require.Error(t, err)
+		require.Equal(t, []string{"LOCK", "UNLOCK"}, recorder.statements)
+		require.Len(t, recorder.records, 3)
+	})
+
+	t.Run("Test down with invalid steps", func(t *testing.T) {
+		db, recorder := newRecordingDB(t)
+		_, err := NewMigrator(db, migrations).Down(0)
+		require.Error(t, err)
+		require.Empty(t, recorder.statements)
+	})
+}
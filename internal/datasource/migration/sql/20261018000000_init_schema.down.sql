@@ -0,0 +1,27 @@
+DROP TABLE IF EXISTS "messages";
+DROP TABLE IF EXISTS "channel_members";
+DROP TABLE IF EXISTS "channels";
+DROP TABLE IF EXISTS "replies";
+DROP TABLE IF EXISTS "comments";
+DROP TABLE IF EXISTS "posts";
+DROP TABLE IF EXISTS "data_exports";
+DROP TABLE IF EXISTS "email_changes";
+DROP TABLE IF EXISTS "password_resets";
+DROP TABLE IF EXISTS "verify_emails";
+DROP TABLE IF EXISTS "chain_cursors";
+DROP TABLE IF EXISTS "chain_events";
+DROP TABLE IF EXISTS "project_updates";
+DROP TABLE IF EXISTS "project_refunds";
+DROP TABLE IF EXISTS "project_backers";
+DROP TABLE IF EXISTS "project_rewards";
+DROP TABLE IF EXISTS "project_ratings";
+DROP TABLE IF EXISTS "projects";
+DROP TABLE IF EXISTS "project_sub_categories";
+DROP TABLE IF EXISTS "project_categories";
+DROP TABLE IF EXISTS "sessions";
+DROP TABLE IF EXISTS "api_keys";
+DROP TABLE IF EXISTS "user_recovery_codes";
+DROP TABLE IF EXISTS "user_totps";
+DROP TABLE IF EXISTS "user_identities";
+DROP TABLE IF EXISTS "user_wallets";
+DROP TABLE IF EXISTS "users";
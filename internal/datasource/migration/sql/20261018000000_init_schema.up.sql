@@ -0,0 +1,445 @@
+-- Databases created by AutoMigrate before this migration already have the older tables, which
+-- CREATE TABLE IF NOT EXISTS leaves untouched. The ALTER TABLE statements following those tables
+-- bring them up to date; on a fresh database they do nothing.
+
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "email" text NOT NULL,
+    "hashed_password" text NOT NULL,
+    "firstname" text NOT NULL,
+    "lastname" text NOT NULL,
+    "display_name" text NOT NULL,
+    "profile_image" text,
+    "birth_date" timestamptz NOT NULL,
+    "gender" bigint NOT NULL DEFAULT 3,
+    "is_email_verified" boolean NOT NULL DEFAULT false,
+    "role" bigint NOT NULL DEFAULT 1,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_email" ON "users" ("email");
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "role" bigint NOT NULL DEFAULT 1;
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "user_wallets" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" uuid NOT NULL,
+    "address" varchar(42) NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_users_wallets" FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_wallets_address" ON "user_wallets" ("address");
+CREATE INDEX IF NOT EXISTS "idx_user_wallets_user_id" ON "user_wallets" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_user_wallets_deleted_at" ON "user_wallets" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "user_identities" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" uuid NOT NULL,
+    "provider" varchar(32) NOT NULL,
+    "subject" text NOT NULL,
+    "email" text NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_users_identities" FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_user_identities_user_id" ON "user_identities" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_user_identities_deleted_at" ON "user_identities" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_identity_subject" ON "user_identities" ("provider","subject");
+
+CREATE TABLE IF NOT EXISTS "user_totps" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" text NOT NULL,
+    "secret" text NOT NULL,
+    "confirmed_at" timestamptz,
+    "last_used_step" bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_totps_user_id" ON "user_totps" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_user_totps_deleted_at" ON "user_totps" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "user_recovery_codes" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" text NOT NULL,
+    "hashed_code" text NOT NULL,
+    "used_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_user_recovery_codes_user_id" ON "user_recovery_codes" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_user_recovery_codes_deleted_at" ON "user_recovery_codes" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "api_keys" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" uuid NOT NULL,
+    "name" text NOT NULL,
+    "prefix" text NOT NULL,
+    "hashed_key" text NOT NULL,
+    "scopes" text NOT NULL,
+    "last_used_at" timestamptz,
+    "expired_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_api_keys_user" FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_api_keys_hashed_key" ON "api_keys" ("hashed_key");
+CREATE INDEX IF NOT EXISTS "idx_api_keys_user_id" ON "api_keys" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_api_keys_deleted_at" ON "api_keys" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "sessions" (
+    "id" uuid,
+    "user_id" text NOT NULL,
+    "family_id" uuid,
+    "parent_id" uuid,
+    "refresh_token" text NOT NULL,
+    "user_agent" text NOT NULL,
+    "client_ip" text NOT NULL,
+    "is_blocked" boolean NOT NULL DEFAULT false,
+    "rotated_at" timestamptz,
+    "expired_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+ALTER TABLE "sessions"
+    ADD COLUMN IF NOT EXISTS "family_id" uuid,
+    ADD COLUMN IF NOT EXISTS "parent_id" uuid,
+    ADD COLUMN IF NOT EXISTS "rotated_at" timestamptz;
+-- A session issued before refresh tokens were rotated is the only member of its family.
+UPDATE "sessions" SET "family_id" = "id" WHERE "family_id" IS NULL;
+CREATE INDEX IF NOT EXISTS "idx_sessions_family_id" ON "sessions" ("family_id");
+
+CREATE TABLE IF NOT EXISTS "project_categories" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" varchar(255) NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_project_categories_deleted_at" ON "project_categories" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "project_sub_categories" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" varchar(255) NOT NULL,
+    "category_id" uuid,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_project_categories_sub_categories" FOREIGN KEY ("category_id") REFERENCES "project_categories"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_project_sub_categories_deleted_at" ON "project_sub_categories" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "projects" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "project_contract_id" varchar(255) NOT NULL,
+    "title" varchar(255) NOT NULL,
+    "sub_title" text NOT NULL,
+    "category_id" uuid NOT NULL,
+    "description" text,
+    "sub_category_id" uuid,
+    "location" text NOT NULL,
+    "image" text,
+    "start_date" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "end_date" timestamptz NOT NULL,
+    "status" bigint NOT NULL DEFAULT 3,
+    "goal" decimal(32,16) NOT NULL DEFAULT '0',
+    "currency" varchar(16) NOT NULL DEFAULT 'ETH',
+    "owner_id" uuid NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_projects_category" FOREIGN KEY ("category_id") REFERENCES "project_categories"("id"),
+    CONSTRAINT "fk_projects_sub_category" FOREIGN KEY ("sub_category_id") REFERENCES "project_sub_categories"("id"),
+    CONSTRAINT "fk_projects_owner" FOREIGN KEY ("owner_id") REFERENCES "users"("id")
+);
+-- Projects created before the campaign lifecycle were already open to contributions, so they
+-- start live; the goal and currency take the same defaults as a new project.
+ALTER TABLE "projects"
+    ADD COLUMN IF NOT EXISTS "status" bigint NOT NULL DEFAULT 3,
+    ADD COLUMN IF NOT EXISTS "goal" decimal(32,16) NOT NULL DEFAULT '0',
+    ADD COLUMN IF NOT EXISTS "currency" varchar(16) NOT NULL DEFAULT 'ETH';
+CREATE INDEX IF NOT EXISTS "idx_projects_status" ON "projects" ("status");
+CREATE INDEX IF NOT EXISTS "idx_projects_deleted_at" ON "projects" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "project_ratings" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "rating" decimal NOT NULL,
+    "project_id" uuid,
+    "user_id" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_projects_ratings" FOREIGN KEY ("project_id") REFERENCES "projects"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_project_ratings_deleted_at" ON "project_ratings" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "project_rewards" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "project_id" text NOT NULL,
+    "title" varchar(255) NOT NULL,
+    "description" text,
+    "minimum_amount" decimal(32,16) NOT NULL,
+    "estimated_delivery" timestamptz NOT NULL,
+    "quantity_limit" bigint,
+    "quantity_claimed" bigint NOT NULL DEFAULT 0,
+    "requires_shipping" boolean NOT NULL DEFAULT false,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_project_rewards_project_id" ON "project_rewards" ("project_id");
+CREATE INDEX IF NOT EXISTS "idx_project_rewards_deleted_at" ON "project_rewards" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "project_backers" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "project_id" uuid,
+    "user_id" text,
+    "amount" decimal(32,16),
+    "reward_id" uuid,
+    "tx_hash" varchar(66),
+    "status" bigint NOT NULL DEFAULT 2,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_project_backers_reward" FOREIGN KEY ("reward_id") REFERENCES "project_rewards"("id"),
+    CONSTRAINT "fk_projects_backers" FOREIGN KEY ("project_id") REFERENCES "projects"("id")
+);
+-- Backers recorded before contributions were verified on chain count as confirmed.
+ALTER TABLE "project_backers"
+    ADD COLUMN IF NOT EXISTS "reward_id" uuid,
+    ADD COLUMN IF NOT EXISTS "tx_hash" varchar(66),
+    ADD COLUMN IF NOT EXISTS "status" bigint NOT NULL DEFAULT 2;
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_project_backers_reward') THEN
+        ALTER TABLE "project_backers" ADD CONSTRAINT "fk_project_backers_reward"
+            FOREIGN KEY ("reward_id") REFERENCES "project_rewards"("id");
+    END IF;
+END $$;
+CREATE INDEX IF NOT EXISTS "idx_project_backers_deleted_at" ON "project_backers" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_project_backers_status" ON "project_backers" ("status");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_project_backers_tx_hash" ON "project_backers" ("tx_hash");
+
+CREATE TABLE IF NOT EXISTS "project_refunds" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "backer_id" uuid NOT NULL,
+    "project_id" text NOT NULL,
+    "user_id" text NOT NULL,
+    "amount" decimal(32,16) NOT NULL,
+    "status" bigint NOT NULL DEFAULT 1,
+    "chain_event_id" uuid,
+    "tx_hash" varchar(66),
+    "failure_reason" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_project_refunds_backer" FOREIGN KEY ("backer_id") REFERENCES "project_backers"("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_project_refunds_chain_event_id" ON "project_refunds" ("chain_event_id");
+CREATE INDEX IF NOT EXISTS "idx_project_refunds_status" ON "project_refunds" ("status");
+CREATE INDEX IF NOT EXISTS "idx_project_refunds_user_id" ON "project_refunds" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_project_refunds_project_id" ON "project_refunds" ("project_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_project_refunds_backer_id" ON "project_refunds" ("backer_id");
+CREATE INDEX IF NOT EXISTS "idx_project_refunds_deleted_at" ON "project_refunds" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "project_updates" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "project_id" text NOT NULL,
+    "author_id" uuid NOT NULL,
+    "title" varchar(255) NOT NULL,
+    "body" text NOT NULL,
+    "visibility" bigint NOT NULL DEFAULT 1,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_project_updates_author" FOREIGN KEY ("author_id") REFERENCES "users"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_project_updates_deleted_at" ON "project_updates" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_project_updates_project_id" ON "project_updates" ("project_id");
+
+CREATE TABLE IF NOT EXISTS "chain_events" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "type" bigint NOT NULL,
+    "contract_address" varchar(42) NOT NULL,
+    "account" varchar(42) NOT NULL,
+    "amount" decimal(32,16) NOT NULL DEFAULT '0',
+    "tx_hash" varchar(66) NOT NULL,
+    "log_index" bigint NOT NULL,
+    "block_number" bigint NOT NULL,
+    "block_hash" varchar(66) NOT NULL,
+    "confirmed" boolean NOT NULL DEFAULT false,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_chain_events_deleted_at" ON "chain_events" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_chain_events_confirmed" ON "chain_events" ("confirmed");
+CREATE INDEX IF NOT EXISTS "idx_chain_events_block_number" ON "chain_events" ("block_number");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_chain_event_log" ON "chain_events" ("tx_hash","log_index");
+CREATE INDEX IF NOT EXISTS "idx_chain_events_contract_address" ON "chain_events" ("contract_address");
+CREATE INDEX IF NOT EXISTS "idx_chain_events_type" ON "chain_events" ("type");
+
+CREATE TABLE IF NOT EXISTS "chain_cursors" (
+    "name" varchar(64),
+    "block_number" bigint NOT NULL,
+    "block_hash" varchar(66) NOT NULL,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("name")
+);
+
+CREATE TABLE IF NOT EXISTS "verify_emails" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "email" text NOT NULL,
+    "secret_code" text NOT NULL,
+    "is_used" boolean NOT NULL DEFAULT false,
+    "expired_at" timestamptz NOT NULL DEFAULT now() + interval '15 minutes',
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_verify_emails_deleted_at" ON "verify_emails" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "password_resets" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" text NOT NULL,
+    "email" text NOT NULL,
+    "secret_code_hash" text NOT NULL,
+    "is_used" boolean NOT NULL DEFAULT false,
+    "expired_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_password_resets_user_id" ON "password_resets" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_password_resets_deleted_at" ON "password_resets" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "email_changes" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" text NOT NULL,
+    "new_email" text NOT NULL,
+    "secret_code_hash" text NOT NULL,
+    "is_used" boolean NOT NULL DEFAULT false,
+    "expired_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_email_changes_user_id" ON "email_changes" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_email_changes_deleted_at" ON "email_changes" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "data_exports" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "user_id" text NOT NULL,
+    "status" bigint NOT NULL DEFAULT 1,
+    "archive" bytea,
+    "secret_code_hash" text,
+    "expired_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_data_exports_user_id" ON "data_exports" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_data_exports_deleted_at" ON "data_exports" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "posts" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "title" varchar(255) NOT NULL,
+    "description" varchar(255) NOT NULL,
+    "content" text NOT NULL,
+    "author_id" uuid NOT NULL,
+    "project_id" uuid NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_posts_author" FOREIGN KEY ("author_id") REFERENCES "users"("id"),
+    CONSTRAINT "fk_posts_project" FOREIGN KEY ("project_id") REFERENCES "projects"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_posts_deleted_at" ON "posts" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "comments" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "content" varchar(255) NOT NULL,
+    "author_id" uuid,
+    "post_id" uuid,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_comments_author" FOREIGN KEY ("author_id") REFERENCES "users"("id"),
+    CONSTRAINT "fk_posts_comments" FOREIGN KEY ("post_id") REFERENCES "posts"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_comments_deleted_at" ON "comments" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "replies" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "content" varchar(255) NOT NULL,
+    "author_id" uuid,
+    "comment_id" uuid,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_comments_replies" FOREIGN KEY ("comment_id") REFERENCES "comments"("id"),
+    CONSTRAINT "fk_replies_author" FOREIGN KEY ("author_id") REFERENCES "users"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_replies_deleted_at" ON "replies" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "channels" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "name" varchar(255) NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_channels_deleted_at" ON "channels" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "channel_members" (
+    "channel_id" uuid DEFAULT uuid_generate_v4(),
+    "user_id" uuid DEFAULT uuid_generate_v4(),
+    PRIMARY KEY ("channel_id","user_id"),
+    CONSTRAINT "fk_channel_members_channel" FOREIGN KEY ("channel_id") REFERENCES "channels"("id"),
+    CONSTRAINT "fk_channel_members_user" FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "messages" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "text" text,
+    "attachment" text,
+    "channel_id" uuid,
+    "author_id" uuid,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_messages_author" FOREIGN KEY ("author_id") REFERENCES "users"("id"),
+    CONSTRAINT "fk_channels_messages" FOREIGN KEY ("channel_id") REFERENCES "channels"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_messages_deleted_at" ON "messages" ("deleted_at");
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"fund-o/api-server/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type ApiKeyRepository interface {
+	Create(apiKey *entity.ApiKey) (*entity.ApiKey, error)
+	FindByUserID(userID uuid.UUID) ([]entity.ApiKey, error)
+	FindByHashedKey(hashedKey string) (*entity.ApiKey, error)
+	Delete(id uuid.UUID, userID uuid.UUID) (bool, error)
+	UpdateLastUsedAt(id uuid.UUID, lastUsedAt time.Time) error
+}
+
+type apiKeyRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewApiKeyRepository(db *gorm.DB) ApiKeyRepository {
+	logger := log.With().Str("module", "api_key_repository").Logger()
+	return &apiKeyRepository{db, logger}
+}
+
+func (repo *apiKeyRepository) Create(apiKey *entity.ApiKey) (*entity.ApiKey, error) {
+	if result := repo.db.Create(apiKey); result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to create api key")
+		return nil, result.Error
+	}
+
+	return apiKey, nil
+}
+
+func (repo *apiKeyRepository) FindByUserID(userID uuid.UUID) ([]entity.ApiKey, error) {
+	var apiKeys []entity.ApiKey
+	result := repo.db.
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&apiKeys)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find api keys by user id: " + userID.String())
+		return nil, result.Error
+	}
+
+	return apiKeys, nil
+}
+
+// FindByHashedKey returns the key with its user, whose role the key acts with.
+func (repo *apiKeyRepository) FindByHashedKey(hashedKey string) (*entity.ApiKey, error) {
+	var apiKey entity.ApiKey
+	if result := repo.db.Preload("User").Where("hashed_key = ?", hashedKey).First(&apiKey); result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find api key by hashed key")
+		return nil, result.Error
+	}
+
+	return &apiKey, nil
+}
+
+func (repo *apiKeyRepository) Delete(id uuid.UUID, userID uuid.UUID) (bool, error) {
+	result := repo.db.Where("id = ? AND user_id = ?", id, userID).Delete(&entity.ApiKey{})
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to delete api key: " + id.String())
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (repo *apiKeyRepository) UpdateLastUsedAt(id uuid.UUID, lastUsedAt time.Time) error {
+	result := repo.db.Model(&entity.ApiKey{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", lastUsedAt)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to update api key last used at: " + id.String())
+		return result.Error
+	}
+
+	return nil
+}
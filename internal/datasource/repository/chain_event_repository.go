@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"fund-o/api-server/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ChainEventRepository interface {
+	FindCursor(name string) (*entity.ChainCursor, error)
+	SaveEvents(cursor *entity.ChainCursor, events []entity.ChainEvent) error
+	Rollback(cursor *entity.ChainCursor) (int64, error)
+	FindUnconfirmed(maxBlockNumber uint64) ([]entity.ChainEvent, error)
+	MarkConfirmed(eventID uuid.UUID) error
+}
+
+type chainEventRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewChainEventRepository(db *gorm.DB) ChainEventRepository {
+	logger := log.With().Str("module", "chain_event_repository").Logger()
+	return &chainEventRepository{db, logger}
+}
+
+func (repo *chainEventRepository) WithContext(ctx context.Context) ChainEventRepository {
+	return &chainEventRepository{repo.db.WithContext(ctx), repo.logger.With().Ctx(ctx).Logger()}
+}
+
+func (repo *chainEventRepository) FindCursor(name string) (*entity.ChainCursor, error) {
+	var cursor entity.ChainCursor
+	if result := repo.db.Where("name = ?", name).First(&cursor); result.Error != nil {
+		return nil, result.Error
+	}
+	return &cursor, nil
+}
+
+// SaveEvents stores the events of a block range and moves the cursor to its end in one
+// transaction. Events that were already stored are left untouched.
+func (repo *chainEventRepository) SaveEvents(cursor *entity.ChainCursor, events []entity.ChainEvent) error {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if len(events) > 0 {
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&events)
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+
+		return saveCursor(tx, cursor)
+	})
+	if err != nil {
+		repo.logger.Error().Err(err).Msgf("failed to save chain events up to block %d", cursor.BlockNumber)
+		return err
+	}
+	return nil
+}
+
+// Rollback deletes the unconfirmed events above the cursor block and rewinds the cursor to it.
+func (repo *chainEventRepository) Rollback(cursor *entity.ChainCursor) (int64, error) {
+	var deleted int64
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().
+			Where("block_number > ? AND confirmed = ?", cursor.BlockNumber, false).
+			Delete(&entity.ChainEvent{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted = result.RowsAffected
+
+		return saveCursor(tx, cursor)
+	})
+	if err != nil {
+		repo.logger.Error().Err(err).Msgf("failed to roll back chain events to block %d", cursor.BlockNumber)
+		return 0, err
+	}
+	return deleted, nil
+}
+
+func (repo *chainEventRepository) FindUnconfirmed(maxBlockNumber uint64) ([]entity.ChainEvent, error) {
+	var events []entity.ChainEvent
+	result := repo.db.
+		Where("confirmed = ? AND block_number <= ?", false, maxBlockNumber).
+		Order("block_number, log_index").
+		Find(&events)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find unconfirmed chain events")
+		return nil, result.Error
+	}
+	return events, nil
+}
+
+func (repo *chainEventRepository) MarkConfirmed(eventID uuid.UUID) error {
+	result := repo.db.Model(&entity.ChainEvent{}).Where("id = ?", eventID).Update("confirmed", true)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to confirm chain event: " + eventID.String())
+		return result.Error
+	}
+	return nil
+}
+
+func saveCursor(tx *gorm.DB, cursor *entity.ChainCursor) error {
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"block_number", "block_hash", "updated_at"}),
+	}).Create(cursor).Error
+}
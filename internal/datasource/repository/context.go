@@ -0,0 +1,19 @@
+package repository
+
+import "context"
+
+// contextual is implemented by the repositories able to run their queries with a context. It is
+// kept out of the repository interfaces so that mocks do not have to expect the scoping.
+type contextual[T any] interface {
+	WithContext(ctx context.Context) T
+}
+
+// WithContext returns a copy of repo whose queries run with ctx, so they are cancelled with it and
+// traced as its children. A repository that cannot be scoped, such as a mock, is returned as is.
+func WithContext[T any](ctx context.Context, repo T) T {
+	if scoped, ok := any(repo).(contextual[T]); ok {
+		return scoped.WithContext(ctx)
+	}
+
+	return repo
+}
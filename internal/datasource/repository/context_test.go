@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type ctxKey struct{}
+
+type scopedRepository interface {
+	Context() context.Context
+}
+
+type contextualRepository struct {
+	ctx context.Context
+}
+
+func (repo *contextualRepository) WithContext(ctx context.Context) scopedRepository {
+	return &contextualRepository{ctx}
+}
+
+func (repo *contextualRepository) Context() context.Context {
+	return repo.ctx
+}
+
+type plainRepository struct{}
+
+func (plainRepository) Context() context.Context {
+	return nil
+}
+
+func TestWithContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+
+	t.Run("Test scoped repository", func(t *testing.T) {
+		var repo scopedRepository = &contextualRepository{context.Background()}
+
+		scoped := WithContext(ctx, repo)
+		require.Equal(t, ctx, scoped.Context())
+		require.Equal(t, context.Background(), repo.Context())
+	})
+
+	t.Run("Test repository without context", func(t *testing.T) {
+		var repo scopedRepository = plainRepository{}
+
+		require.Equal(t, repo, WithContext(ctx, repo))
+	})
+}
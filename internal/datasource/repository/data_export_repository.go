@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"fund-o/api-server/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type DataExportRepository interface {
+	Create(export *entity.DataExport) (*entity.DataExport, error)
+	FindByID(id uuid.UUID) (*entity.DataExport, error)
+	FindLatestByUserID(userID uuid.UUID) (*entity.DataExport, error)
+	FindUserData(userID uuid.UUID) (*entity.UserData, error)
+	MarkReady(id uuid.UUID, archive []byte, secretCodeHash string, expiredAt time.Time) error
+	UpdateSecretCodeHash(id uuid.UUID, secretCodeHash string) error
+}
+
+type dataExportRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewDataExportRepository(db *gorm.DB) DataExportRepository {
+	logger := log.With().Str("module", "data_export_repository").Logger()
+	return &dataExportRepository{db, logger}
+}
+
+func (repo *dataExportRepository) WithContext(ctx context.Context) DataExportRepository {
+	return &dataExportRepository{repo.db.WithContext(ctx), repo.logger.With().Ctx(ctx).Logger()}
+}
+
+func (repo *dataExportRepository) Create(export *entity.DataExport) (*entity.DataExport, error) {
+	if result := repo.db.Create(export); result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to create data export: " + export.UserID.String())
+		return nil, result.Error
+	}
+
+	return export, nil
+}
+
+func (repo *dataExportRepository) FindByID(id uuid.UUID) (*entity.DataExport, error) {
+	var export entity.DataExport
+	if result := repo.db.Where("id = ?", id).First(&export); result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find data export by id: " + id.String())
+		return nil, result.Error
+	}
+
+	return &export, nil
+}
+
+// FindLatestByUserID returns the most recent export of the user without its archive.
+func (repo *dataExportRepository) FindLatestByUserID(userID uuid.UUID) (*entity.DataExport, error) {
+	var export entity.DataExport
+	result := repo.db.
+		Omit("archive").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		First(&export)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find latest data export: " + userID.String())
+		return nil, result.Error
+	}
+
+	return &export, nil
+}
+
+// FindUserData reads every record that holds personal data of the user.
+func (repo *dataExportRepository) FindUserData(userID uuid.UUID) (*entity.UserData, error) {
+	var data entity.UserData
+	queries := []struct {
+		name  string
+		query func() *gorm.DB
+	}{
+		{"user", func() *gorm.DB { return repo.db.Preload("Wallets").Preload("Identities").First(&data.User, userID) }},
+		{"sessions", func() *gorm.DB { return repo.db.Where("user_id = ?", userID).Find(&data.Sessions) }},
+		{"projects", func() *gorm.DB {
+			return repo.db.Scopes(withFunding).
+				Preload("Category").
+				Preload("SubCategory").
+				Where("owner_id = ?", userID).
+				Find(&data.Projects)
+		}},
+		{"backings", func() *gorm.DB { return repo.db.Where("user_id = ?", userID).Find(&data.Backings) }},
+		{"ratings", func() *gorm.DB { return repo.db.Where("user_id = ?", userID).Find(&data.Ratings) }},
+		{"posts", func() *gorm.DB { return repo.db.Where("author_id = ?", userID).Find(&data.Posts) }},
+		{"comments", func() *gorm.DB { return repo.db.Where("author_id = ?", userID).Find(&data.Comments) }},
+		{"replies", func() *gorm.DB { return repo.db.Where("author_id = ?", userID).Find(&data.Replies) }},
+		{"messages", func() *gorm.DB { return repo.db.Where("author_id = ?", userID).Find(&data.Messages) }},
+	}
+
+	for _, q := range queries {
+		if result := q.query(); result.Error != nil {
+			repo.logger.Error().Err(result.Error).Msg("failed to find " + q.name + " of user: " + userID.String())
+			return nil, result.Error
+		}
+	}
+
+	return &data, nil
+}
+
+func (repo *dataExportRepository) MarkReady(id uuid.UUID, archive []byte, secretCodeHash string, expiredAt time.Time) error {
+	result := repo.db.Model(&entity.DataExport{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":           entity.DataExportReady,
+			"archive":          archive,
+			"secret_code_hash": secretCodeHash,
+			"expired_at":       expiredAt,
+		})
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to mark data export as ready: " + id.String())
+		return result.Error
+	}
+
+	return nil
+}
+
+func (repo *dataExportRepository) UpdateSecretCodeHash(id uuid.UUID, secretCodeHash string) error {
+	result := repo.db.Model(&entity.DataExport{}).
+		Where("id = ?", id).
+		Update("secret_code_hash", secretCodeHash)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to update data export secret code: " + id.String())
+		return result.Error
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"fund-o/api-server/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type EmailChangeRepository interface {
+	Create(emailChange *entity.EmailChange) (*entity.EmailChange, error)
+	FindByID(id uuid.UUID) (*entity.EmailChange, error)
+	MarkUsed(id uuid.UUID) (bool, error)
+}
+
+type emailChangeRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewEmailChangeRepository(db *gorm.DB) EmailChangeRepository {
+	logger := log.With().Str("module", "email_change_repository").Logger()
+	return &emailChangeRepository{db, logger}
+}
+
+func (repo *emailChangeRepository) WithContext(ctx context.Context) EmailChangeRepository {
+	return &emailChangeRepository{repo.db.WithContext(ctx), repo.logger.With().Ctx(ctx).Logger()}
+}
+
+func (repo *emailChangeRepository) Create(emailChange *entity.EmailChange) (*entity.EmailChange, error) {
+	if result := repo.db.Create(emailChange); result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to create email change: " + emailChange.NewEmail)
+		return nil, result.Error
+	}
+	return emailChange, nil
+}
+
+func (repo *emailChangeRepository) FindByID(id uuid.UUID) (*entity.EmailChange, error) {
+	var emailChange entity.EmailChange
+	if result := repo.db.Where("id = ?", id).First(&emailChange); result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find email change by id: " + id.String())
+		return nil, result.Error
+	}
+	return &emailChange, nil
+}
+
+// MarkUsed flags the email change as used. It reports false when it was already used.
+func (repo *emailChangeRepository) MarkUsed(id uuid.UUID) (bool, error) {
+	result := repo.db.Model(&entity.EmailChange{}).
+		Where("id = ? AND is_used = ?", id, false).
+		Update("is_used", true)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to mark email change as used: " + id.String())
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
@@ -20,6 +20,7 @@ type ForumRepository interface {
 	CreatePost(forum *entity.Post) (*entity.Post, error)
 	FindPostByID(id uuid.UUID) (*entity.Post, error)
 	FindAllPostsByAuthorID(authorID uuid.UUID) ([]entity.Post, error)
+	DeletePost(id uuid.UUID) (bool, error)
 	CreateComment(comment *entity.Comment) (*entity.Comment, error)
 	CreateReply(reply *entity.Reply) (*entity.Reply, error)
 }
@@ -104,6 +105,16 @@ func (repo *forumRepository) FindAllPostsByAuthorID(authorID uuid.UUID) ([]entit
 	return forums, nil
 }
 
+func (repo *forumRepository) DeletePost(id uuid.UUID) (bool, error) {
+	result := repo.db.Where("id = ?", id).Delete(&entity.Post{})
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to delete post: " + id.String())
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
 func (repo *forumRepository) CreateComment(comment *entity.Comment) (*entity.Comment, error) {
 	result := repo.db.
 		Preload("Author").
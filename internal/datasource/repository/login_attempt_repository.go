@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	loginFailuresKeyPrefix = "auth:login:failures:"
+	loginLockKeyPrefix     = "auth:login:lock:"
+)
+
+type LoginAttemptRepository interface {
+	AddFailure(key string, window time.Duration) (int64, error)
+	ResetFailures(key string) error
+	Lock(key string, ttl time.Duration) error
+	LockTTL(key string) (time.Duration, error)
+}
+
+type loginAttemptRepository struct {
+	redis  *redis.Client
+	ctx    context.Context
+	logger zerolog.Logger
+}
+
+func NewLoginAttemptRepository(redisClient *redis.Client) LoginAttemptRepository {
+	logger := log.With().Str("module", "login_attempt_repository").Logger()
+	return &loginAttemptRepository{redisClient, context.Background(), logger}
+}
+
+func (repo *loginAttemptRepository) WithContext(ctx context.Context) LoginAttemptRepository {
+	return &loginAttemptRepository{repo.redis, ctx, repo.logger.With().Ctx(ctx).Logger()}
+}
+
+// AddFailure counts a failed login and returns the failures within the window. The window starts
+// with the first failure, so the counter clears itself once the window has passed.
+func (repo *loginAttemptRepository) AddFailure(key string, window time.Duration) (int64, error) {
+	pipe := repo.redis.TxPipeline()
+	count := pipe.Incr(repo.ctx, loginFailuresKeyPrefix+key)
+	pipe.ExpireNX(repo.ctx, loginFailuresKeyPrefix+key, window)
+	if _, err := pipe.Exec(repo.ctx); err != nil {
+		repo.logger.Error().Err(err).Msg("failed to add login failure")
+		return 0, err
+	}
+
+	return count.Val(), nil
+}
+
+func (repo *loginAttemptRepository) ResetFailures(key string) error {
+	if err := repo.redis.Del(repo.ctx, loginFailuresKeyPrefix+key).Err(); err != nil {
+		repo.logger.Error().Err(err).Msg("failed to reset login failures")
+		return err
+	}
+	return nil
+}
+
+// Lock blocks logins for the key until the ttl passed, replacing any shorter or longer lock.
+func (repo *loginAttemptRepository) Lock(key string, ttl time.Duration) error {
+	if err := repo.redis.Set(repo.ctx, loginLockKeyPrefix+key, 1, ttl).Err(); err != nil {
+		repo.logger.Error().Err(err).Msg("failed to lock login")
+		return err
+	}
+
+	return nil
+}
+
+// LockTTL returns how long logins for the key stay blocked, or zero when they are not.
+func (repo *loginAttemptRepository) LockTTL(key string) (time.Duration, error) {
+	ttl, err := repo.redis.PTTL(repo.ctx, loginLockKeyPrefix+key).Result()
+	if err != nil {
+		repo.logger.Error().Err(err).Msg("failed to get login lock")
+		return 0, err
+	}
+
+	if ttl < 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"fund-o/api-server/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type MfaRepository interface {
+	FindTOTPByUserID(userID uuid.UUID) (*entity.UserTOTP, error)
+	SaveTOTP(totp *entity.UserTOTP) (*entity.UserTOTP, error)
+	ConfirmTOTP(userID uuid.UUID, step int64, recoveryCodes []entity.UserRecoveryCode) (bool, error)
+	UseTOTPStep(userID uuid.UUID, step int64) (bool, error)
+	DeleteTOTP(userID uuid.UUID) (bool, error)
+	FindUnusedRecoveryCodes(userID uuid.UUID) ([]entity.UserRecoveryCode, error)
+	UseRecoveryCode(id uuid.UUID) (bool, error)
+}
+
+type mfaRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewMfaRepository(db *gorm.DB) MfaRepository {
+	logger := log.With().Str("module", "mfa_repository").Logger()
+	return &mfaRepository{db, logger}
+}
+
+func (repo *mfaRepository) FindTOTPByUserID(userID uuid.UUID) (*entity.UserTOTP, error) {
+	var totp entity.UserTOTP
+	if result := repo.db.Where("user_id = ?", userID).First(&totp); result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find totp by user id: " + userID.String())
+		return nil, result.Error
+	}
+
+	return &totp, nil
+}
+
+// SaveTOTP stores a new, unconfirmed authenticator and drops an earlier enrollment that was never
+// confirmed. A confirmed authenticator is kept and makes the insert fail.
+func (repo *mfaRepository) SaveTOTP(totp *entity.UserTOTP) (*entity.UserTOTP, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().
+			Where("user_id = ? AND confirmed_at IS NULL", totp.UserID).
+			Delete(&entity.UserTOTP{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return tx.Create(totp).Error
+	})
+	if err != nil {
+		repo.logger.Error().Err(err).Msg("failed to save totp: " + totp.UserID.String())
+		return nil, err
+	}
+
+	return totp, nil
+}
+
+// ConfirmTOTP activates the authenticator with the time step of its first code and replaces the
+// recovery codes of the user. It reports false when there is no unconfirmed authenticator.
+func (repo *mfaRepository) ConfirmTOTP(userID uuid.UUID, step int64, recoveryCodes []entity.UserRecoveryCode) (bool, error) {
+	confirmed := false
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.UserTOTP{}).
+			Where("user_id = ? AND confirmed_at IS NULL", userID).
+			Updates(map[string]interface{}{"confirmed_at": time.Now(), "last_used_step": step})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&entity.UserRecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&recoveryCodes).Error; err != nil {
+			return err
+		}
+
+		confirmed = true
+		return nil
+	})
+	if err != nil {
+		repo.logger.Error().Err(err).Msg("failed to confirm totp: " + userID.String())
+		return false, err
+	}
+
+	return confirmed, nil
+}
+
+// UseTOTPStep records that the code of a time step was used. It reports false when a code of that
+// step or a later one was already used, so a code cannot be replayed.
+func (repo *mfaRepository) UseTOTPStep(userID uuid.UUID, step int64) (bool, error) {
+	result := repo.db.Model(&entity.UserTOTP{}).
+		Where("user_id = ? AND confirmed_at IS NOT NULL AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to use totp step: " + userID.String())
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteTOTP removes the authenticator and the recovery codes of the user.
+func (repo *mfaRepository) DeleteTOTP(userID uuid.UUID) (bool, error) {
+	deleted := false
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Where("user_id = ?", userID).Delete(&entity.UserTOTP{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&entity.UserRecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		deleted = result.RowsAffected > 0
+		return nil
+	})
+	if err != nil {
+		repo.logger.Error().Err(err).Msg("failed to delete totp: " + userID.String())
+		return false, err
+	}
+
+	return deleted, nil
+}
+
+func (repo *mfaRepository) FindUnusedRecoveryCodes(userID uuid.UUID) ([]entity.UserRecoveryCode, error) {
+	var recoveryCodes []entity.UserRecoveryCode
+	if result := repo.db.Where("user_id = ? AND used_at IS NULL", userID).Find(&recoveryCodes); result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find recovery codes: " + userID.String())
+		return nil, result.Error
+	}
+
+	return recoveryCodes, nil
+}
+
+// UseRecoveryCode marks the recovery code as used. It reports false when it was used already.
+func (repo *mfaRepository) UseRecoveryCode(id uuid.UUID) (bool, error) {
+	result := repo.db.Model(&entity.UserRecoveryCode{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to use recovery code: " + id.String())
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const nonceKeyPrefix = "auth:nonce:"
+
+type NonceRepository interface {
+	Create(nonce string, subject string, ttl time.Duration) error
+	Consume(nonce string) (string, error)
+}
+
+type nonceRepository struct {
+	redis  *redis.Client
+	ctx    context.Context
+	logger zerolog.Logger
+}
+
+func NewNonceRepository(redisClient *redis.Client) NonceRepository {
+	logger := log.With().Str("module", "nonce_repository").Logger()
+	return &nonceRepository{redisClient, context.Background(), logger}
+}
+
+func (repo *nonceRepository) WithContext(ctx context.Context) NonceRepository {
+	return &nonceRepository{repo.redis, ctx, repo.logger.With().Ctx(ctx).Logger()}
+}
+
+// Create stores a nonce issued for the given subject, which describes what the nonce may be used for.
+func (repo *nonceRepository) Create(nonce string, subject string, ttl time.Duration) error {
+	if err := repo.redis.Set(repo.ctx, nonceKeyPrefix+nonce, subject, ttl).Err(); err != nil {
+		repo.logger.Error().Err(err).Msg("failed to create nonce")
+		return err
+	}
+	return nil
+}
+
+// Consume deletes the nonce and returns the subject it was issued for, so that a nonce can be used
+// only once. It returns an empty subject when the nonce does not exist or has expired.
+func (repo *nonceRepository) Consume(nonce string) (string, error) {
+	subject, err := repo.redis.GetDel(repo.ctx, nonceKeyPrefix+nonce).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", nil
+		}
+
+		repo.logger.Error().Err(err).Msg("failed to consume nonce")
+		return "", err
+	}
+	return subject, nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"fund-o/api-server/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type PasswordResetRepository interface {
+	Create(passwordReset *entity.PasswordReset) (*entity.PasswordReset, error)
+	FindByID(id uuid.UUID) (*entity.PasswordReset, error)
+	MarkUsed(id uuid.UUID) (bool, error)
+}
+
+type passwordResetRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	logger := log.With().Str("module", "password_reset_repository").Logger()
+	return &passwordResetRepository{db, logger}
+}
+
+func (repo *passwordResetRepository) WithContext(ctx context.Context) PasswordResetRepository {
+	return &passwordResetRepository{repo.db.WithContext(ctx), repo.logger.With().Ctx(ctx).Logger()}
+}
+
+func (repo *passwordResetRepository) Create(passwordReset *entity.PasswordReset) (*entity.PasswordReset, error) {
+	if result := repo.db.Create(passwordReset); result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to create password reset: " + passwordReset.Email)
+		return nil, result.Error
+	}
+	return passwordReset, nil
+}
+
+func (repo *passwordResetRepository) FindByID(id uuid.UUID) (*entity.PasswordReset, error) {
+	var passwordReset entity.PasswordReset
+	if result := repo.db.Where("id = ?", id).First(&passwordReset); result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find password reset by id: " + id.String())
+		return nil, result.Error
+	}
+	return &passwordReset, nil
+}
+
+// MarkUsed flags the password reset as used. It reports false when the reset was already used, so
+// two requests racing with the same link cannot both change the password.
+func (repo *passwordResetRepository) MarkUsed(id uuid.UUID) (bool, error) {
+	result := repo.db.Model(&entity.PasswordReset{}).
+		Where("id = ? AND is_used = ?", id, false).
+		Update("is_used", true)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to mark password reset as used: " + id.String())
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
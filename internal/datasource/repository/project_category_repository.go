@@ -10,6 +10,7 @@ import (
 
 type ProjectCategoryRepository interface {
 	FindAll() ([]entity.ProjectCategory, error)
+	Create(category *entity.ProjectCategory) (*entity.ProjectCategory, error)
 }
 
 type projectCategoryRepository struct {
@@ -31,3 +32,12 @@ func (repo *projectCategoryRepository) FindAll() ([]entity.ProjectCategory, erro
 
 	return categories, nil
 }
+
+func (repo *projectCategoryRepository) Create(category *entity.ProjectCategory) (*entity.ProjectCategory, error) {
+	if result := repo.db.Create(category); result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to create project category: " + category.Name)
+		return nil, result.Error
+	}
+
+	return category, nil
+}
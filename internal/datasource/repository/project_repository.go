@@ -1,23 +1,29 @@
 package repository
 
 import (
-	"fmt"
+	"context"
 	"fund-o/api-server/internal/entity"
+	"fund-o/api-server/pkg/apperrors"
 	"fund-o/api-server/pkg/pagination"
 	"github.com/rs/zerolog"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProjectRepository interface {
 	FindAll(paginateOptions pagination.PaginateFindOptions, findOptions entity.ProjectListOptions) []entity.Project
-	Count() int64
+	Count(findOptions entity.ProjectListOptions) int64
 	Create(project *entity.Project) (*entity.Project, error)
 	FindByID(projectID uuid.UUID) (*entity.Project, error)
+	FindByContractID(contractID string) (*entity.Project, error)
+	FindContractAddresses() ([]string, error)
 	FindAllByOwnerID(ownerID uuid.UUID) ([]entity.Project, error)
 	FindRecommendation(count int) ([]entity.Project, error)
 	CreateProjectRating(rating *entity.ProjectRating) (*entity.ProjectRating, error)
@@ -26,6 +32,26 @@ type ProjectRepository interface {
 	CreateProjectBacker(backer *entity.ProjectBacker) (*entity.ProjectBacker, error)
 	UpdateProjectBacker(backer *entity.ProjectBacker) (*entity.ProjectBacker, error)
 	FindBackProjectsByUserID(userID string) ([]entity.ProjectFunding, error)
+	UpdateStatus(projectID uuid.UUID, from, to entity.ProjectStatus) (bool, error)
+	CloseExpired(now time.Time) (int64, error)
+	FindRewardsByProjectID(projectID uuid.UUID) ([]entity.ProjectReward, error)
+	FindRewardByID(rewardID uuid.UUID) (*entity.ProjectReward, error)
+	CreateReward(reward *entity.ProjectReward) (*entity.ProjectReward, error)
+	UpdateReward(reward *entity.ProjectReward) (*entity.ProjectReward, error)
+	DeleteReward(rewardID uuid.UUID) error
+	FindProjectBackerByID(backerID uuid.UUID) (*entity.ProjectBacker, error)
+	FindProjectBackerByTxHash(txHash string) (*entity.ProjectBacker, error)
+	UpdateProjectBackerStatus(backerID uuid.UUID, from, to entity.ProjectBackerStatus) (bool, error)
+	FindRefundableBackers(projectID uuid.UUID, userID uuid.UUID) ([]entity.ProjectBacker, error)
+	RequestRefunds(backers []entity.ProjectBacker) ([]entity.ProjectRefund, error)
+	FindRefundByID(refundID uuid.UUID) (*entity.ProjectRefund, error)
+	FindOpenRefund(projectID, userID uuid.UUID, amount decimal.Decimal) (*entity.ProjectRefund, error)
+	UpdateRefundStatus(refundID uuid.UUID, from, to entity.RefundStatus) (bool, error)
+	CompleteRefund(refundID uuid.UUID, event *entity.ChainEvent) (bool, error)
+	FailRefund(refundID uuid.UUID, reason string) (bool, error)
+	FindRefundEvent(contractAddress, account string, amount decimal.Decimal) (*entity.ChainEvent, error)
+	IsProjectBacker(projectID uuid.UUID, userID uuid.UUID) (bool, error)
+	FindBackerEmails(projectID uuid.UUID) ([]string, error)
 }
 
 type projectRepository struct {
@@ -38,25 +64,61 @@ func NewProjectRepository(db *gorm.DB) ProjectRepository {
 	return &projectRepository{db, logger}
 }
 
+func (repo *projectRepository) WithContext(ctx context.Context) ProjectRepository {
+	return &projectRepository{repo.db.WithContext(ctx), repo.logger.With().Ctx(ctx).Logger()}
+}
+
+// projectFundingJoin aggregates the amount raised and the number of distinct backers per project
+// from confirmed contributions. It takes entity.BackerConfirmed as its only argument.
+const projectFundingJoin = `LEFT JOIN (
+            SELECT project_id, SUM(amount) AS raised, COUNT(DISTINCT user_id) AS backer_count
+            FROM project_backers
+            WHERE deleted_at IS NULL AND status = ?
+            GROUP BY project_id
+        ) AS funding ON funding.project_id = projects.id`
+
+// withFunding populates Project.Raised and Project.BackerCount in the same query.
+func withFunding(db *gorm.DB) *gorm.DB {
+	return db.
+		Select("projects.*, COALESCE(funding.raised, 0) AS raised, COALESCE(funding.backer_count, 0) AS backer_count").
+		Joins(projectFundingJoin, entity.BackerConfirmed)
+}
+
+// filterProjects applies the filters of a project list, so that FindAll and Count agree.
+func filterProjects(findOptions entity.ProjectListOptions) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("LOWER(title) LIKE ?", "%"+strings.ToLower(findOptions.Query)+"%")
+
+		if findOptions.CategoryID != uuid.Nil {
+			db = db.Where("category_id = ?", findOptions.CategoryID)
+		}
+
+		if findOptions.SubCategoryID != uuid.Nil {
+			db = db.Where("sub_category_id = ?", findOptions.SubCategoryID)
+		}
+
+		if findOptions.Status != 0 {
+			db = db.Where("status = ?", findOptions.Status)
+		}
+
+		if findOptions.PublicOnly {
+			db = db.Where("(status IN ? OR owner_id = ?)", entity.PublicProjectStatuses, findOptions.ViewerID)
+		}
+
+		return db
+	}
+}
+
 func (repo *projectRepository) FindAll(paginateOptions pagination.PaginateFindOptions, findOptions entity.ProjectListOptions) (projects []entity.Project) {
-	query := repo.db.
+	result := repo.db.
+		Scopes(withFunding, filterProjects(findOptions)).
 		Limit(paginateOptions.Limit).
 		Offset(paginateOptions.Skip).
-		Where("LOWER(title) LIKE ?", "%"+strings.ToLower(findOptions.Query)+"%").
 		Preload("Category").
 		Preload("SubCategory").
 		Preload("Owner").
-		Preload("Ratings")
-
-	if findOptions.CategoryID != uuid.Nil {
-		query = query.Where("category_id = ?", findOptions.CategoryID)
-	}
-
-	if findOptions.SubCategoryID != uuid.Nil {
-		query = query.Where("sub_category_id = ?", findOptions.SubCategoryID)
-	}
-
-	result := query.Find(&projects)
+		Preload("Ratings").
+		Find(&projects)
 	if result.Error != nil {
 		repo.logger.Error().Err(result.Error).Msg("failed to list projects")
 		return
@@ -65,9 +127,9 @@ func (repo *projectRepository) FindAll(paginateOptions pagination.PaginateFindOp
 	return projects
 }
 
-func (repo *projectRepository) Count() int64 {
+func (repo *projectRepository) Count(findOptions entity.ProjectListOptions) int64 {
 	var count int64
-	if result := repo.db.Model(&entity.Project{}).Count(&count); result.Error != nil {
+	if result := repo.db.Model(&entity.Project{}).Scopes(filterProjects(findOptions)).Count(&count); result.Error != nil {
 		repo.logger.Error().Err(result.Error).Msg("failed to count projects")
 		return 0
 	}
@@ -93,6 +155,7 @@ func (repo *projectRepository) Create(project *entity.Project) (*entity.Project,
 func (repo *projectRepository) FindByID(projectID uuid.UUID) (*entity.Project, error) {
 	var project entity.Project
 	result := repo.db.
+		Scopes(withFunding).
 		Preload("Owner").
 		Preload("Category").
 		Preload("SubCategory").
@@ -107,9 +170,37 @@ func (repo *projectRepository) FindByID(projectID uuid.UUID) (*entity.Project, e
 	return &project, nil
 }
 
+func (repo *projectRepository) FindByContractID(contractID string) (*entity.Project, error) {
+	var project entity.Project
+	result := repo.db.Where("LOWER(project_contract_id) = LOWER(?)", contractID).First(&project)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find project by contract id: " + contractID)
+		return nil, result.Error
+	}
+
+	return &project, nil
+}
+
+// FindContractAddresses returns the contract address of every project that has one.
+func (repo *projectRepository) FindContractAddresses() ([]string, error) {
+	var addresses []string
+	result := repo.db.
+		Model(&entity.Project{}).
+		Where("project_contract_id <> ''").
+		Distinct().
+		Pluck("project_contract_id", &addresses)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find project contract addresses")
+		return nil, result.Error
+	}
+
+	return addresses, nil
+}
+
 func (repo *projectRepository) FindAllByOwnerID(ownerID uuid.UUID) ([]entity.Project, error) {
 	var projects []entity.Project
 	result := repo.db.
+		Scopes(withFunding).
 		Preload("Owner").
 		Preload("Category").
 		Preload("SubCategory").
@@ -121,11 +212,6 @@ func (repo *projectRepository) FindAllByOwnerID(ownerID uuid.UUID) ([]entity.Pro
 		return nil, result.Error
 	}
 
-	for _, project := range projects {
-		fmt.Println(ownerID)
-		fmt.Println("project owner: ", project.OwnerID)
-	}
-
 	return projects, nil
 }
 
@@ -136,8 +222,10 @@ func (repo *projectRepository) FindRecommendation(count int) ([]entity.Project,
 		Preload("Category").
 		Preload("SubCategory").
 		Preload("Ratings").
-		Select("projects.*, AVG(project_ratings.rating) AS avg_rating").
+		Select("projects.*, COALESCE(MAX(funding.raised), 0) AS raised, COALESCE(MAX(funding.backer_count), 0) AS backer_count, AVG(project_ratings.rating) AS avg_rating").
+		Joins(projectFundingJoin, entity.BackerConfirmed).
 		Joins("LEFT JOIN project_ratings ON projects.id = project_ratings.project_id").
+		Where("projects.status IN ?", entity.PublicProjectStatuses).
 		Group("projects.id").
 		Having("AVG(project_ratings.rating) > 0").
 		Order("avg_rating DESC").
@@ -189,13 +277,29 @@ func (repo *projectRepository) GetProjectBacker(userID, projectID uuid.UUID) (en
 	return backer, nil
 }
 
+// CreateProjectBacker records a contribution. When the backer picked a reward, one unit of it is
+// claimed in the same transaction and apperrors.ErrRewardSoldOut is returned if none is left.
 func (repo *projectRepository) CreateProjectBacker(backer *entity.ProjectBacker) (*entity.ProjectBacker, error) {
-	result := repo.db.
-		Create(&backer).
-		First(&backer)
-	if result.Error != nil {
-		repo.logger.Error().Err(result.Error).Msg("failed to create project backer")
-		return nil, result.Error
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if backer.RewardID != nil {
+			claim := tx.
+				Model(&entity.ProjectReward{}).
+				Where("id = ? AND (quantity_limit IS NULL OR quantity_claimed < quantity_limit)", backer.RewardID).
+				Update("quantity_claimed", gorm.Expr("quantity_claimed + 1"))
+			if claim.Error != nil {
+				return claim.Error
+			}
+
+			if claim.RowsAffected == 0 {
+				return apperrors.ErrRewardSoldOut
+			}
+		}
+
+		return tx.Create(&backer).First(&backer).Error
+	})
+	if err != nil {
+		repo.logger.Error().Err(err).Msg("failed to create project backer")
+		return nil, err
 	}
 
 	return backer, nil
@@ -213,17 +317,97 @@ func (repo *projectRepository) UpdateProjectBacker(backer *entity.ProjectBacker)
 	return backer, nil
 }
 
+func (repo *projectRepository) FindProjectBackerByID(backerID uuid.UUID) (*entity.ProjectBacker, error) {
+	var backer entity.ProjectBacker
+	result := repo.db.
+		Where("id = ?", backerID).
+		First(&backer)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find project backer by id")
+		return nil, result.Error
+	}
+
+	return &backer, nil
+}
+
+func (repo *projectRepository) FindProjectBackerByTxHash(txHash string) (*entity.ProjectBacker, error) {
+	var backer entity.ProjectBacker
+	result := repo.db.
+		Where("tx_hash = ?", txHash).
+		First(&backer)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find project backer by tx hash")
+		return nil, result.Error
+	}
+
+	return &backer, nil
+}
+
+// UpdateProjectBackerStatus moves a contribution from one status to another and reports whether
+// it did. Rejecting a contribution gives its reward slot back in the same transaction.
+func (repo *projectRepository) UpdateProjectBackerStatus(backerID uuid.UUID, from, to entity.ProjectBackerStatus) (bool, error) {
+	updated := false
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		var backer entity.ProjectBacker
+		result := tx.
+			Model(&backer).
+			Clauses(clause.Returning{}).
+			Where("id = ? AND status = ?", backerID, from).
+			Update("status", to)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		updated = true
+
+		if to == entity.BackerRejected && backer.RewardID != nil {
+			return tx.
+				Model(&entity.ProjectReward{}).
+				Where("id = ? AND quantity_claimed > 0", backer.RewardID).
+				Update("quantity_claimed", gorm.Expr("quantity_claimed - 1")).
+				Error
+		}
+
+		return nil
+	})
+	if err != nil {
+		repo.logger.Error().Err(err).Msg("failed to update project backer status")
+		return false, err
+	}
+
+	return updated, nil
+}
+
 func (repo *projectRepository) FindBackProjectsByUserID(userID string) ([]entity.ProjectFunding, error) {
 	var projectFundings []entity.ProjectFunding
 
 	// Execute raw SQL query to calculate the sum of the amount funded for each project by the user
 	result := repo.db.Raw(`
-        SELECT projects.id as project_id, SUM(project_backers.amount) as total_funds
+        SELECT projects.id as project_id, SUM(project_backers.amount) as total_funds,
+            CASE
+                WHEN BOOL_OR(project_refunds.status = ?) THEN ?
+                WHEN BOOL_OR(project_refunds.status = ?) THEN ?
+                WHEN BOOL_OR(project_refunds.status = ?) THEN ?
+                WHEN BOOL_OR(project_refunds.status = ?) THEN ?
+                ELSE 0
+            END AS refund_status,
+            COALESCE(SUM(project_refunds.amount) FILTER (WHERE project_refunds.status = ?), 0) AS refunded_amount
         FROM projects
         JOIN project_backers ON projects.id = project_backers.project_id
-        WHERE project_backers.user_id = ?
+        LEFT JOIN project_refunds ON project_refunds.backer_id = project_backers.id AND project_refunds.deleted_at IS NULL
+        WHERE project_backers.user_id = ? AND project_backers.status = ? AND project_backers.deleted_at IS NULL
         GROUP BY projects.id
-    `, userID).Scan(&projectFundings)
+    `,
+		entity.RefundFailed, entity.RefundFailed,
+		entity.RefundProcessing, entity.RefundProcessing,
+		entity.RefundRequested, entity.RefundRequested,
+		entity.RefundRefunded, entity.RefundRefunded,
+		entity.RefundRefunded,
+		userID, entity.BackerConfirmed,
+	).Scan(&projectFundings)
 	if result.Error != nil {
 		repo.logger.Error().Err(result.Error).Msg("failed to find back projects by user id")
 		return nil, result.Error
@@ -246,3 +430,339 @@ func (repo *projectRepository) FindBackProjectsByUserID(userID string) ([]entity
 	//	return nil, result.Error
 	//}
 }
+
+// UpdateStatus moves the project to the given status only if it is still in the expected one,
+// so concurrent transitions cannot both succeed. It reports whether the row was updated.
+func (repo *projectRepository) UpdateStatus(projectID uuid.UUID, from, to entity.ProjectStatus) (bool, error) {
+	result := repo.db.
+		Model(&entity.Project{}).
+		Where("id = ? AND status = ?", projectID, from).
+		Update("status", to)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to update project status")
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// CloseExpired ends every live campaign whose end date has passed. Campaigns that raised
+// their goal (and at least something) succeed, the rest fail.
+func (repo *projectRepository) CloseExpired(now time.Time) (int64, error) {
+	result := repo.db.Exec(`
+        UPDATE projects
+        SET status = CASE
+                WHEN funding.raised > 0 AND funding.raised >= projects.goal THEN ?
+                ELSE ?
+            END,
+            updated_at = ?
+        FROM (
+            SELECT projects.id AS project_id, COALESCE(SUM(project_backers.amount), 0) AS raised
+            FROM projects
+            LEFT JOIN project_backers
+                ON project_backers.project_id = projects.id
+                AND project_backers.status = ?
+                AND project_backers.deleted_at IS NULL
+            GROUP BY projects.id
+        ) AS funding
+        WHERE funding.project_id = projects.id
+            AND projects.status = ? AND projects.end_date < ? AND projects.deleted_at IS NULL
+    `, entity.ProjectSucceeded, entity.ProjectFailed, now, entity.BackerConfirmed, entity.ProjectLive, now)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to close expired projects")
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (repo *projectRepository) FindRewardsByProjectID(projectID uuid.UUID) ([]entity.ProjectReward, error) {
+	var rewards []entity.ProjectReward
+	result := repo.db.
+		Where("project_id = ?", projectID).
+		Order("minimum_amount ASC").
+		Find(&rewards)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to list project rewards")
+		return nil, result.Error
+	}
+
+	return rewards, nil
+}
+
+func (repo *projectRepository) FindRewardByID(rewardID uuid.UUID) (*entity.ProjectReward, error) {
+	var reward entity.ProjectReward
+	result := repo.db.
+		Where("id = ?", rewardID).
+		First(&reward)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find project reward by id")
+		return nil, result.Error
+	}
+
+	return &reward, nil
+}
+
+func (repo *projectRepository) CreateReward(reward *entity.ProjectReward) (*entity.ProjectReward, error) {
+	result := repo.db.
+		Create(&reward).
+		First(&reward)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to create project reward")
+		return nil, result.Error
+	}
+
+	return reward, nil
+}
+
+// UpdateReward saves the editable fields of a reward. quantity_claimed is left untouched so
+// concurrent claims are not overwritten, and a limit below the claimed count is rejected.
+func (repo *projectRepository) UpdateReward(reward *entity.ProjectReward) (*entity.ProjectReward, error) {
+	result := repo.db.
+		Model(reward).
+		Where("quantity_claimed <= COALESCE(?, quantity_claimed)", reward.QuantityLimit).
+		Select("title", "description", "minimum_amount", "estimated_delivery", "quantity_limit", "requires_shipping").
+		Updates(reward)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to update project reward")
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, apperrors.ErrRewardLimitBelowClaimed
+	}
+
+	if err := repo.db.First(reward, "id = ?", reward.ID).Error; err != nil {
+		repo.logger.Error().Err(err).Msg("failed to reload project reward")
+		return nil, err
+	}
+
+	return reward, nil
+}
+
+func (repo *projectRepository) DeleteReward(rewardID uuid.UUID) error {
+	result := repo.db.
+		Where("id = ?", rewardID).
+		Delete(&entity.ProjectReward{})
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to delete project reward")
+		return result.Error
+	}
+
+	return nil
+}
+
+// FindRefundableBackers lists the confirmed contributions to the project that have no refund yet,
+// or whose refund failed. When userID is not uuid.Nil only that user's contributions are listed.
+func (repo *projectRepository) FindRefundableBackers(projectID uuid.UUID, userID uuid.UUID) ([]entity.ProjectBacker, error) {
+	var backers []entity.ProjectBacker
+	query := repo.db.
+		Where("project_id = ? AND status = ?", projectID, entity.BackerConfirmed).
+		Where(`NOT EXISTS (
+            SELECT 1 FROM project_refunds
+            WHERE project_refunds.backer_id = project_backers.id
+                AND project_refunds.status <> ?
+                AND project_refunds.deleted_at IS NULL
+        )`, entity.RefundFailed)
+
+	if userID != uuid.Nil {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	if result := query.Find(&backers); result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find refundable project backers")
+		return nil, result.Error
+	}
+
+	return backers, nil
+}
+
+// RequestRefunds records a refund request for each contribution. A failed refund is requested
+// again; a refund that is already in progress or done is left as is and not returned.
+func (repo *projectRepository) RequestRefunds(backers []entity.ProjectBacker) ([]entity.ProjectRefund, error) {
+	if len(backers) == 0 {
+		return nil, nil
+	}
+
+	requests := make([]entity.ProjectRefund, 0, len(backers))
+	backerIDs := make([]uuid.UUID, 0, len(backers))
+	for _, backer := range backers {
+		requests = append(requests, entity.ProjectRefund{
+			BackerID:  backer.ID,
+			ProjectID: backer.ProjectID,
+			UserID:    backer.UserID,
+			Amount:    backer.Amount,
+			Status:    entity.RefundRequested,
+		})
+		backerIDs = append(backerIDs, backer.ID)
+	}
+
+	var refunds []entity.ProjectRefund
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "backer_id"}},
+				Where: clause.Where{Exprs: []clause.Expression{
+					clause.Eq{Column: clause.Column{Table: "project_refunds", Name: "status"}, Value: entity.RefundFailed},
+				}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"status":         entity.RefundRequested,
+					"failure_reason": "",
+					"updated_at":     time.Now(),
+				}),
+			}).
+			Create(&requests)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return tx.
+			Where("backer_id IN ? AND status = ?", backerIDs, entity.RefundRequested).
+			Find(&refunds).
+			Error
+	})
+	if err != nil {
+		repo.logger.Error().Err(err).Msg("failed to request project refunds")
+		return nil, err
+	}
+
+	return refunds, nil
+}
+
+func (repo *projectRepository) FindRefundByID(refundID uuid.UUID) (*entity.ProjectRefund, error) {
+	var refund entity.ProjectRefund
+	result := repo.db.
+		Preload("Backer").
+		Where("id = ?", refundID).
+		First(&refund)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find project refund by id")
+		return nil, result.Error
+	}
+
+	return &refund, nil
+}
+
+// FindOpenRefund returns the earliest refund of the user for the project and amount that is
+// requested or in progress.
+func (repo *projectRepository) FindOpenRefund(projectID, userID uuid.UUID, amount decimal.Decimal) (*entity.ProjectRefund, error) {
+	var refund entity.ProjectRefund
+	result := repo.db.
+		Where("project_id = ? AND user_id = ? AND amount = ?", projectID, userID, amount).
+		Where("status IN ?", []entity.RefundStatus{entity.RefundRequested, entity.RefundProcessing}).
+		Order("created_at ASC").
+		First(&refund)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find open project refund")
+		return nil, result.Error
+	}
+
+	return &refund, nil
+}
+
+// UpdateRefundStatus moves a refund from one status to another and reports whether it did.
+func (repo *projectRepository) UpdateRefundStatus(refundID uuid.UUID, from, to entity.RefundStatus) (bool, error) {
+	result := repo.db.
+		Model(&entity.ProjectRefund{}).
+		Where("id = ? AND status = ?", refundID, from).
+		Update("status", to)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to update project refund status")
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// CompleteRefund marks a refund in progress as refunded by the given on-chain event. An event
+// can settle only one refund.
+func (repo *projectRepository) CompleteRefund(refundID uuid.UUID, event *entity.ChainEvent) (bool, error) {
+	result := repo.db.
+		Model(&entity.ProjectRefund{}).
+		Where("id = ? AND status = ?", refundID, entity.RefundProcessing).
+		Updates(map[string]interface{}{
+			"status":         entity.RefundRefunded,
+			"chain_event_id": event.ID,
+			"tx_hash":        event.TxHash,
+		})
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to complete project refund")
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// FailRefund marks a refund that is not done yet as failed with the given reason.
+func (repo *projectRepository) FailRefund(refundID uuid.UUID, reason string) (bool, error) {
+	result := repo.db.
+		Model(&entity.ProjectRefund{}).
+		Where("id = ? AND status IN ?", refundID, []entity.RefundStatus{entity.RefundRequested, entity.RefundProcessing}).
+		Updates(map[string]interface{}{
+			"status":         entity.RefundFailed,
+			"failure_reason": reason,
+		})
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to fail project refund")
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// FindRefundEvent returns the earliest confirmed Refunded event of the contract that paid the
+// amount back to the account and has not settled a refund yet.
+func (repo *projectRepository) FindRefundEvent(contractAddress, account string, amount decimal.Decimal) (*entity.ChainEvent, error) {
+	var event entity.ChainEvent
+	result := repo.db.
+		Where("type = ? AND confirmed = ?", entity.ChainEventRefunded, true).
+		Where("LOWER(contract_address) = LOWER(?) AND LOWER(account) = LOWER(?) AND amount = ?", contractAddress, account, amount).
+		Where("NOT EXISTS (SELECT 1 FROM project_refunds WHERE project_refunds.chain_event_id = chain_events.id)").
+		Order("block_number ASC, log_index ASC").
+		First(&event)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find refund event")
+		return nil, result.Error
+	}
+
+	return &event, nil
+}
+
+// IsProjectBacker reports whether the user has a confirmed contribution to the project.
+func (repo *projectRepository) IsProjectBacker(projectID uuid.UUID, userID uuid.UUID) (bool, error) {
+	var count int64
+	result := repo.db.
+		Model(&entity.ProjectBacker{}).
+		Where("project_id = ? AND user_id = ? AND status = ?", projectID, userID, entity.BackerConfirmed).
+		Limit(1).
+		Count(&count)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to check project backer")
+		return false, result.Error
+	}
+
+	return count > 0, nil
+}
+
+// FindBackerEmails lists the email addresses of the users with a confirmed contribution to the
+// project, leaving out the placeholder addresses of wallet-only and deleted accounts.
+func (repo *projectRepository) FindBackerEmails(projectID uuid.UUID) ([]string, error) {
+	query := repo.db.
+		Model(&entity.User{}).
+		Distinct("users.email").
+		Joins("JOIN project_backers ON project_backers.user_id = users.id AND project_backers.deleted_at IS NULL").
+		Where("project_backers.project_id = ? AND project_backers.status = ?", projectID, entity.BackerConfirmed)
+
+	for _, domain := range entity.PlaceholderEmailDomains {
+		query = query.Where("users.email NOT LIKE ?", "%@"+domain)
+	}
+
+	var emails []string
+	result := query.Pluck("users.email", &emails)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find project backer emails")
+		return nil, result.Error
+	}
+
+	return emails, nil
+}
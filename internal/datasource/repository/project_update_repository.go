@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fund-o/api-server/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type ProjectUpdateRepository interface {
+	FindAllByProjectID(projectID uuid.UUID, includeBackersOnly bool) ([]entity.ProjectUpdate, error)
+	FindByID(updateID uuid.UUID) (*entity.ProjectUpdate, error)
+	Create(update *entity.ProjectUpdate) (*entity.ProjectUpdate, error)
+	Update(update *entity.ProjectUpdate) (*entity.ProjectUpdate, error)
+	Delete(updateID uuid.UUID) error
+}
+
+type projectUpdateRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewProjectUpdateRepository(db *gorm.DB) ProjectUpdateRepository {
+	logger := log.With().Str("module", "project_update_repository").Logger()
+	return &projectUpdateRepository{db, logger}
+}
+
+func (repo *projectUpdateRepository) WithContext(ctx context.Context) ProjectUpdateRepository {
+	return &projectUpdateRepository{repo.db.WithContext(ctx), repo.logger.With().Ctx(ctx).Logger()}
+}
+
+// FindAllByProjectID lists the updates of a project, newest first. Backers-only updates are left
+// out unless includeBackersOnly is set.
+func (repo *projectUpdateRepository) FindAllByProjectID(projectID uuid.UUID, includeBackersOnly bool) ([]entity.ProjectUpdate, error) {
+	var updates []entity.ProjectUpdate
+	query := repo.db.
+		Preload("Author").
+		Where("project_id = ?", projectID)
+
+	if !includeBackersOnly {
+		query = query.Where("visibility = ?", entity.UpdateVisibilityPublic)
+	}
+
+	if result := query.Order("created_at DESC").Find(&updates); result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to list project updates")
+		return nil, result.Error
+	}
+
+	return updates, nil
+}
+
+func (repo *projectUpdateRepository) FindByID(updateID uuid.UUID) (*entity.ProjectUpdate, error) {
+	var update entity.ProjectUpdate
+	result := repo.db.
+		Preload("Author").
+		Where("id = ?", updateID).
+		First(&update)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find project update by id")
+		return nil, result.Error
+	}
+
+	return &update, nil
+}
+
+func (repo *projectUpdateRepository) Create(update *entity.ProjectUpdate) (*entity.ProjectUpdate, error) {
+	if result := repo.db.Create(update); result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to create project update")
+		return nil, result.Error
+	}
+
+	return repo.FindByID(update.ID)
+}
+
+// Update saves the editable fields of a project update.
+func (repo *projectUpdateRepository) Update(update *entity.ProjectUpdate) (*entity.ProjectUpdate, error) {
+	result := repo.db.
+		Model(update).
+		Select("title", "body", "visibility").
+		Updates(update)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to update project update")
+		return nil, result.Error
+	}
+
+	return repo.FindByID(update.ID)
+}
+
+func (repo *projectUpdateRepository) Delete(updateID uuid.UUID) error {
+	result := repo.db.
+		Where("id = ?", updateID).
+		Delete(&entity.ProjectUpdate{})
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to delete project update")
+		return result.Error
+	}
+
+	return nil
+}
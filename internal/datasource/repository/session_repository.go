@@ -1,7 +1,10 @@
 package repository
 
 import (
+	"context"
 	"fund-o/api-server/internal/entity"
+	"time"
+
 	"github.com/rs/zerolog"
 
 	"github.com/google/uuid"
@@ -13,6 +16,11 @@ import (
 type SessionRepository interface {
 	Create(session *entity.Session) (*entity.Session, error)
 	FindByID(id uuid.UUID) (*entity.Session, error)
+	FindActiveByUserID(userID uuid.UUID, now time.Time) ([]entity.Session, error)
+	Block(id uuid.UUID, userID uuid.UUID) (bool, error)
+	BlockAllByUserID(userID uuid.UUID) (int64, error)
+	BlockFamily(familyID uuid.UUID) (int64, error)
+	Rotate(previousID uuid.UUID, next *entity.Session) (bool, error)
 }
 
 type sessionRepository struct {
@@ -25,6 +33,10 @@ func NewSessionRepository(db *gorm.DB) SessionRepository {
 	return &sessionRepository{db, logger}
 }
 
+func (repo *sessionRepository) WithContext(ctx context.Context) SessionRepository {
+	return &sessionRepository{repo.db.WithContext(ctx), repo.logger.With().Ctx(ctx).Logger()}
+}
+
 func (repo *sessionRepository) Create(session *entity.Session) (*entity.Session, error) {
 	if result := repo.db.Create(&session); result.Error != nil {
 		repo.logger.Error().Err(result.Error).Msg("failed to create session")
@@ -43,3 +55,87 @@ func (repo *sessionRepository) FindByID(id uuid.UUID) (*entity.Session, error) {
 
 	return &session, nil
 }
+
+func (repo *sessionRepository) FindActiveByUserID(userID uuid.UUID, now time.Time) ([]entity.Session, error) {
+	var sessions []entity.Session
+	result := repo.db.
+		Where("user_id = ? AND is_blocked = ? AND rotated_at IS NULL AND expired_at > ?", userID, false, now).
+		Order("expired_at DESC").
+		Find(&sessions)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find active sessions by user id: " + userID.String())
+		return nil, result.Error
+	}
+
+	return sessions, nil
+}
+
+// Block revokes a session of the user. Blocking an already blocked session still counts as done,
+// so false only means the user has no such session.
+func (repo *sessionRepository) Block(id uuid.UUID, userID uuid.UUID) (bool, error) {
+	result := repo.db.Model(&entity.Session{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("is_blocked", true)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to block session: " + id.String())
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (repo *sessionRepository) BlockAllByUserID(userID uuid.UUID) (int64, error) {
+	result := repo.db.Model(&entity.Session{}).
+		Where("user_id = ? AND is_blocked = ?", userID, false).
+		Update("is_blocked", true)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to block sessions by user id: " + userID.String())
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (repo *sessionRepository) BlockFamily(familyID uuid.UUID) (int64, error) {
+	result := repo.db.Model(&entity.Session{}).
+		Where("family_id = ? OR id = ?", familyID, familyID).
+		Update("is_blocked", true)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to block session family: " + familyID.String())
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// Rotate marks the previous session as rotated and creates its successor in one transaction. It
+// returns false without creating anything when the previous session was already rotated or
+// blocked, which happens when the same refresh token is renewed twice concurrently.
+func (repo *sessionRepository) Rotate(previousID uuid.UUID, next *entity.Session) (bool, error) {
+	rotated := false
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Session{}).
+			Where("id = ? AND rotated_at IS NULL AND is_blocked = ?", previousID, false).
+			Update("rotated_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+
+		rotated = true
+		return nil
+	})
+	if err != nil {
+		repo.logger.Error().Err(err).Msg("failed to rotate session: " + previousID.String())
+		return false, err
+	}
+
+	return rotated, nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"fund-o/api-server/internal/entity"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
@@ -12,7 +13,13 @@ type UserRepository interface {
 	Create(user *entity.User) (*entity.User, error)
 	FindByEmail(email string) (*entity.User, error)
 	FindById(id uuid.UUID) (*entity.User, error)
+	FindByWalletAddress(address string) (*entity.User, error)
+	CreateWallet(wallet *entity.UserWallet) (*entity.UserWallet, error)
+	DeleteWallet(userID uuid.UUID, address string) (bool, error)
+	FindByIdentity(provider, subject string) (*entity.User, error)
+	CreateIdentity(identity *entity.UserIdentity) (*entity.UserIdentity, error)
 	UpdateByID(id uuid.UUID, user *entity.User) (*entity.User, error)
+	Anonymize(id uuid.UUID, user *entity.User) error
 }
 
 type userRepository struct {
@@ -25,6 +32,10 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{db, logger}
 }
 
+func (repo *userRepository) WithContext(ctx context.Context) UserRepository {
+	return &userRepository{repo.db.WithContext(ctx), repo.logger.With().Ctx(ctx).Logger()}
+}
+
 func (repo *userRepository) Create(user *entity.User) (*entity.User, error) {
 	if result := repo.db.Create(&user); result.Error != nil {
 		repo.logger.Error().Err(result.Error).Msg("failed to create user")
@@ -36,7 +47,7 @@ func (repo *userRepository) Create(user *entity.User) (*entity.User, error) {
 
 func (repo *userRepository) FindByEmail(email string) (*entity.User, error) {
 	var user entity.User
-	if result := repo.db.Where("email = ?", email).First(&user); result.Error != nil {
+	if result := repo.db.Preload("Wallets").Where("email = ?", email).First(&user); result.Error != nil {
 		repo.logger.Error().Err(result.Error).Msg("failed to find user by email: " + email)
 		return nil, result.Error
 	}
@@ -46,7 +57,7 @@ func (repo *userRepository) FindByEmail(email string) (*entity.User, error) {
 
 func (repo *userRepository) FindById(id uuid.UUID) (*entity.User, error) {
 	var user entity.User
-	if result := repo.db.First(&user, id); result.Error != nil {
+	if result := repo.db.Preload("Wallets").Preload("Identities").First(&user, id); result.Error != nil {
 		repo.logger.Error().Err(result.Error).Msg("failed to find user by id: " + id.String())
 		return nil, result.Error
 	}
@@ -54,11 +65,131 @@ func (repo *userRepository) FindById(id uuid.UUID) (*entity.User, error) {
 	return &user, nil
 }
 
+func (repo *userRepository) FindByWalletAddress(address string) (*entity.User, error) {
+	var user entity.User
+	result := repo.db.
+		Preload("Wallets").
+		Joins("JOIN user_wallets ON user_wallets.user_id = users.id AND user_wallets.deleted_at IS NULL").
+		Where("user_wallets.address = ?", address).
+		First(&user)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find user by wallet address: " + address)
+		return nil, result.Error
+	}
+
+	return &user, nil
+}
+
+func (repo *userRepository) CreateWallet(wallet *entity.UserWallet) (*entity.UserWallet, error) {
+	if result := repo.db.Create(wallet); result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to create wallet: " + wallet.Address)
+		return nil, result.Error
+	}
+
+	return wallet, nil
+}
+
+// DeleteWallet permanently removes the link so that the address can be linked again later.
+func (repo *userRepository) DeleteWallet(userID uuid.UUID, address string) (bool, error) {
+	result := repo.db.Unscoped().
+		Where("user_id = ? AND address = ?", userID, address).
+		Delete(&entity.UserWallet{})
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to delete wallet: " + address)
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (repo *userRepository) FindByIdentity(provider, subject string) (*entity.User, error) {
+	var user entity.User
+	result := repo.db.
+		Preload("Wallets").
+		Joins("JOIN user_identities ON user_identities.user_id = users.id AND user_identities.deleted_at IS NULL").
+		Where("user_identities.provider = ? AND user_identities.subject = ?", provider, subject).
+		First(&user)
+	if result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to find user by identity: " + provider)
+		return nil, result.Error
+	}
+
+	return &user, nil
+}
+
+func (repo *userRepository) CreateIdentity(identity *entity.UserIdentity) (*entity.UserIdentity, error) {
+	if result := repo.db.Create(identity); result.Error != nil {
+		repo.logger.Error().Err(result.Error).Msg("failed to create identity: " + identity.Provider)
+		return nil, result.Error
+	}
+
+	return identity, nil
+}
+
 func (repo *userRepository) UpdateByID(id uuid.UUID, user *entity.User) (*entity.User, error) {
-	if result := repo.db.Model(&entity.User{}).Where("id = ?", id).Updates(&user).First(&user); result.Error != nil {
+	if result := repo.db.Model(&entity.User{}).Where("id = ?", id).Updates(&user).Preload("Wallets").First(&user); result.Error != nil {
 		repo.logger.Error().Err(result.Error).Msg("failed to update user by id: " + id.String())
 		return nil, result.Error
 	}
 
 	return user, nil
 }
+
+// Anonymize overwrites the profile of the user with the given placeholder and removes every way to
+// sign in along with the personal records that are not needed for accounting. Backings, refunds
+// and projects are kept and stay linked to the anonymous user.
+func (repo *userRepository) Anonymize(id uuid.UUID, user *entity.User) error {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		var current entity.User
+		if err := tx.Select("email").First(&current, id).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&entity.User{}).
+			Where("id = ?", id).
+			Select("email", "hashed_password", "firstname", "lastname", "display_name", "profile_image",
+				"birth_date", "gender", "is_email_verified", "role").
+			Updates(user)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := tx.Where("email = ?", current.Email).Delete(&entity.VerifyEmail{}).Error; err != nil {
+			return err
+		}
+
+		// Wallets and identities are removed for good so they can be used by another account.
+		for _, model := range []interface{}{&entity.UserWallet{}, &entity.UserIdentity{}} {
+			if err := tx.Unscoped().Where("user_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, model := range []interface{}{
+			&entity.UserTOTP{},
+			&entity.UserRecoveryCode{},
+			&entity.ApiKey{},
+			&entity.EmailChange{},
+			&entity.PasswordReset{},
+			&entity.DataExport{},
+		} {
+			if err := tx.Where("user_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("author_id = ?", id).Delete(&entity.Message{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&entity.Session{}).
+			Where("user_id = ?", id).
+			Update("is_blocked", true).Error
+	})
+	if err != nil {
+		repo.logger.Error().Err(err).Msg("failed to anonymize user: " + id.String())
+		return err
+	}
+
+	return nil
+}
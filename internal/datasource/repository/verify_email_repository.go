@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"fund-o/api-server/internal/entity"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -23,6 +24,10 @@ func NewVerifyEmailRepository(db *gorm.DB) VerifyEmailRepository {
 	return &verifyEmailRepository{db, logger}
 }
 
+func (repo *verifyEmailRepository) WithContext(ctx context.Context) VerifyEmailRepository {
+	return &verifyEmailRepository{repo.db.WithContext(ctx), repo.logger.With().Ctx(ctx).Logger()}
+}
+
 func (repo *verifyEmailRepository) Create(verifyEmail *entity.VerifyEmail) (*entity.VerifyEmail, error) {
 	if result := repo.db.Create(&verifyEmail); result.Error != nil {
 		repo.logger.Error().Err(result.Error).Msg("failed to create verify email: " + verifyEmail.Email)
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ApiKeyScopeRead  = "read"
+	ApiKeyScopeWrite = "write"
+)
+
+// ApiKey lets a user's scripts call the API without signing in. Only a SHA-256 hash of the key is
+// stored; the key itself is shown once when created. Prefix is the start of the key, kept so the
+// user can tell their keys apart.
+type ApiKey struct {
+	Base
+	UserID     uuid.UUID `gorm:"not null;index"`
+	User       User      `gorm:"foreignKey:UserID"`
+	Name       string    `gorm:"not null"`
+	Prefix     string    `gorm:"not null"`
+	HashedKey  string    `gorm:"not null;uniqueIndex"`
+	Scopes     string    `gorm:"not null"`
+	LastUsedAt *time.Time
+	ExpiredAt  *time.Time
+}
+
+type ApiKeyDto struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiredAt  *time.Time `json:"expired_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+} // @name ApiKey
+
+// Secondary types
+
+type ApiKeyCreatePayload struct {
+	Name      string     `json:"name" binding:"required,max=100" example:"Deploy script"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1,dive,oneof=read write" example:"read"`
+	ExpiredAt *time.Time `json:"expired_at"`
+} // @name ApiKeyCreatePayload
+
+type ApiKeyCreateResponse struct {
+	ApiKeyDto
+	Key string `json:"key"`
+} // @name ApiKeyCreateResponse
+
+// Parse functions
+
+func (k *ApiKey) ToApiKeyDto() *ApiKeyDto {
+	return &ApiKeyDto{
+		ID:         k.ID.String(),
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     k.ScopeList(),
+		LastUsedAt: k.LastUsedAt,
+		ExpiredAt:  k.ExpiredAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
+
+// ScopeList returns the scopes of the key, which are stored space separated.
+func (k *ApiKey) ScopeList() []string {
+	return strings.Fields(k.Scopes)
+}
+
+func (k *ApiKey) IsExpired(now time.Time) bool {
+	return k.ExpiredAt != nil && !now.Before(*k.ExpiredAt)
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type ChainEventType int
+
+const (
+	ChainEventProjectCreated ChainEventType = iota + 1
+	ChainEventContributed
+	ChainEventRefunded
+	ChainEventFundsWithdrawn
+)
+
+// ChainEvent is a project contract event read by the indexer. Events stay unconfirmed until
+// their block is deep enough, and unconfirmed events are dropped when the chain reorganizes.
+type ChainEvent struct {
+	Base
+	Type            ChainEventType  `gorm:"not null;index"`
+	ContractAddress string          `gorm:"type:varchar(42);not null;index"`
+	Account         string          `gorm:"type:varchar(42);not null"`
+	Amount          decimal.Decimal `gorm:"type:decimal(32,16);not null;default:0"`
+	TxHash          string          `gorm:"type:varchar(66);not null;uniqueIndex:idx_chain_event_log"`
+	LogIndex        uint64          `gorm:"not null;uniqueIndex:idx_chain_event_log"`
+	BlockNumber     uint64          `gorm:"not null;index"`
+	BlockHash       string          `gorm:"type:varchar(66);not null"`
+	Confirmed       bool            `gorm:"not null;default:false;index"`
+}
+
+// ChainCursor remembers the last block an indexer has read and its hash, which is used to
+// detect reorganizations on the next run.
+type ChainCursor struct {
+	Name        string `gorm:"primarykey;type:varchar(64)"`
+	BlockNumber uint64 `gorm:"not null"`
+	BlockHash   string `gorm:"type:varchar(66);not null"`
+	UpdatedAt   time.Time
+}
+
+// Secondary types
+
+type ChainIndexResult struct {
+	FromBlock  uint64
+	ToBlock    uint64
+	Indexed    int
+	Confirmed  int
+	RolledBack int64
+}
+
+// Parse functions
+
+func (t ChainEventType) String() string {
+	if t < ChainEventProjectCreated || t > ChainEventFundsWithdrawn {
+		return ""
+	}
+
+	return [...]string{"", "project_created", "contributed", "refunded", "funds_withdrawn"}[t]
+}
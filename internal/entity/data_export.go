@@ -0,0 +1,209 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DataExportStatus int
+
+const (
+	DataExportPending DataExportStatus = iota + 1
+	DataExportReady
+)
+
+// DeletedEmailDomain is the reserved domain of the placeholder email given to deleted accounts.
+// It can never receive mail.
+const DeletedEmailDomain = "deleted.invalid"
+
+// DataExport is an archive of the personal data of a user. It is generated by the worker and can
+// be downloaded with the secret code sent to the user until it expires. Users without an email
+// address get the code from the API instead. Only a hash of the secret code is stored.
+type DataExport struct {
+	Base
+	UserID         uuid.UUID        `gorm:"not null;index"`
+	Status         DataExportStatus `gorm:"not null;default:1"`
+	Archive        []byte           `gorm:"type:bytea"`
+	SecretCodeHash string
+	ExpiredAt      *time.Time
+}
+
+type DataExportDto struct {
+	ID          string     `json:"id"`
+	Status      string     `json:"status"`
+	ExpiredAt   *time.Time `json:"expired_at"`
+	DownloadURL string     `json:"download_url,omitempty"`
+	CreatedAt   string     `json:"created_at"`
+} // @name DataExport
+
+// UserData is everything stored about a user, as read from the database.
+type UserData struct {
+	User     User
+	Sessions []Session
+	Projects []Project
+	Backings []ProjectBacker
+	Ratings  []ProjectRating
+	Posts    []Post
+	Comments []Comment
+	Replies  []Reply
+	Messages []Message
+}
+
+// Secondary types
+
+type AccountDeletePayload struct {
+	Password string `json:"password" example:"@Password123"`
+} // @name AccountDeletePayload
+
+// DataExportLink is what the export email is made of.
+type DataExportLink struct {
+	Email string
+	URL   string
+}
+
+// UserDataArchive is the document a user downloads when exporting their data.
+type UserDataArchive struct {
+	ExportedAt time.Time            `json:"exported_at"`
+	Profile    *UserDto             `json:"profile"`
+	Sessions   []UserSessionDto     `json:"sessions"`
+	Projects   []ProjectDto         `json:"projects"`
+	Backings   []ProjectBackerDto   `json:"backings"`
+	Ratings    []ArchivedRatingDto  `json:"ratings"`
+	Posts      []ArchivedPostDto    `json:"posts"`
+	Comments   []ArchivedCommentDto `json:"comments"`
+	Replies    []ArchivedReplyDto   `json:"replies"`
+	Messages   []ArchivedMessageDto `json:"messages"`
+}
+
+type ArchivedRatingDto struct {
+	ProjectID string  `json:"project_id"`
+	Rating    float32 `json:"rating"`
+	CreatedAt string  `json:"created_at"`
+}
+
+type ArchivedPostDto struct {
+	ID          string `json:"id"`
+	ProjectID   string `json:"project_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Content     string `json:"content"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type ArchivedCommentDto struct {
+	ID        string `json:"id"`
+	PostID    string `json:"post_id"`
+	Content   string `json:"content"`
+	CreatedAt string `json:"created_at"`
+}
+
+type ArchivedReplyDto struct {
+	ID        string `json:"id"`
+	CommentID string `json:"comment_id"`
+	Content   string `json:"content"`
+	CreatedAt string `json:"created_at"`
+}
+
+type ArchivedMessageDto struct {
+	ID         string  `json:"id"`
+	ChannelID  string  `json:"channel_id"`
+	Text       *string `json:"text"`
+	Attachment *string `json:"attachment"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+// Parse functions
+
+func (e *DataExport) ToDataExportDto() *DataExportDto {
+	return &DataExportDto{
+		ID:        e.ID.String(),
+		Status:    e.Status.String(),
+		ExpiredAt: e.ExpiredAt,
+		CreatedAt: e.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (d *UserData) ToUserDataArchive(exportedAt time.Time) *UserDataArchive {
+	archive := &UserDataArchive{
+		ExportedAt: exportedAt,
+		Profile:    d.User.ToUserDto(),
+		Sessions:   make([]UserSessionDto, 0, len(d.Sessions)),
+		Projects:   make([]ProjectDto, 0, len(d.Projects)),
+		Backings:   make([]ProjectBackerDto, 0, len(d.Backings)),
+		Ratings:    make([]ArchivedRatingDto, 0, len(d.Ratings)),
+		Posts:      make([]ArchivedPostDto, 0, len(d.Posts)),
+		Comments:   make([]ArchivedCommentDto, 0, len(d.Comments)),
+		Replies:    make([]ArchivedReplyDto, 0, len(d.Replies)),
+		Messages:   make([]ArchivedMessageDto, 0, len(d.Messages)),
+	}
+
+	for _, session := range d.Sessions {
+		archive.Sessions = append(archive.Sessions, *session.ToUserSessionDto())
+	}
+
+	for _, project := range d.Projects {
+		archive.Projects = append(archive.Projects, *project.ToProjectDto())
+	}
+
+	for _, backing := range d.Backings {
+		archive.Backings = append(archive.Backings, *backing.ToProjectBackerDto())
+	}
+
+	for _, rating := range d.Ratings {
+		archive.Ratings = append(archive.Ratings, ArchivedRatingDto{
+			ProjectID: rating.ProjectID.String(),
+			Rating:    rating.Rating,
+			CreatedAt: rating.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	for _, post := range d.Posts {
+		archive.Posts = append(archive.Posts, ArchivedPostDto{
+			ID:          post.ID.String(),
+			ProjectID:   post.ProjectID.String(),
+			Title:       post.Title,
+			Description: post.Description,
+			Content:     post.Content,
+			CreatedAt:   post.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	for _, comment := range d.Comments {
+		archive.Comments = append(archive.Comments, ArchivedCommentDto{
+			ID:        comment.ID.String(),
+			PostID:    comment.PostID.String(),
+			Content:   comment.Content,
+			CreatedAt: comment.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	for _, reply := range d.Replies {
+		archive.Replies = append(archive.Replies, ArchivedReplyDto{
+			ID:        reply.ID.String(),
+			CommentID: reply.CommentID.String(),
+			Content:   reply.Content,
+			CreatedAt: reply.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	for _, message := range d.Messages {
+		archive.Messages = append(archive.Messages, ArchivedMessageDto{
+			ID:         message.ID.String(),
+			ChannelID:  message.ChannelID.String(),
+			Text:       message.Text,
+			Attachment: message.Attachment,
+			CreatedAt:  message.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return archive
+}
+
+func (s DataExportStatus) String() string {
+	if s < DataExportPending || s > DataExportReady {
+		return ""
+	}
+
+	return [...]string{"", "pending", "ready"}[s]
+}
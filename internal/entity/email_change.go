@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailChange is a pending change of a user's email. The email only changes once the link sent to
+// the new address is followed, which proves the user owns it. Only a hash of the secret code is
+// stored.
+type EmailChange struct {
+	Base
+	UserID         uuid.UUID `gorm:"not null;index"`
+	NewEmail       string    `gorm:"not null"`
+	SecretCodeHash string    `gorm:"not null"`
+	IsUsed         bool      `gorm:"not null;default:false"`
+	ExpiredAt      time.Time `gorm:"not null"`
+}
+
+// Secondary types
+
+type EmailChangePayload struct {
+	Email    string `json:"email" binding:"required,email" example:"newemail@gmail.com"`
+	Password string `json:"password" example:"@Password123"`
+} // @name EmailChangePayload
+
+type EmailChangeConfirmPayload struct {
+	ID         string `json:"id" binding:"required"`
+	SecretCode string `json:"secret_code" binding:"required"`
+} // @name EmailChangeConfirmPayload
+
+// EmailChangeLink is what the confirmation and notification emails are made of.
+type EmailChangeLink struct {
+	OldEmail string
+	NewEmail string
+	URL      string
+}
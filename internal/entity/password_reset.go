@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordReset is a single use link to choose a new password. Only a hash of the secret code is
+// stored, the code itself is only ever sent in the reset email.
+type PasswordReset struct {
+	Base
+	UserID         uuid.UUID `gorm:"not null;index"`
+	Email          string    `gorm:"not null"`
+	SecretCodeHash string    `gorm:"not null"`
+	IsUsed         bool      `gorm:"not null;default:false"`
+	ExpiredAt      time.Time `gorm:"not null"`
+}
+
+// Secondary types
+
+type ForgotPasswordPayload struct {
+	Email string `json:"email" binding:"required"`
+} // @name ForgotPasswordPayload
+
+type PasswordResetPayload struct {
+	ID                   string `json:"id" binding:"required"`
+	SecretCode           string `json:"secret_code" binding:"required"`
+	Password             string `json:"password" binding:"required"`
+	PasswordConfirmation string `json:"password_confirmation" binding:"required"`
+} // @name PasswordResetPayload
+
+// PasswordResetLink is what the reset email is made of.
+type PasswordResetLink struct {
+	Email string
+	URL   string
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fund-o/api-server/pkg/helper"
 	"fund-o/api-server/pkg/pagination"
 	"mime/multipart"
 	"time"
@@ -9,6 +10,39 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+const DefaultProjectCurrency = "ETH"
+
+type ProjectStatus int
+
+const (
+	ProjectDraft ProjectStatus = iota + 1
+	ProjectPendingReview
+	ProjectLive
+	ProjectSucceeded
+	ProjectFailed
+	ProjectCancelled
+)
+
+// PublicProjectStatuses are the statuses of the projects anyone may see. Projects in any other
+// status are only shown to their owner and to moderators.
+var PublicProjectStatuses = []ProjectStatus{ProjectLive, ProjectSucceeded, ProjectFailed}
+
+// projectStatusTransitions lists the statuses a project may move to from each status.
+// Succeeded, failed and cancelled are terminal.
+var projectStatusTransitions = map[ProjectStatus][]ProjectStatus{
+	ProjectDraft:         {ProjectPendingReview, ProjectCancelled},
+	ProjectPendingReview: {ProjectLive, ProjectDraft, ProjectCancelled},
+	ProjectLive:          {ProjectSucceeded, ProjectFailed, ProjectCancelled},
+}
+
+type ProjectBackerStatus int
+
+const (
+	BackerPending ProjectBackerStatus = iota + 1
+	BackerConfirmed
+	BackerRejected
+)
+
 type OldProject struct {
 	Base
 	Title          string `gorm:"type:varchar(255);not null"`
@@ -45,10 +79,15 @@ type Project struct {
 	Image             string
 	Ratings           []ProjectRating
 	Backers           []ProjectBacker
-	StartDate         time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
-	EndDate           time.Time `gorm:"not null"`
-	OwnerID           uuid.UUID `gorm:"not null"`
-	Owner             User      `gorm:"foreignKey:OwnerID"`
+	StartDate         time.Time       `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	EndDate           time.Time       `gorm:"not null"`
+	Status            ProjectStatus   `gorm:"not null;default:3;index"`
+	Goal              decimal.Decimal `gorm:"type:decimal(32,16);not null;default:0"`
+	Currency          string          `gorm:"type:varchar(16);not null;default:'ETH'"`
+	Raised            decimal.Decimal `gorm:"->;-:migration"`
+	BackerCount       int64           `gorm:"->;-:migration"`
+	OwnerID           uuid.UUID       `gorm:"not null"`
+	Owner             User            `gorm:"foreignKey:OwnerID"`
 }
 
 type ProjectDto struct {
@@ -64,6 +103,12 @@ type ProjectDto struct {
 	Rating            float32                `json:"rating"`
 	StartDate         string                 `json:"start_date"`
 	EndDate           string                 `json:"end_date"`
+	Status            string                 `json:"status"`
+	Goal              decimal.Decimal        `json:"goal"`
+	Currency          string                 `json:"currency"`
+	Raised            decimal.Decimal        `json:"raised"`
+	PercentFunded     float64                `json:"percent_funded"`
+	BackerCount       int64                  `json:"backer_count"`
 	Owner             *UserDto               `json:"owner"`
 	CreatedAt         string                 `json:"created_at"`
 } // @name Project
@@ -80,8 +125,23 @@ type ProjectBacker struct {
 	ProjectID uuid.UUID
 	UserID    uuid.UUID
 	Amount    decimal.Decimal `gorm:"type:decimal(32,16)"`
+	RewardID  *uuid.UUID
+	Reward    *ProjectReward      `gorm:"foreignKey:RewardID"`
+	TxHash    *string             `gorm:"type:varchar(66);uniqueIndex"`
+	Status    ProjectBackerStatus `gorm:"not null;default:2;index"`
 }
 
+type ProjectBackerDto struct {
+	ID        string          `json:"id"`
+	ProjectID string          `json:"project_id"`
+	UserID    string          `json:"user_id"`
+	Amount    decimal.Decimal `json:"amount"`
+	RewardID  *string         `json:"reward_id"`
+	TxHash    string          `json:"tx_hash"`
+	Status    string          `json:"status"`
+	CreatedAt string          `json:"created_at"`
+} // @name ProjectBacker
+
 // Secondary types
 
 type ProjectListParams struct {
@@ -89,12 +149,17 @@ type ProjectListParams struct {
 	Query         string `form:"q"`
 	CategoryID    string `form:"category"`
 	SubCategoryID string `form:"sub_category"`
+	Status        string `form:"status"`
 }
 
 type ProjectListOptions struct {
 	Query         string
 	CategoryID    uuid.UUID
 	SubCategoryID uuid.UUID
+	Status        ProjectStatus
+	// PublicOnly limits the list to projects in a public status, besides the projects of ViewerID.
+	PublicOnly bool
+	ViewerID   uuid.UUID
 }
 
 type ProjectCreatePayload struct {
@@ -107,6 +172,8 @@ type ProjectCreatePayload struct {
 	Location          string                `form:"location" binding:"required"`
 	Image             *multipart.FileHeader `form:"image" binding:"required"`
 	EndDate           string                `form:"end_date" binding:"required"`
+	Goal              string                `form:"goal" binding:"required"`
+	Currency          string                `form:"currency"`
 	OwnerID           string                `swaggerignore:"true"`
 }
 
@@ -118,17 +185,25 @@ type ProjectRatingCreatePayload struct {
 
 type ProjectBackerCreatePayload struct {
 	ProjectID string  `json:"project_id" binding:"required"`
-	Amount    float64 `json:"amount" binding:"required"`
+	Amount    float64 `json:"amount" binding:"required,gt=0"`
+	RewardID  string  `json:"reward_id"`
+	TxHash    string  `json:"tx_hash" binding:"required" example:"0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060"`
 }
 
 type ListBackedProjectResponse struct {
-	Project    ProjectDto      `json:"project"`
-	FundAmount decimal.Decimal `json:"fund_amount"`
+	Project        ProjectDto      `json:"project"`
+	FundAmount     decimal.Decimal `json:"fund_amount"`
+	RefundStatus   string          `json:"refund_status"`
+	RefundedAmount decimal.Decimal `json:"refunded_amount"`
 }
 
+// ProjectFunding sums up a user's contributions to a project. RefundStatus is zero when no refund
+// was requested, otherwise it is the status that needs the most attention among the refunds.
 type ProjectFunding struct {
-	ProjectID  uuid.UUID
-	TotalFunds decimal.Decimal
+	ProjectID      uuid.UUID
+	TotalFunds     decimal.Decimal
+	RefundStatus   RefundStatus
+	RefundedAmount decimal.Decimal
 }
 
 // Parse functions
@@ -157,7 +232,98 @@ func (p *Project) ToProjectDto() *ProjectDto {
 		Description:       p.Description,
 		StartDate:         p.StartDate.Format(time.RFC3339),
 		EndDate:           p.EndDate.Format(time.RFC3339),
+		Status:            p.Status.String(),
+		Goal:              p.Goal,
+		Currency:          p.Currency,
+		Raised:            p.Raised,
+		PercentFunded:     p.PercentFunded(),
+		BackerCount:       p.BackerCount,
 		Owner:             p.Owner.ToUserDto(),
 		CreatedAt:         p.CreatedAt.Format(time.RFC3339),
 	}
 }
+
+func (b *ProjectBacker) ToProjectBackerDto() *ProjectBackerDto {
+	var rewardID *string
+	if b.RewardID != nil {
+		id := b.RewardID.String()
+		rewardID = &id
+	}
+
+	var txHash string
+	if b.TxHash != nil {
+		txHash = *b.TxHash
+	}
+
+	return &ProjectBackerDto{
+		ID:        b.ID.String(),
+		ProjectID: b.ProjectID.String(),
+		UserID:    b.UserID.String(),
+		Amount:    b.Amount,
+		RewardID:  rewardID,
+		TxHash:    txHash,
+		Status:    b.Status.String(),
+		CreatedAt: b.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// PercentFunded returns how much of the goal has been raised, as a percentage rounded to two decimals.
+func (p *Project) PercentFunded() float64 {
+	if !p.Goal.IsPositive() {
+		return 0
+	}
+
+	percent, _ := p.Raised.Div(p.Goal).Mul(decimal.NewFromInt(100)).Round(2).Float64()
+	return percent
+}
+
+func (s ProjectStatus) String() string {
+	if s < ProjectDraft || s > ProjectCancelled {
+		return ""
+	}
+
+	return [...]string{"", "draft", "pending_review", "live", "succeeded", "failed", "cancelled"}[s]
+}
+
+// IsPublic reports whether anyone may see a project in the status.
+func (s ProjectStatus) IsPublic() bool {
+	for _, public := range PublicProjectStatuses {
+		if s == public {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanTransitionTo reports whether a project in status s may move to next.
+func (s ProjectStatus) CanTransitionTo(next ProjectStatus) bool {
+	for _, allowed := range projectStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+var ParseProjectStatus = func(str string) (ProjectStatus, bool) {
+	mapString := map[string]ProjectStatus{
+		"draft":          ProjectDraft,
+		"pending_review": ProjectPendingReview,
+		"live":           ProjectLive,
+		"succeeded":      ProjectSucceeded,
+		"failed":         ProjectFailed,
+		"cancelled":      ProjectCancelled,
+	}
+
+	return helper.ParseString(mapString, str)
+}
+
+func (s ProjectBackerStatus) String() string {
+	if s < BackerPending || s > BackerRejected {
+		return ""
+	}
+
+	return [...]string{"", "pending", "confirmed", "rejected"}[s]
+}
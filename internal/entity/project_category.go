@@ -27,6 +27,13 @@ type ProjectSubCategoryDto struct {
 	Name string `json:"name"`
 } // @name ProjectSubCategory
 
+// Secondary types
+
+type ProjectCategoryCreatePayload struct {
+	Name          string   `json:"name" binding:"required,max=255" example:"Technology"`
+	SubCategories []string `json:"subcategories" binding:"dive,required,max=255" example:"Gadgets,Software"`
+} // @name ProjectCategoryCreatePayload
+
 // Parse functions
 
 func (p *ProjectCategory) ToProjectCategoryDto() *ProjectCategoryDto {
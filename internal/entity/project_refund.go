@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type RefundStatus int
+
+const (
+	RefundRequested RefundStatus = iota + 1
+	RefundProcessing
+	RefundRefunded
+	RefundFailed
+)
+
+// ProjectRefund tracks giving a confirmed contribution back to its backer once the project
+// failed or was cancelled. There is at most one refund per contribution.
+type ProjectRefund struct {
+	Base
+	BackerID      uuid.UUID       `gorm:"not null;uniqueIndex"`
+	Backer        ProjectBacker   `gorm:"foreignKey:BackerID"`
+	ProjectID     uuid.UUID       `gorm:"not null;index"`
+	UserID        uuid.UUID       `gorm:"not null;index"`
+	Amount        decimal.Decimal `gorm:"type:decimal(32,16);not null"`
+	Status        RefundStatus    `gorm:"not null;default:1;index"`
+	ChainEventID  *uuid.UUID      `gorm:"type:uuid;uniqueIndex"`
+	TxHash        *string         `gorm:"type:varchar(66)"`
+	FailureReason string
+}
+
+type ProjectRefundDto struct {
+	ID            string          `json:"id"`
+	BackerID      string          `json:"backer_id"`
+	ProjectID     string          `json:"project_id"`
+	UserID        string          `json:"user_id"`
+	Amount        decimal.Decimal `json:"amount"`
+	Status        string          `json:"status"`
+	TxHash        string          `json:"tx_hash"`
+	FailureReason string          `json:"failure_reason"`
+	CreatedAt     string          `json:"created_at"`
+	UpdatedAt     string          `json:"updated_at"`
+} // @name ProjectRefund
+
+// Parse functions
+
+func (r *ProjectRefund) ToProjectRefundDto() *ProjectRefundDto {
+	var txHash string
+	if r.TxHash != nil {
+		txHash = *r.TxHash
+	}
+
+	return &ProjectRefundDto{
+		ID:            r.ID.String(),
+		BackerID:      r.BackerID.String(),
+		ProjectID:     r.ProjectID.String(),
+		UserID:        r.UserID.String(),
+		Amount:        r.Amount,
+		Status:        r.Status.String(),
+		TxHash:        txHash,
+		FailureReason: r.FailureReason,
+		CreatedAt:     r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     r.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// IsRefundable reports whether backers of a project in status s may get their contributions back.
+func (s ProjectStatus) IsRefundable() bool {
+	return s == ProjectFailed || s == ProjectCancelled
+}
+
+func (s RefundStatus) String() string {
+	if s < RefundRequested || s > RefundFailed {
+		return ""
+	}
+
+	return [...]string{"", "requested", "processing", "refunded", "failed"}[s]
+}
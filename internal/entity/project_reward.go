@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type ProjectReward struct {
+	Base
+	ProjectID         uuid.UUID `gorm:"not null;index"`
+	Title             string    `gorm:"type:varchar(255);not null"`
+	Description       string
+	MinimumAmount     decimal.Decimal `gorm:"type:decimal(32,16);not null"`
+	EstimatedDelivery time.Time       `gorm:"not null"`
+	QuantityLimit     *int64
+	QuantityClaimed   int64 `gorm:"not null;default:0"`
+	RequiresShipping  bool  `gorm:"not null;default:false"`
+}
+
+type ProjectRewardDto struct {
+	ID                string          `json:"id"`
+	ProjectID         string          `json:"project_id"`
+	Title             string          `json:"title"`
+	Description       string          `json:"description"`
+	MinimumAmount     decimal.Decimal `json:"minimum_amount"`
+	EstimatedDelivery string          `json:"estimated_delivery"`
+	QuantityLimit     *int64          `json:"quantity_limit"`
+	Remaining         *int64          `json:"remaining"`
+	RequiresShipping  bool            `json:"requires_shipping"`
+	CreatedAt         string          `json:"created_at"`
+} // @name ProjectReward
+
+// Secondary types
+
+type ProjectRewardCreatePayload struct {
+	Title             string  `json:"title" binding:"required"`
+	Description       string  `json:"description"`
+	MinimumAmount     float64 `json:"minimum_amount" binding:"required,gt=0"`
+	EstimatedDelivery string  `json:"estimated_delivery" binding:"required" example:"2025-01-01T00:00:00Z"`
+	QuantityLimit     *int64  `json:"quantity_limit" binding:"omitempty,gt=0"`
+	RequiresShipping  bool    `json:"requires_shipping"`
+	ProjectID         string  `swaggerignore:"true"`
+} // @name ProjectRewardCreatePayload
+
+type ProjectRewardUpdatePayload struct {
+	Title             *string  `json:"title"`
+	Description       *string  `json:"description"`
+	MinimumAmount     *float64 `json:"minimum_amount" binding:"omitempty,gt=0"`
+	EstimatedDelivery *string  `json:"estimated_delivery" example:"2025-01-01T00:00:00Z"`
+	QuantityLimit     *int64   `json:"quantity_limit" binding:"omitempty,gt=0,excluded_with=ClearQuantityLimit"`
+	// ClearQuantityLimit removes the limit of the reward, since a null quantity_limit reads the
+	// same as an absent one.
+	ClearQuantityLimit bool   `json:"clear_quantity_limit"`
+	RequiresShipping   *bool  `json:"requires_shipping"`
+	ProjectID          string `swaggerignore:"true"`
+	RewardID           string `swaggerignore:"true"`
+} // @name ProjectRewardUpdatePayload
+
+// Parse functions
+
+func (r *ProjectReward) ToProjectRewardDto() *ProjectRewardDto {
+	var remaining *int64
+	if r.QuantityLimit != nil {
+		left := *r.QuantityLimit - r.QuantityClaimed
+		if left < 0 {
+			left = 0
+		}
+		remaining = &left
+	}
+
+	return &ProjectRewardDto{
+		ID:                r.ID.String(),
+		ProjectID:         r.ProjectID.String(),
+		Title:             r.Title,
+		Description:       r.Description,
+		MinimumAmount:     r.MinimumAmount,
+		EstimatedDelivery: r.EstimatedDelivery.Format(time.RFC3339),
+		QuantityLimit:     r.QuantityLimit,
+		Remaining:         remaining,
+		RequiresShipping:  r.RequiresShipping,
+		CreatedAt:         r.CreatedAt.Format(time.RFC3339),
+	}
+}
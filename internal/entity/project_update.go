@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"fund-o/api-server/pkg/helper"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectUpdateVisibility int
+
+const (
+	UpdateVisibilityPublic ProjectUpdateVisibility = iota + 1
+	UpdateVisibilityBackersOnly
+)
+
+// ProjectUpdate is a progress update a creator posts to the people following a project.
+type ProjectUpdate struct {
+	Base
+	ProjectID  uuid.UUID               `gorm:"not null;index"`
+	AuthorID   uuid.UUID               `gorm:"not null"`
+	Author     User                    `gorm:"foreignKey:AuthorID"`
+	Title      string                  `gorm:"type:varchar(255);not null"`
+	Body       string                  `gorm:"type:text;not null"`
+	Visibility ProjectUpdateVisibility `gorm:"not null;default:1"`
+}
+
+type ProjectUpdateDto struct {
+	ID         string   `json:"id"`
+	ProjectID  string   `json:"project_id"`
+	Title      string   `json:"title"`
+	Body       string   `json:"body"`
+	Visibility string   `json:"visibility"`
+	Author     *UserDto `json:"author"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+} // @name ProjectUpdate
+
+// Secondary types
+
+type ProjectUpdateCreatePayload struct {
+	Title      string `json:"title" binding:"required,max=255"`
+	Body       string `json:"body" binding:"required"`
+	Visibility string `json:"visibility" binding:"omitempty,oneof=public backers_only" example:"public"`
+	ProjectID  string `swaggerignore:"true"`
+} // @name ProjectUpdateCreatePayload
+
+type ProjectUpdateUpdatePayload struct {
+	Title      *string `json:"title" binding:"omitempty,max=255"`
+	Body       *string `json:"body"`
+	Visibility *string `json:"visibility" binding:"omitempty,oneof=public backers_only" example:"backers_only"`
+	ProjectID  string  `swaggerignore:"true"`
+	UpdateID   string  `swaggerignore:"true"`
+} // @name ProjectUpdateUpdatePayload
+
+// ProjectUpdateNotification is what backers are told about a newly published update.
+type ProjectUpdateNotification struct {
+	ProjectTitle string
+	UpdateTitle  string
+}
+
+// Parse functions
+
+func (u *ProjectUpdate) ToProjectUpdateDto() *ProjectUpdateDto {
+	return &ProjectUpdateDto{
+		ID:         u.ID.String(),
+		ProjectID:  u.ProjectID.String(),
+		Title:      u.Title,
+		Body:       u.Body,
+		Visibility: u.Visibility.String(),
+		Author:     u.Author.ToUserDto(),
+		CreatedAt:  u.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  u.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (v ProjectUpdateVisibility) String() string {
+	if v < UpdateVisibilityPublic || v > UpdateVisibilityBackersOnly {
+		return ""
+	}
+
+	return [...]string{"", "public", "backers_only"}[v]
+}
+
+var ParseProjectUpdateVisibility = func(str string) (ProjectUpdateVisibility, bool) {
+	mapString := map[string]ProjectUpdateVisibility{
+		"public":       UpdateVisibilityPublic,
+		"backers_only": UpdateVisibilityBackersOnly,
+	}
+
+	return helper.ParseString(mapString, str)
+}
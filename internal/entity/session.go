@@ -6,13 +6,19 @@ import (
 	"github.com/google/uuid"
 )
 
+// Session records a refresh token. Every renewal rotates the refresh token into a new session of
+// the same family, the chain of sessions started by one sign in; ParentID points at the session
+// it replaced and RotatedAt is set once a session has been replaced.
 type Session struct {
-	ID           uuid.UUID `gorm:"primaryKey;type:uuid"`
-	UserID       uuid.UUID `gorm:"not null"`
-	RefreshToken string    `gorm:"not null"`
-	UserAgent    string    `gorm:"not null"`
-	ClientIP     string    `gorm:"not null"`
-	IsBlocked    bool      `gorm:"default:false;not null"`
+	ID           uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	UserID       uuid.UUID  `gorm:"not null"`
+	FamilyID     uuid.UUID  `gorm:"type:uuid;index"`
+	ParentID     *uuid.UUID `gorm:"type:uuid"`
+	RefreshToken string     `gorm:"not null"`
+	UserAgent    string     `gorm:"not null"`
+	ClientIP     string     `gorm:"not null"`
+	IsBlocked    bool       `gorm:"default:false;not null"`
+	RotatedAt    *time.Time
 	ExpiredAt    time.Time `gorm:"not null"`
 }
 
@@ -26,6 +32,14 @@ type SessionDto struct {
 	ExpiredAt    time.Time `json:"expired_at"`
 }
 
+// UserSessionDto describes a signed in device without exposing its refresh token.
+type UserSessionDto struct {
+	ID        string    `json:"id"`
+	UserAgent string    `json:"user_agent"`
+	ClientIP  string    `json:"client_ip"`
+	ExpiredAt time.Time `json:"expired_at"`
+} // @name UserSession
+
 // Secondary types
 
 type SessionCreatePayload struct {
@@ -39,6 +53,16 @@ type SessionCreatePayload struct {
 
 // Parse functions
 
+// Family returns the token family of the session. Sessions created before rotation was
+// introduced have no family and form one on their own.
+func (s *Session) Family() uuid.UUID {
+	if s.FamilyID == uuid.Nil {
+		return s.ID
+	}
+
+	return s.FamilyID
+}
+
 func (s *Session) ToSessionDto() *SessionDto {
 	return &SessionDto{
 		ID:           s.ID.String(),
@@ -50,3 +74,12 @@ func (s *Session) ToSessionDto() *SessionDto {
 		ExpiredAt:    s.ExpiredAt,
 	}
 }
+
+func (s *Session) ToUserSessionDto() *UserSessionDto {
+	return &UserSessionDto{
+		ID:        s.ID.String(),
+		UserAgent: s.UserAgent,
+		ClientIP:  s.ClientIP,
+		ExpiredAt: s.ExpiredAt,
+	}
+}
@@ -3,6 +3,7 @@ package entity
 import (
 	"fmt"
 	"mime/multipart"
+	"strings"
 	"time"
 
 	"fund-o/api-server/pkg/helper"
@@ -11,38 +12,67 @@ import (
 type UserRole int
 type Gender int
 
+const (
+	RoleUser UserRole = iota + 1
+	RoleCreator
+	RoleModerator
+	RoleAdmin
+)
+
 const (
 	Male Gender = iota + 1
 	Female
 	NotSay
 )
 
+// WalletEmailDomain is the reserved domain of the placeholder email given to wallet-only
+// accounts. It can never receive mail.
+const WalletEmailDomain = "wallet.invalid"
+
+// PlaceholderEmailDomains are the reserved domains of the emails made up for accounts without a
+// real one.
+var PlaceholderEmailDomains = []string{WalletEmailDomain, DeletedEmailDomain}
+
+// IsPlaceholderEmail reports whether the email was made up for an account without a real one.
+func IsPlaceholderEmail(email string) bool {
+	for _, domain := range PlaceholderEmailDomains {
+		if strings.HasSuffix(email, "@"+domain) {
+			return true
+		}
+	}
+
+	return false
+}
+
 type User struct {
 	Base
-	Email             string `gorm:"not null;uniqueIndex"`
-	HashedPassword    string `gorm:"not null"`
-	Firstname         string `gorm:"not null"`
-	Lastname          string `gorm:"not null"`
-	DisplayName       string `gorm:"not null"`
-	ProfileImage      string
-	BirthDate         time.Time `gorm:"not null"`
-	Gender            Gender    `gorm:"not null;default:3"`
-	MetaMaskAccountID string    `gorm:"default:'empty'"`
-	IsEmailVerified   bool      `gorm:"not null;default:false"`
+	Email           string `gorm:"not null;uniqueIndex"`
+	HashedPassword  string `gorm:"not null"`
+	Firstname       string `gorm:"not null"`
+	Lastname        string `gorm:"not null"`
+	DisplayName     string `gorm:"not null"`
+	ProfileImage    string
+	BirthDate       time.Time `gorm:"not null"`
+	Gender          Gender    `gorm:"not null;default:3"`
+	IsEmailVerified bool      `gorm:"not null;default:false"`
+	Role            UserRole  `gorm:"not null;default:1"`
+	Wallets         []UserWallet
+	Identities      []UserIdentity
 }
 
 type UserDto struct {
-	ID                string `json:"id"`
-	Email             string `json:"email"`
-	FullName          string `json:"full_name"`
-	DisplayName       string `json:"display_name"`
-	ProfileImage      string `json:"profile_image"`
-	BirthDate         string `json:"birthdate"`
-	Gender            string `json:"gender"`
-	MetamaskAccountID string `json:"metamask_account_id"`
-	IsEmailVerified   bool   `json:"is_email_verified"`
-	CreatedAt         string `json:"created_at"`
-	UpdatedAt         string `json:"updated_at"`
+	ID              string          `json:"id"`
+	Email           string          `json:"email"`
+	FullName        string          `json:"full_name"`
+	DisplayName     string          `json:"display_name"`
+	ProfileImage    string          `json:"profile_image"`
+	BirthDate       string          `json:"birthdate"`
+	Gender          string          `json:"gender"`
+	Role            string          `json:"role"`
+	Wallets         []UserWalletDto `json:"wallets"`
+	IsEmailVerified bool            `json:"is_email_verified"`
+	CreatedAt       string          `json:"created_at"`
+	UpdatedAt       string          `json:"updated_at"`
 } // @name User
 
 // Secondary types
@@ -58,18 +88,29 @@ type UserCreatePayload struct {
 } // @name UserCreatePayload
 
 type UserUpdatePayload struct {
-	Email             string                `form:"email"`
-	DisplayName       string                `form:"display_name"`
-	ProfileImage      *multipart.FileHeader `form:"profile_image"`
-	MetamaskAccountID string                `form:"metamask_account_id"`
-	IsEmailVerified   bool                  `form:"is_email_verified"`
+	DisplayName  string                `form:"display_name"`
+	ProfileImage *multipart.FileHeader `form:"profile_image"`
 } // @name UserUpdatePayload
 
+type UserRoleUpdatePayload struct {
+	Role string `json:"role" binding:"required,oneof=user creator moderator admin" example:"moderator"`
+} // @name UserRoleUpdatePayload
+
 type UserLoginPayload struct {
 	Email    string `json:"email" binding:"required" example:"someemail@gmail.com"`
 	Password string `json:"password" binding:"required" example:"@Password123"`
 } // @name UserLoginPayload
 
+type UserWalletLoginPayload struct {
+	Message   string `json:"message" binding:"required"`
+	Signature string `json:"signature" binding:"required" example:"0x5c50...1b"`
+} // @name UserWalletLoginPayload
+
+type WalletNonceResponse struct {
+	Nonce     string    `json:"nonce"`
+	ExpiredAt time.Time `json:"expired_at"`
+} // @name WalletNonceResponse
+
 type UserAuthenticateResponse struct {
 	SessionID             string    `json:"session_id"`
 	AccessToken           string    `json:"access_token"`
@@ -82,19 +123,55 @@ type UserAuthenticateResponse struct {
 // Parse functions
 
 func (u *User) ToUserDto() *UserDto {
+	wallets := make([]UserWalletDto, 0, len(u.Wallets))
+	for _, wallet := range u.Wallets {
+		wallets = append(wallets, wallet.ToUserWalletDto())
+	}
+
 	return &UserDto{
-		ID:                u.ID.String(),
-		Email:             u.Email,
-		DisplayName:       u.DisplayName,
-		FullName:          fmt.Sprintf("%s %s", u.Firstname, u.Lastname),
-		ProfileImage:      u.ProfileImage,
-		BirthDate:         u.BirthDate.Format(time.RFC3339),
-		Gender:            u.Gender.String(),
-		IsEmailVerified:   u.IsEmailVerified,
-		MetamaskAccountID: u.MetaMaskAccountID,
-		CreatedAt:         u.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:         u.UpdatedAt.Format(time.RFC3339),
+		ID:              u.ID.String(),
+		Email:           u.Email,
+		DisplayName:     u.DisplayName,
+		FullName:        fmt.Sprintf("%s %s", u.Firstname, u.Lastname),
+		ProfileImage:    u.ProfileImage,
+		BirthDate:       u.BirthDate.Format(time.RFC3339),
+		Gender:          u.Gender.String(),
+		Role:            u.Role.String(),
+		IsEmailVerified: u.IsEmailVerified,
+		Wallets:         wallets,
+		CreatedAt:       u.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:       u.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// HasWallet reports whether the address is one of the user's linked wallets.
+func (u *User) HasWallet(address string) bool {
+	for _, wallet := range u.Wallets {
+		if strings.EqualFold(wallet.Address, address) {
+			return true
+		}
 	}
+
+	return false
+}
+
+func (r UserRole) String() string {
+	if r < RoleUser || r > RoleAdmin {
+		return ""
+	}
+
+	return [...]string{"", "user", "creator", "moderator", "admin"}[r]
+}
+
+var ParseUserRole = func(str string) (UserRole, bool) {
+	mapString := map[string]UserRole{
+		"user":      RoleUser,
+		"creator":   RoleCreator,
+		"moderator": RoleModerator,
+		"admin":     RoleAdmin,
+	}
+
+	return helper.ParseString(mapString, str)
 }
 
 func (g Gender) String() string {
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdentityProviderGoogle is the provider name of identities signed in with Google.
+const IdentityProviderGoogle = "google"
+
+// UserIdentity links an account of an external identity provider to a user. The subject is the
+// provider's stable id of the account; the email of the account may change.
+type UserIdentity struct {
+	Base
+	UserID   uuid.UUID `gorm:"not null;index"`
+	Provider string    `gorm:"type:varchar(32);not null;uniqueIndex:idx_user_identity_subject"`
+	Subject  string    `gorm:"not null;uniqueIndex:idx_user_identity_subject"`
+	Email    string    `gorm:"not null"`
+}
+
+// Secondary types
+
+// OAuthAuthorization is a sign in started with an identity provider, which the user is sent to.
+type OAuthAuthorization struct {
+	URL       string
+	State     string
+	ExpiredAt time.Time
+}
+
+type OAuthCallbackPayload struct {
+	State string `json:"state" binding:"required"`
+	Code  string `json:"code" binding:"required"`
+} // @name OAuthCallbackPayload
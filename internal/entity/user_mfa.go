@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserTOTP is the authenticator app a user enrolled as second factor. It only protects the
+// account once confirmed with a first code. The secret is kept as is since every code is derived
+// from it.
+type UserTOTP struct {
+	Base
+	UserID       uuid.UUID `gorm:"not null;uniqueIndex"`
+	Secret       string    `gorm:"not null"`
+	ConfirmedAt  *time.Time
+	LastUsedStep int64 `gorm:"not null;default:0"`
+}
+
+// UserRecoveryCode is a single use code to pass the second factor without the authenticator.
+type UserRecoveryCode struct {
+	Base
+	UserID     uuid.UUID `gorm:"not null;index"`
+	HashedCode string    `gorm:"not null"`
+	UsedAt     *time.Time
+}
+
+// Secondary types
+
+type TOTPEnrollmentResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+} // @name TOTPEnrollmentResponse
+
+type TOTPCodePayload struct {
+	Code string `json:"code" binding:"required" example:"123456"`
+} // @name TOTPCodePayload
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+} // @name RecoveryCodesResponse
+
+type MfaChallengeResponse struct {
+	MfaRequired bool      `json:"mfa_required"`
+	MfaToken    string    `json:"mfa_token"`
+	ExpiredAt   time.Time `json:"expired_at"`
+} // @name MfaChallengeResponse
+
+type MfaVerifyPayload struct {
+	MfaToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required" example:"123456"`
+} // @name MfaVerifyPayload
+
+// IsConfirmed reports whether the authenticator is active and required at sign in.
+func (t *UserTOTP) IsConfirmed() bool {
+	return t.ConfirmedAt != nil
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserWallet is a wallet address a user proved to control by signing a challenge.
+type UserWallet struct {
+	Base
+	UserID  uuid.UUID `gorm:"not null;index"`
+	Address string    `gorm:"type:varchar(42);not null;uniqueIndex"`
+}
+
+type UserWalletDto struct {
+	Address  string `json:"address"`
+	LinkedAt string `json:"linked_at"`
+} // @name UserWallet
+
+// Secondary types
+
+type UserWalletChallengePayload struct {
+	Address string `json:"address" binding:"required" example:"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"`
+} // @name UserWalletChallengePayload
+
+type UserWalletChallengeResponse struct {
+	Message   string    `json:"message"`
+	Nonce     string    `json:"nonce"`
+	ExpiredAt time.Time `json:"expired_at"`
+} // @name UserWalletChallengeResponse
+
+type UserWalletLinkPayload struct {
+	Message   string `json:"message" binding:"required"`
+	Signature string `json:"signature" binding:"required"`
+} // @name UserWalletLinkPayload
+
+// Parse functions
+
+func (w *UserWallet) ToUserWalletDto() UserWalletDto {
+	return UserWalletDto{
+		Address:  w.Address,
+		LinkedAt: w.CreatedAt.Format(time.RFC3339),
+	}
+}
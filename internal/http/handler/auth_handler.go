@@ -1,46 +1,80 @@
 package handler
 
 import (
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"fund-o/api-server/cmd/worker"
 	"fund-o/api-server/internal/entity"
+	"fund-o/api-server/internal/http/middleware"
 	"fund-o/api-server/internal/usecase"
 	"fund-o/api-server/pkg/apperrors"
+	"fund-o/api-server/pkg/chain"
+	"fund-o/api-server/pkg/oauth"
 	"fund-o/api-server/pkg/password"
+	"fund-o/api-server/pkg/siwe"
 	"fund-o/api-server/pkg/token"
+	"math"
 	"net/http"
+	"path"
+	"strconv"
 	"time"
 
 	"github.com/hibiken/asynq"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// googleStateCookie keeps the state of a Google sign in in the browser that started it, so that a
+// callback cannot be completed with a state issued to someone else.
+const googleStateCookie = "google_oauth_state"
+
 type AuthHandlerOptions struct {
 	usecase.UserUseCase
 	usecase.SessionUseCase
 	usecase.VerifyEmailUseCase
+	usecase.WalletAuthUseCase
+	usecase.PasswordResetUseCase
+	usecase.EmailChangeUseCase
+	usecase.GoogleAuthUseCase
+	usecase.MfaUseCase
+	usecase.LoginAttemptUseCase
 	TokenMaker token.Maker
+	KeySet     *token.KeySet
 	worker.TaskDistributor
 }
 
 type AuthHandler struct {
-	userUseCase        usecase.UserUseCase
-	sessionUseCase     usecase.SessionUseCase
-	verifyEmailUseCase usecase.VerifyEmailUseCase
-	tokenMaker         token.Maker
-	taskDistributor    worker.TaskDistributor
+	userUseCase          usecase.UserUseCase
+	sessionUseCase       usecase.SessionUseCase
+	verifyEmailUseCase   usecase.VerifyEmailUseCase
+	walletAuthUseCase    usecase.WalletAuthUseCase
+	passwordResetUseCase usecase.PasswordResetUseCase
+	emailChangeUseCase   usecase.EmailChangeUseCase
+	googleAuthUseCase    usecase.GoogleAuthUseCase
+	mfaUseCase           usecase.MfaUseCase
+	loginAttemptUseCase  usecase.LoginAttemptUseCase
+	tokenMaker           token.Maker
+	keySet               *token.KeySet
+	taskDistributor      worker.TaskDistributor
 }
 
 func NewAuthHandler(options *AuthHandlerOptions) *AuthHandler {
 	return &AuthHandler{
-		userUseCase:        options.UserUseCase,
-		sessionUseCase:     options.SessionUseCase,
-		verifyEmailUseCase: options.VerifyEmailUseCase,
-		tokenMaker:         options.TokenMaker,
-		taskDistributor:    options.TaskDistributor,
+		userUseCase:          options.UserUseCase,
+		sessionUseCase:       options.SessionUseCase,
+		verifyEmailUseCase:   options.VerifyEmailUseCase,
+		walletAuthUseCase:    options.WalletAuthUseCase,
+		passwordResetUseCase: options.PasswordResetUseCase,
+		emailChangeUseCase:   options.EmailChangeUseCase,
+		googleAuthUseCase:    options.GoogleAuthUseCase,
+		mfaUseCase:           options.MfaUseCase,
+		loginAttemptUseCase:  options.LoginAttemptUseCase,
+		tokenMaker:           options.TokenMaker,
+		keySet:               options.KeySet,
+		taskDistributor:      options.TaskDistributor,
 	}
 }
 
@@ -88,72 +122,128 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		HashedPassword: hashedPassword,
 		BirthDate:      birthDate,
 		Gender:         entity.ParseGender(user.Gender),
+		Role:           entity.RoleUser,
 	})
 	if err != nil {
 		c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, err.Error()))
 		return
 	}
 
-	accessToken, accessTokenPayload, err := h.tokenMaker.CreateToken(userDto.ID, 15*time.Minute)
+	response, err := h.createUserSession(c, userDto)
 	if err != nil {
 		c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error authenticate user: %v", err.Error())))
 		return
 	}
 
-	refreshToken, refreshTokenPayload, err := h.tokenMaker.CreateToken(userDto.ID, 24*time.Hour)
+	c.JSON(makeHttpResponse(http.StatusCreated, response))
+}
+
+// Login godoc
+// @summary Authenticate User
+// @description Authenticate user with email and password. Users with two-factor authentication get a challenge to answer at /auth/mfa/verify instead of the tokens. Repeated failures delay further attempts and lock the account out for a while
+// @tags auth
+// @id Login
+// @accept json
+// @produce json
+// @param User body entity.UserLoginPayload true "User data to be authenticated"
+// @response 200 {object} handler.ResultResponse[entity.UserAuthenticateResponse] "OK"
+// @response 202 {object} handler.ResultResponse[entity.MfaChallengeResponse] "Accepted"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 429 {object} handler.ErrorResponse "Too Many Requests"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req entity.UserLoginPayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, fmt.Sprintf("error authenticate user: %v", err.Error())))
+		return
+	}
+
+	ip := c.ClientIP()
+	retryAfter, err := h.loginAttemptUseCase.Check(c, req.Email, ip)
 	if err != nil {
+		if errors.Is(err, apperrors.ErrTooManyLoginAttempts) {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.JSON(makeHttpErrorResponse(http.StatusTooManyRequests, fmt.Sprintf("error authenticate user: %v", err.Error())))
+			return
+		}
+
 		c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error authenticate user: %v", err.Error())))
 		return
 	}
 
-	session, err := h.sessionUseCase.CreateSession(&entity.SessionCreatePayload{
-		ID:           refreshTokenPayload.ID,
-		UserID:       userDto.ID,
-		RefreshToken: refreshToken,
-		UserAgent:    c.Request.UserAgent(),
-		ClientIP:     c.ClientIP(),
-		ExpiredAt:    refreshTokenPayload.ExpiredAt,
-	})
+	user, err := h.userUseCase.AuthenticateUser(&req)
 	if err != nil {
-		c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error authenticate user: %v", err.Error())))
+		if !errors.Is(err, apperrors.ErrInvalidCredentials) {
+			c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error authenticate user: %v", err.Error())))
+			return
+		}
+
+		locked, err := h.loginAttemptUseCase.RecordFailure(c, req.Email, ip)
+		if err != nil {
+			c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error authenticate user: %v", err.Error())))
+			return
+		}
+
+		// The email is sent whether or not an account exists, so the response stays the same.
+		if locked {
+			h.taskDistributor.DistributeTaskSendAccountLockedEmail(c, &worker.PayloadSendAccountLockedEmail{
+				Email:       req.Email,
+				LockedUntil: time.Now().Add(h.loginAttemptUseCase.LockoutDuration()),
+			}, asynq.Queue(worker.QueueCritical))
+		}
+
+		c.JSON(makeHttpErrorResponse(http.StatusUnauthorized, fmt.Sprintf("error authenticate user: %v", apperrors.ErrInvalidCredentials.Error())))
 		return
 	}
 
-	response := entity.UserAuthenticateResponse{
-		SessionID:             session.ID,
-		AccessToken:           accessToken,
-		AccessTokenExpiredAt:  accessTokenPayload.ExpiredAt,
-		RefreshToken:          refreshToken,
-		RefreshTokenExpiredAt: refreshTokenPayload.ExpiredAt,
-		User:                  userDto,
+	// The failures are kept until the second factor is verified too, if the user has one.
+	if h.requireSecondFactor(c, user) {
+		return
 	}
 
-	c.JSON(makeHttpResponse(http.StatusCreated, response))
+	if err := h.loginAttemptUseCase.RecordSuccess(c, req.Email); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error authenticate user: %v", err.Error())))
+		return
+	}
+
+	h.respondUserSession(c, user)
 }
 
-// Login godoc
-// @summary Authenticate User
-// @description Authenticate user with email and password
+// VerifyMfa godoc
+// @summary Verify Two-Factor Authentication
+// @description Finish signing in by answering the login challenge with an authenticator code or a recovery code. Repeated wrong codes delay further attempts and lock the user out for a while
 // @tags auth
-// @id Login
+// @id VerifyMfa
 // @accept json
 // @produce json
-// @param User body entity.UserLoginPayload true "User data to be authenticated"
+// @param Challenge body entity.MfaVerifyPayload true "Challenge token and code"
 // @response 200 {object} handler.ResultResponse[entity.UserAuthenticateResponse] "OK"
 // @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 429 {object} handler.ErrorResponse "Too Many Requests"
 // @response 500 {object} handler.ErrorResponse "Internal Server Error"
-// @router /auth/login [post]
-func (h *AuthHandler) Login(c *gin.Context) {
-	var req entity.UserLoginPayload
+// @router /auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMfa(c *gin.Context) {
+	var req entity.MfaVerifyPayload
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, fmt.Sprintf("error authenticate user: %v", err.Error())))
 		return
 	}
 
-	user, err := h.userUseCase.AuthenticateUser(&req)
+	userID, err := h.mfaUseCase.ConsumeChallenge(c, req.MfaToken)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.JSON(makeHttpErrorResponse(http.StatusNotFound, fmt.Sprintf("error authenticate user: %v", err.Error())))
+		c.JSON(makeHttpErrorResponse(mfaErrorStatus(err), fmt.Sprintf("error authenticate user: %v", err.Error())))
+		return
+	}
+
+	ip := c.ClientIP()
+	retryAfter, err := h.loginAttemptUseCase.CheckMfa(c, userID, ip)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrTooManyLoginAttempts) {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.JSON(makeHttpErrorResponse(http.StatusTooManyRequests, fmt.Sprintf("error authenticate user: %v", err.Error())))
 			return
 		}
 
@@ -161,62 +251,300 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	accessToken, accessTokenPayload, err := h.tokenMaker.CreateToken(user.ID, 15*time.Minute)
+	user, err := h.mfaUseCase.VerifyChallenge(userID, req.Code)
 	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidTOTPCode) {
+			if _, err := h.loginAttemptUseCase.RecordMfaFailure(c, userID, ip); err != nil {
+				c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error authenticate user: %v", err.Error())))
+				return
+			}
+		}
+
+		c.JSON(makeHttpErrorResponse(mfaErrorStatus(err), fmt.Sprintf("error authenticate user: %v", err.Error())))
+		return
+	}
+
+	if err := h.loginAttemptUseCase.RecordMfaSuccess(c, user.ID, user.Email); err != nil {
 		c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error authenticate user: %v", err.Error())))
 		return
 	}
 
-	refreshToken, refreshTokenPayload, err := h.tokenMaker.CreateToken(user.ID, 24*time.Hour)
+	h.respondUserSession(c, user)
+}
+
+func mfaErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, apperrors.ErrInvalidUserID),
+		errors.Is(err, apperrors.ErrTOTPNotEnrolled):
+		return http.StatusBadRequest
+	case errors.Is(err, apperrors.ErrInvalidMfaToken),
+		errors.Is(err, apperrors.ErrInvalidTOTPCode):
+		return http.StatusUnauthorized
+	case errors.Is(err, apperrors.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, apperrors.ErrTOTPAlreadyEnabled):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// GetWalletNonce godoc
+// @summary Get Wallet Nonce
+// @description Create a single-use nonce to put in a Sign-In with Ethereum message
+// @tags auth
+// @id GetWalletNonce
+// @produce json
+// @response 200 {object} handler.ResultResponse[entity.WalletNonceResponse] "OK"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /auth/wallet/nonce [get]
+func (h *AuthHandler) GetWalletNonce(c *gin.Context) {
+	nonce, err := h.walletAuthUseCase.CreateNonce(c)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error create nonce: %v", err.Error())))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusOK, nonce))
+}
+
+// LoginWithWallet godoc
+// @summary Authenticate User With Wallet
+// @description Authenticate user with a signed Sign-In with Ethereum (EIP-4361) message. Users with two-factor authentication get a challenge to answer at /auth/mfa/verify instead of the tokens
+// @tags auth
+// @id LoginWithWallet
+// @accept json
+// @produce json
+// @param User body entity.UserWalletLoginPayload true "Signed message"
+// @response 200 {object} handler.ResultResponse[entity.UserAuthenticateResponse] "OK"
+// @response 202 {object} handler.ResultResponse[entity.MfaChallengeResponse] "Accepted"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /auth/wallet/verify [post]
+func (h *AuthHandler) LoginWithWallet(c *gin.Context) {
+	var req entity.UserWalletLoginPayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, fmt.Sprintf("error authenticate user: %v", err.Error())))
+		return
+	}
+
+	user, err := h.walletAuthUseCase.AuthenticateWallet(c, &req)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(walletLoginErrorStatus(err), fmt.Sprintf("error authenticate user: %v", err.Error())))
+		return
+	}
+
+	h.signIn(c, user)
+}
+
+func walletLoginErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, siwe.ErrInvalidMessage),
+		errors.Is(err, chain.ErrInvalidSignature):
+		return http.StatusBadRequest
+	case errors.Is(err, siwe.ErrDomainMismatch),
+		errors.Is(err, siwe.ErrChainIDMismatch),
+		errors.Is(err, siwe.ErrMessageExpired),
+		errors.Is(err, siwe.ErrMessageNotYetValid),
+		errors.Is(err, apperrors.ErrWalletSignatureMismatch),
+		errors.Is(err, apperrors.ErrInvalidNonce):
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// LoginWithGoogle godoc
+// @summary Login With Google
+// @description Redirect to Google to sign in. Google redirects back to the frontend afterwards, which completes the sign in at the callback endpoint. The state of the sign in is kept in a cookie of this browser
+// @tags auth
+// @id LoginWithGoogle
+// @response 307 "Temporary Redirect"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /auth/google [get]
+func (h *AuthHandler) LoginWithGoogle(c *gin.Context) {
+	authorization, err := h.googleAuthUseCase.CreateAuthCodeURL(c)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error sign in with google: %v", err.Error())))
+		return
+	}
+
+	// The cookie is scoped to this endpoint, which the callback endpoint is under.
+	maxAge := int(time.Until(authorization.ExpiredAt).Seconds())
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(googleStateCookie, authorization.State, maxAge, c.Request.URL.Path, "", isSecureRequest(c), true)
+	c.Redirect(http.StatusTemporaryRedirect, authorization.URL)
+}
+
+// GoogleCallback godoc
+// @summary Google Callback
+// @description Complete a Google sign in with the authorization code and state Google redirected to the frontend with. The state has to match the one kept in the cookie of the browser that started the sign in. Users with two-factor authentication get a challenge to answer at /auth/mfa/verify instead of the tokens
+// @tags auth
+// @id GoogleCallback
+// @accept json
+// @produce json
+// @param Callback body entity.OAuthCallbackPayload true "State and authorization code"
+// @response 200 {object} handler.ResultResponse[entity.UserAuthenticateResponse] "OK"
+// @response 202 {object} handler.ResultResponse[entity.MfaChallengeResponse] "Accepted"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 403 {object} handler.ErrorResponse "Forbidden"
+// @response 409 {object} handler.ErrorResponse "Conflict"
+// @response 502 {object} handler.ErrorResponse "Bad Gateway"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /auth/google/callback [post]
+func (h *AuthHandler) GoogleCallback(c *gin.Context) {
+	var req entity.OAuthCallbackPayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, fmt.Sprintf("error sign in with google: %v", err.Error())))
+		return
+	}
+
+	// A state can be used once, so the cookie is cleared whatever the outcome.
+	state, _ := c.Cookie(googleStateCookie)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(googleStateCookie, "", -1, path.Dir(c.Request.URL.Path), "", isSecureRequest(c), true)
+
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(req.State)) != 1 {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, fmt.Sprintf("error sign in with google: %v", apperrors.ErrInvalidOAuthState.Error())))
+		return
+	}
+
+	user, err := h.googleAuthUseCase.AuthenticateGoogle(c, &req)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(googleLoginErrorStatus(err), fmt.Sprintf("error sign in with google: %v", err.Error())))
+		return
+	}
+
+	h.signIn(c, user)
+}
+
+// isSecureRequest reports whether the request came over HTTPS, directly or through a proxy.
+func isSecureRequest(c *gin.Context) bool {
+	return c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+}
+
+func googleLoginErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, apperrors.ErrInvalidOAuthState):
+		return http.StatusBadRequest
+	case errors.Is(err, oauth.ErrExchangeFailed):
+		return http.StatusUnauthorized
+	case errors.Is(err, apperrors.ErrOAuthEmailNotVerified):
+		return http.StatusForbidden
+	case errors.Is(err, apperrors.ErrOAuthAccountNotVerified):
+		return http.StatusConflict
+	case errors.Is(err, oauth.ErrUserInfoFailed):
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// signIn finishes a sign in once the user proved the first factor, whatever the method. Users
+// with two-factor authentication get a challenge to answer at /auth/mfa/verify; no session is
+// created until the second factor is verified.
+func (h *AuthHandler) signIn(c *gin.Context, user *entity.UserDto) {
+	if h.requireSecondFactor(c, user) {
+		return
+	}
+
+	h.respondUserSession(c, user)
+}
+
+// requireSecondFactor answers with a challenge when the user has two-factor authentication. It
+// reports whether the request was answered, with the challenge or with an error.
+func (h *AuthHandler) requireSecondFactor(c *gin.Context, user *entity.UserDto) bool {
+	challenge, err := h.mfaUseCase.CreateChallenge(c, user.ID)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error authenticate user: %v", err.Error())))
+		return true
+	}
+
+	if challenge != nil {
+		c.JSON(makeHttpResponse(http.StatusAccepted, challenge))
+		return true
+	}
+
+	return false
+}
+
+// respondUserSession answers with the tokens of a new session of the user.
+func (h *AuthHandler) respondUserSession(c *gin.Context, user *entity.UserDto) {
+	response, err := h.createUserSession(c, user)
 	if err != nil {
 		c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error authenticate user: %v", err.Error())))
 		return
 	}
 
-	session, err := h.sessionUseCase.CreateSession(&entity.SessionCreatePayload{
+	c.JSON(makeHttpResponse(http.StatusOK, response))
+}
+
+// createUserSession issues an access token and a refresh token for the user and records the
+// refresh token as a new session.
+func (h *AuthHandler) createUserSession(c *gin.Context, user *entity.UserDto) (*entity.UserAuthenticateResponse, error) {
+	return h.issueUserSession(c, user, uuid.Nil)
+}
+
+// issueUserSession issues an access token and a refresh token for the user. The refresh token
+// starts a new session, or replaces the previous session when one is given.
+func (h *AuthHandler) issueUserSession(c *gin.Context, user *entity.UserDto, previousSessionID uuid.UUID) (*entity.UserAuthenticateResponse, error) {
+	accessToken, accessTokenPayload, err := h.tokenMaker.CreateToken(user.ID, user.Role, token.TypeAccess, 15*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, refreshTokenPayload, err := h.tokenMaker.CreateToken(user.ID, user.Role, token.TypeRefresh, 24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &entity.SessionCreatePayload{
 		ID:           refreshTokenPayload.ID,
 		UserID:       user.ID,
 		RefreshToken: refreshToken,
 		UserAgent:    c.Request.UserAgent(),
 		ClientIP:     c.ClientIP(),
 		ExpiredAt:    refreshTokenPayload.ExpiredAt,
-	})
+	}
+
+	var session *entity.SessionDto
+	if previousSessionID == uuid.Nil {
+		session, err = h.sessionUseCase.CreateSession(payload)
+	} else {
+		session, err = h.sessionUseCase.RotateSession(previousSessionID, payload)
+	}
 	if err != nil {
-		c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error authenticate user: %v", err.Error())))
-		return
+		return nil, err
 	}
 
-	response := entity.UserAuthenticateResponse{
+	return &entity.UserAuthenticateResponse{
 		SessionID:             session.ID,
 		AccessToken:           accessToken,
 		AccessTokenExpiredAt:  accessTokenPayload.ExpiredAt,
 		RefreshToken:          refreshToken,
 		RefreshTokenExpiredAt: refreshTokenPayload.ExpiredAt,
 		User:                  user,
-	}
-
-	c.JSON(makeHttpResponse(http.StatusOK, response))
+	}, nil
 }
 
 type RenewAccessTokenPayload struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
-type RenewAccessTokenResponse struct {
-	AccessToken          string    `json:"access_token"`
-	AccessTokenExpiredAt time.Time `json:"access_token_expired_at"`
-}
-
 // RenewAccessToken godoc
 // @summary Renew Access Token
-// @description Renew access token with refresh token
+// @description Renew access token with refresh token. The refresh token is rotated: the response
+// @description carries a new refresh token and reusing the old one signs out the whole session
 // @tags auth
 // @id RenewAccessToken
 // @accept json
 // @produce json
 // @param User body handler.RenewAccessTokenPayload true "Refresh token to be renewed"
-// @response 200 {object} handler.ResultResponse[handler.RenewAccessTokenResponse] "OK"
+// @response 200 {object} handler.ResultResponse[entity.UserAuthenticateResponse] "OK"
 // @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
 // @response 500 {object} handler.ErrorResponse "Internal Server Error"
 // @router /auth/renew-token [post]
 func (h *AuthHandler) RenewAccessToken(c *gin.Context) {
@@ -226,9 +554,10 @@ func (h *AuthHandler) RenewAccessToken(c *gin.Context) {
 		return
 	}
 
-	refreshTokenPayload, err := h.tokenMaker.VerifyToken(req.RefreshToken)
+	refreshTokenPayload, err := h.verifyRefreshToken(req.RefreshToken)
 	if err != nil {
 		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, err.Error()))
+		return
 	}
 
 	session, err := h.sessionUseCase.GetSessionByID(refreshTokenPayload.ID)
@@ -248,7 +577,7 @@ func (h *AuthHandler) RenewAccessToken(c *gin.Context) {
 		return
 	}
 
-	if session.UserID != refreshTokenPayload.UserID {
+	if session.UserID != refreshTokenPayload.UserID || session.RefreshToken != req.RefreshToken {
 		err := fmt.Errorf("mismatch session token")
 		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, err.Error()))
 		return
@@ -260,20 +589,121 @@ func (h *AuthHandler) RenewAccessToken(c *gin.Context) {
 		return
 	}
 
-	accessToken, accessTokenPayload, err := h.tokenMaker.CreateToken(refreshTokenPayload.UserID, 15*time.Minute)
+	// The role is read again so that role changes apply from the next renewal.
+	user, err := h.userUseCase.GetUserById(refreshTokenPayload.UserID)
 	if err != nil {
 		c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, err.Error()))
 		return
 	}
 
-	response := RenewAccessTokenResponse{
-		AccessToken:          accessToken,
-		AccessTokenExpiredAt: accessTokenPayload.ExpiredAt,
+	response, err := h.issueUserSession(c, user, refreshTokenPayload.ID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRefreshTokenReused) {
+			c.JSON(makeHttpErrorResponse(http.StatusUnauthorized, err.Error()))
+			return
+		}
+
+		c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, err.Error()))
+		return
 	}
 
 	c.JSON(makeHttpResponse(http.StatusOK, response))
 }
 
+// verifyRefreshToken verifies a token given to renew or end a session, which must be a refresh
+// token and not an access token.
+func (h *AuthHandler) verifyRefreshToken(refreshToken string) (*token.Payload, error) {
+	payload, err := h.tokenMaker.VerifyToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := payload.CheckType(token.TypeRefresh); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+type LogoutPayload struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// Logout godoc
+// @summary Logout
+// @description Block the session of the refresh token, and every session rotated from the same sign in, so they can no longer renew access tokens. A refresh token that was already rotated blocks them too but is answered with 401.
+// @tags auth
+// @id Logout
+// @accept json
+// @produce json
+// @param Session body handler.LogoutPayload true "Refresh token of the session to be blocked"
+// @response 200 {object} handler.MessageResponse "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req LogoutPayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	// An expired refresh token is rejected too; its session cannot renew anymore anyway.
+	refreshTokenPayload, err := h.verifyRefreshToken(req.RefreshToken)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusUnauthorized, err.Error()))
+		return
+	}
+
+	err = h.sessionUseCase.Logout(refreshTokenPayload.UserID, refreshTokenPayload.ID.String())
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(sessionErrorStatus(err), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpMessageResponse(http.StatusOK, "logged out successfully"))
+}
+
+// LogoutAll godoc
+// @summary Logout All
+// @description Block every session of the current user, signing out all devices
+// @tags auth
+// @id LogoutAll
+// @produce json
+// @security ApiKeyAuth
+// @response 200 {object} handler.MessageResponse "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	revoked, err := h.sessionUseCase.RevokeAllSessions(userID)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(sessionErrorStatus(err), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpMessageResponse(http.StatusOK, fmt.Sprintf("logged out of %d sessions successfully", revoked)))
+}
+
+func sessionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, apperrors.ErrInvalidUserID),
+		errors.Is(err, apperrors.ErrInvalidSessionID):
+		return http.StatusBadRequest
+	case errors.Is(err, apperrors.ErrSessionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, apperrors.ErrRefreshTokenReused):
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 type SendVerifyEmailPayload struct {
 	Email string `json:"email" binding:"required"`
 }
@@ -298,7 +728,7 @@ func (h *AuthHandler) SendVerifyEmail(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userUseCase.GetUserByEmail(req.Email)
+	user, err := h.userUseCase.GetUserByEmail(c, req.Email)
 	if err != nil {
 		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, err.Error()))
 		return
@@ -357,15 +787,13 @@ func (h *AuthHandler) VerifyEmail(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userUseCase.GetUserByEmail(verifyEmail.Email)
+	user, err := h.userUseCase.GetUserByEmail(c, verifyEmail.Email)
 	if err != nil {
 		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, err.Error()))
 		return
 	}
 
-	updatedUser, err := h.userUseCase.UpdateUserByID(user.ID, &entity.UserUpdatePayload{
-		IsEmailVerified: true,
-	})
+	updatedUser, err := h.userUseCase.MarkEmailVerified(user.ID)
 	if err != nil {
 		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, err.Error()))
 		return
@@ -375,3 +803,115 @@ func (h *AuthHandler) VerifyEmail(c *gin.Context) {
 	c.Redirect(http.StatusFound, "http://localhost:5173/profile")
 	c.JSON(makeHttpResponse(http.StatusOK, updatedUser))
 }
+
+// ForgotPassword godoc
+// @summary Forgot Password
+// @description Email a one-time link to reset the password. The response is the same whether or not an account uses the email
+// @tags auth
+// @id ForgotPassword
+// @accept json
+// @produce json
+// @param User body entity.ForgotPasswordPayload true "Email of the account"
+// @response 200 {object} handler.MessageResponse "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @router /auth/forgot-password [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req entity.ForgotPasswordPayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	// The account is looked up by the worker, so neither the response nor its timing tells
+	// whether the email is registered.
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueCritical),
+	}
+
+	h.taskDistributor.DistributeTaskSendPasswordResetEmail(c, &worker.PayloadSendPasswordResetEmail{
+		Email: req.Email,
+	}, opts...)
+
+	c.JSON(makeHttpMessageResponse(http.StatusOK, "if an account uses this email, a password reset link has been sent"))
+}
+
+// ResetPassword godoc
+// @summary Reset Password
+// @description Set a new password with the link from the password reset email. Every session of the user is signed out
+// @tags auth
+// @id ResetPassword
+// @accept json
+// @produce json
+// @param User body entity.PasswordResetPayload true "Password reset link and the new password"
+// @response 200 {object} handler.MessageResponse "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /auth/reset-password [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req entity.PasswordResetPayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	if err := h.passwordResetUseCase.ResetPassword(&req); err != nil {
+		c.JSON(makeHttpErrorResponse(passwordResetErrorStatus(err), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpMessageResponse(http.StatusOK, "password reset successfully"))
+}
+
+func passwordResetErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, apperrors.ErrPasswordAndConfirmationNotMatch),
+		errors.Is(err, apperrors.ErrInvalidPasswordReset),
+		errors.Is(err, apperrors.ErrPasswordResetExpired),
+		errors.Is(err, apperrors.ErrHashPassword):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ConfirmEmailChange godoc
+// @summary Confirm Email Change
+// @description Move the account to the new email with the link from the email change confirmation
+// @tags auth
+// @id ConfirmEmailChange
+// @accept json
+// @produce json
+// @param Link body entity.EmailChangeConfirmPayload true "Email change link"
+// @response 200 {object} handler.ResultResponse[entity.UserDto] "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /auth/confirm-email-change [post]
+func (h *AuthHandler) ConfirmEmailChange(c *gin.Context) {
+	var req entity.EmailChangeConfirmPayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	user, err := h.emailChangeUseCase.ConfirmEmailChange(&req)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(emailChangeErrorStatus(err), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusOK, user))
+}
+
+// GetJWKS serves the public keys access tokens are signed with as a JSON Web Key Set, so other
+// services can verify the tokens without sharing a secret. The set is empty when tokens are signed
+// with the shared secret.
+func (h *AuthHandler) GetJWKS(c *gin.Context) {
+	jwks := &token.JWKS{Keys: []token.JWK{}}
+	if h.keySet != nil {
+		jwks = h.keySet.JWKS()
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, jwks)
+}
@@ -2,30 +2,53 @@ package handler
 
 import (
 	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"fund-o/api-server/internal/entity"
+	"fund-o/api-server/internal/http/middleware"
 	"fund-o/api-server/internal/usecase"
 	"fund-o/api-server/mocks"
 	"fund-o/api-server/pkg/apperrors"
+	"fund-o/api-server/pkg/chain"
+	"fund-o/api-server/pkg/oauth"
+	"fund-o/api-server/pkg/password"
 	"fund-o/api-server/pkg/random"
 	"fund-o/api-server/pkg/token"
+	"fund-o/api-server/pkg/totp"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/sha3"
 	"gorm.io/gorm"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 )
 
 type AuthHandlerSuite struct {
 	suite.Suite
-	userRepository    *mocks.MockUserRepository
-	sessionRepository *mocks.MockSessionRepository
-	taskDistributor   *mocks.MockTaskDistributor
-	handler           *AuthHandler
+	userRepository          *mocks.MockUserRepository
+	sessionRepository       *mocks.MockSessionRepository
+	nonceRepository         *mocks.MockNonceRepository
+	passwordResetRepository *mocks.MockPasswordResetRepository
+	emailChangeRepository   *mocks.MockEmailChangeRepository
+	googleServer            *fakeGoogleServer
+	mfaRepository           *mocks.MockMfaRepository
+	loginAttemptRepository  *mocks.MockLoginAttemptRepository
+	taskDistributor         *mocks.MockTaskDistributor
+	tokenMaker              token.Maker
+	handler                 *AuthHandler
 }
 
 func (s *AuthHandlerSuite) SetupTest() {
@@ -42,19 +65,64 @@ func (s *AuthHandlerSuite) SetupTest() {
 	sessionUseCase := usecase.NewSessionUseCase(&usecase.SessionUseCaseOptions{
 		SessionRepository: s.sessionRepository,
 	})
+	s.nonceRepository = mocks.NewMockNonceRepository(ctrl)
+	walletAuthUseCase := usecase.NewWalletAuthUseCase(&usecase.WalletAuthUseCaseOptions{
+		UserRepository:  s.userRepository,
+		NonceRepository: s.nonceRepository,
+		Domain:          "fund-o.app",
+	})
+	s.passwordResetRepository = mocks.NewMockPasswordResetRepository(ctrl)
+	passwordResetUseCase := usecase.NewPasswordResetUseCase(&usecase.PasswordResetUseCaseOptions{
+		PasswordResetRepository: s.passwordResetRepository,
+		UserRepository:          s.userRepository,
+		SessionRepository:       s.sessionRepository,
+	})
+	s.emailChangeRepository = mocks.NewMockEmailChangeRepository(ctrl)
+	emailChangeUseCase := usecase.NewEmailChangeUseCase(&usecase.EmailChangeUseCaseOptions{
+		EmailChangeRepository: s.emailChangeRepository,
+		UserRepository:        s.userRepository,
+	})
+
+	s.googleServer = newFakeGoogleServer(s.T())
+	googleAuthUseCase := usecase.NewGoogleAuthUseCase(&usecase.GoogleAuthUseCaseOptions{
+		UserRepository:  s.userRepository,
+		NonceRepository: s.nonceRepository,
+		Provider:        s.googleServer.provider(),
+	})
+
+	s.mfaRepository = mocks.NewMockMfaRepository(ctrl)
+	mfaUseCase := usecase.NewMfaUseCase(&usecase.MfaUseCaseOptions{
+		MfaRepository:   s.mfaRepository,
+		UserRepository:  s.userRepository,
+		NonceRepository: s.nonceRepository,
+	})
+
+	s.loginAttemptRepository = mocks.NewMockLoginAttemptRepository(ctrl)
+	loginAttemptUseCase := usecase.NewLoginAttemptUseCase(&usecase.LoginAttemptUseCaseOptions{
+		LoginAttemptRepository: s.loginAttemptRepository,
+		MaxAttempts:            5,
+		LockoutDuration:        15 * time.Minute,
+	})
 
 	s.taskDistributor = mocks.NewMockTaskDistributor(ctrl)
 
 	secretKey := "alsypVB6YUpE2HBW4npGoXeArNyqVrqO"
 
-	tokenMaker, err := token.NewJWTMaker(secretKey)
+	var err error
+	s.tokenMaker, err = token.NewJWTMaker(secretKey)
 	require.NoError(s.T(), err)
 
 	s.handler = NewAuthHandler(&AuthHandlerOptions{
-		UserUseCase:     userUseCase,
-		SessionUseCase:  sessionUseCase,
-		TaskDistributor: s.taskDistributor,
-		TokenMaker:      tokenMaker,
+		UserUseCase:          userUseCase,
+		SessionUseCase:       sessionUseCase,
+		WalletAuthUseCase:    walletAuthUseCase,
+		PasswordResetUseCase: passwordResetUseCase,
+		EmailChangeUseCase:   emailChangeUseCase,
+		GoogleAuthUseCase:    googleAuthUseCase,
+		MfaUseCase:           mfaUseCase,
+		LoginAttemptUseCase:  loginAttemptUseCase,
+		TaskDistributor:      s.taskDistributor,
+		TokenMaker:           s.tokenMaker,
 	})
 }
 
@@ -234,6 +302,7 @@ func (s *AuthHandlerSuite) TestAuthRegisterAPI() {
 
 func (s *AuthHandlerSuite) TestAuthLoginAPI() {
 	user := randomUser(s.T())
+	emailKey := "email:" + strings.ToLower(user.Email)
 
 	testCases := []struct {
 		name          string
@@ -248,11 +317,26 @@ func (s *AuthHandlerSuite) TestAuthLoginAPI() {
 				Password: "@Password123",
 			},
 			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository) {
+				s.loginAttemptRepository.EXPECT().
+					LockTTL(gomock.Any()).
+					Times(2).
+					Return(time.Duration(0), nil)
+
 				userRepo.EXPECT().
 					FindByEmail(user.Email).
 					Times(1).
 					Return(&user, nil)
 
+				s.loginAttemptRepository.EXPECT().
+					ResetFailures(gomock.Eq(emailKey)).
+					Times(1).
+					Return(nil)
+
+				s.mfaRepository.EXPECT().
+					FindTOTPByUserID(gomock.Eq(user.ID)).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+
 				sessionRepo.EXPECT().
 					Create(gomock.Any()).
 					Times(1).
@@ -274,6 +358,52 @@ func (s *AuthHandlerSuite) TestAuthLoginAPI() {
 				require.Equal(t, user.Email, response.Result.User.Email)
 			},
 		},
+		{
+			name: "MfaRequired",
+			requestBody: entity.UserLoginPayload{
+				Email:    user.Email,
+				Password: "@Password123",
+			},
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository) {
+				confirmedAt := time.Now()
+				s.loginAttemptRepository.EXPECT().
+					LockTTL(gomock.Any()).
+					Times(2).
+					Return(time.Duration(0), nil)
+
+				userRepo.EXPECT().
+					FindByEmail(user.Email).
+					Times(1).
+					Return(&user, nil)
+
+				s.loginAttemptRepository.EXPECT().
+					ResetFailures(gomock.Any()).
+					Times(0)
+
+				s.mfaRepository.EXPECT().
+					FindTOTPByUserID(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&entity.UserTOTP{UserID: user.ID, ConfirmedAt: &confirmedAt}, nil)
+
+				s.nonceRepository.EXPECT().
+					Create(gomock.Any(), gomock.Eq("mfa:"+user.ID.String()), gomock.Any()).
+					Times(1).
+					Return(nil)
+
+				sessionRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[entity.MfaChallengeResponse]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusAccepted, response.StatusCode)
+				require.True(t, response.Result.MfaRequired)
+				require.NotEmpty(t, response.Result.MfaToken)
+			},
+		},
 		{
 			name:        "InvalidRequestBody",
 			requestBody: entity.UserLoginPayload{},
@@ -294,18 +424,154 @@ func (s *AuthHandlerSuite) TestAuthLoginAPI() {
 				Password: "@Password123",
 			},
 			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository) {
+				s.loginAttemptRepository.EXPECT().
+					LockTTL(gomock.Any()).
+					Times(2).
+					Return(time.Duration(0), nil)
+
 				userRepo.EXPECT().
 					FindByEmail(user.Email).
 					Times(1).
 					Return(nil, gorm.ErrRecordNotFound)
+
+				s.loginAttemptRepository.EXPECT().
+					AddFailure(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(int64(1), nil)
+
+				s.loginAttemptRepository.EXPECT().
+					Lock(gomock.Eq(emailKey), gomock.Eq(time.Second)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ErrorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusText(http.StatusUnauthorized), response.Status)
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+				require.Contains(t, response.Error, apperrors.ErrInvalidCredentials.Error())
+			},
+		},
+		{
+			name: "WrongPassword",
+			requestBody: entity.UserLoginPayload{
+				Email:    user.Email,
+				Password: "@WrongPassword123",
+			},
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository) {
+				s.loginAttemptRepository.EXPECT().
+					LockTTL(gomock.Any()).
+					Times(2).
+					Return(time.Duration(0), nil)
+
+				userRepo.EXPECT().
+					FindByEmail(user.Email).
+					Times(1).
+					Return(&user, nil)
+
+				s.loginAttemptRepository.EXPECT().
+					AddFailure(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(int64(3), nil)
+
+				s.loginAttemptRepository.EXPECT().
+					Lock(gomock.Eq(emailKey), gomock.Eq(4*time.Second)).
+					Times(1).
+					Return(nil)
+
+				sessionRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ErrorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+				require.Contains(t, response.Error, apperrors.ErrInvalidCredentials.Error())
+			},
+		},
+		{
+			name: "LockedOut",
+			requestBody: entity.UserLoginPayload{
+				Email:    user.Email,
+				Password: "@WrongPassword123",
+			},
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository) {
+				s.loginAttemptRepository.EXPECT().
+					LockTTL(gomock.Any()).
+					Times(2).
+					Return(time.Duration(0), nil)
+
+				userRepo.EXPECT().
+					FindByEmail(user.Email).
+					Times(1).
+					Return(&user, nil)
+
+				s.loginAttemptRepository.EXPECT().
+					AddFailure(gomock.Not(gomock.Eq(emailKey)), gomock.Any()).
+					Times(1).
+					Return(int64(5), nil)
+
+				s.loginAttemptRepository.EXPECT().
+					AddFailure(gomock.Eq(emailKey), gomock.Any()).
+					Times(1).
+					Return(int64(5), nil)
+
+				s.loginAttemptRepository.EXPECT().
+					Lock(gomock.Eq(emailKey), gomock.Eq(15*time.Minute)).
+					Times(1).
+					Return(nil)
+
+				s.loginAttemptRepository.EXPECT().
+					ResetFailures(gomock.Eq(emailKey)).
+					Times(1).
+					Return(nil)
+
+				s.taskDistributor.EXPECT().
+					DistributeTaskSendAccountLockedEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ErrorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+				require.Contains(t, response.Error, apperrors.ErrInvalidCredentials.Error())
+			},
+		},
+		{
+			name: "TooManyAttempts",
+			requestBody: entity.UserLoginPayload{
+				Email:    user.Email,
+				Password: "@Password123",
+			},
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository) {
+				s.loginAttemptRepository.EXPECT().
+					LockTTL(gomock.Eq(emailKey)).
+					Times(1).
+					Return(90*time.Second, nil)
+
+				s.loginAttemptRepository.EXPECT().
+					LockTTL(gomock.Not(gomock.Eq(emailKey))).
+					Times(1).
+					Return(time.Duration(0), nil)
+
+				userRepo.EXPECT().
+					FindByEmail(gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				var response ErrorResponse
 				err := json.Unmarshal(recorder.Body.Bytes(), &response)
 				require.NoError(t, err)
 
-				require.Equal(t, http.StatusText(http.StatusNotFound), response.Status)
-				require.Equal(t, http.StatusNotFound, response.StatusCode)
+				require.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+				require.Equal(t, "90", recorder.Header().Get("Retry-After"))
 			},
 		},
 	}
@@ -333,70 +599,276 @@ func (s *AuthHandlerSuite) TestAuthLoginAPI() {
 	}
 }
 
-func (s *AuthHandlerSuite) TestSendVerifyEmailAPI() {
-	email := random.NewEmail()
+func (s *AuthHandlerSuite) TestVerifyMfaAPI() {
+	user := randomUser(s.T())
+	secret, err := totp.GenerateSecret()
+	require.NoError(s.T(), err)
+
+	confirmedAt := time.Now()
+	userTOTP := entity.UserTOTP{UserID: user.ID, Secret: secret, ConfirmedAt: &confirmedAt}
+	currentCode, err := totp.Code(secret, totp.Step(time.Now()))
+	require.NoError(s.T(), err)
+
+	recoveryCode := "a1b2c-d3e4f"
+	hashedRecoveryCode, err := password.HashCode(recoveryCode)
+	require.NoError(s.T(), err)
+	userRecoveryCode := entity.UserRecoveryCode{Base: entity.Base{ID: uuid.New()}, UserID: user.ID, HashedCode: hashedRecoveryCode}
+	mfaKey := "mfa:" + user.ID.String()
+	emailKey := "email:" + strings.ToLower(user.Email)
 
 	testCases := []struct {
 		name          string
-		requestBody   gin.H
+		code          string
 		buildStubs    func()
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
 			name: "OK",
-			requestBody: gin.H{
-				"email": email,
-			},
+			code: currentCode,
 			buildStubs: func() {
+				s.nonceRepository.EXPECT().
+					Consume(gomock.Eq("mfa-token")).
+					Times(1).
+					Return("mfa:"+user.ID.String(), nil)
+				s.loginAttemptRepository.EXPECT().
+					LockTTL(gomock.Any()).
+					Times(2).
+					Return(time.Duration(0), nil)
+				s.mfaRepository.EXPECT().
+					FindTOTPByUserID(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&userTOTP, nil)
+				s.mfaRepository.EXPECT().
+					UseTOTPStep(gomock.Eq(user.ID), gomock.Any()).
+					Times(1).
+					Return(true, nil)
 				s.userRepository.EXPECT().
-					FindByEmail(email).
+					FindById(gomock.Eq(user.ID)).
 					Times(1).
-					Return(&entity.User{}, nil)
-
-				s.taskDistributor.EXPECT().
-					DistributeTaskSendVerifyEmail(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Times(1)
+					Return(&user, nil)
+				s.loginAttemptRepository.EXPECT().
+					ResetFailures(gomock.Eq(mfaKey)).
+					Times(1).
+					Return(nil)
+				s.loginAttemptRepository.EXPECT().
+					ResetFailures(gomock.Eq(emailKey)).
+					Times(1).
+					Return(nil)
+				s.sessionRepository.EXPECT().
+					Create(gomock.Any()).
+					Times(1).
+					Return(&entity.Session{}, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				var response MessageResponse
+				var response ResultResponse[entity.UserAuthenticateResponse]
 				err := json.Unmarshal(recorder.Body.Bytes(), &response)
 				require.NoError(t, err)
 
-				require.Equal(t, http.StatusText(http.StatusOK), response.Status)
 				require.Equal(t, http.StatusOK, response.StatusCode)
+				require.NotEmpty(t, response.Result.AccessToken)
+				require.Equal(t, user.ID.String(), response.Result.User.ID)
 			},
 		},
 		{
-			name:        "InvalidRequestBody",
-			requestBody: gin.H{},
-			buildStubs:  func() {},
+			name: "RecoveryCode",
+			code: recoveryCode,
+			buildStubs: func() {
+				s.nonceRepository.EXPECT().
+					Consume(gomock.Eq("mfa-token")).
+					Times(1).
+					Return("mfa:"+user.ID.String(), nil)
+				s.loginAttemptRepository.EXPECT().
+					LockTTL(gomock.Any()).
+					Times(2).
+					Return(time.Duration(0), nil)
+				s.mfaRepository.EXPECT().
+					FindTOTPByUserID(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&userTOTP, nil)
+				s.mfaRepository.EXPECT().
+					FindUnusedRecoveryCodes(gomock.Eq(user.ID)).
+					Times(1).
+					Return([]entity.UserRecoveryCode{userRecoveryCode}, nil)
+				s.mfaRepository.EXPECT().
+					UseRecoveryCode(gomock.Eq(userRecoveryCode.ID)).
+					Times(1).
+					Return(true, nil)
+				s.userRepository.EXPECT().
+					FindById(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&user, nil)
+				s.loginAttemptRepository.EXPECT().
+					ResetFailures(gomock.Eq(mfaKey)).
+					Times(1).
+					Return(nil)
+				s.loginAttemptRepository.EXPECT().
+					ResetFailures(gomock.Eq(emailKey)).
+					Times(1).
+					Return(nil)
+				s.sessionRepository.EXPECT().
+					Create(gomock.Any()).
+					Times(1).
+					Return(&entity.Session{}, nil)
+			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				var response ErrorResponse
-				err := json.Unmarshal(recorder.Body.Bytes(), &response)
-				require.NoError(t, err)
-
-				require.Equal(t, http.StatusText(http.StatusBadRequest), response.Status)
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
 		{
-			name: "UserNotFound",
-			requestBody: gin.H{
-				"email": email,
+			name: "ReplayedCode",
+			code: currentCode,
+			buildStubs: func() {
+				s.nonceRepository.EXPECT().
+					Consume(gomock.Eq("mfa-token")).
+					Times(1).
+					Return("mfa:"+user.ID.String(), nil)
+				s.loginAttemptRepository.EXPECT().
+					LockTTL(gomock.Any()).
+					Times(2).
+					Return(time.Duration(0), nil)
+				s.mfaRepository.EXPECT().
+					FindTOTPByUserID(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&userTOTP, nil)
+				s.mfaRepository.EXPECT().
+					UseTOTPStep(gomock.Eq(user.ID), gomock.Any()).
+					Times(1).
+					Return(false, nil)
+				s.loginAttemptRepository.EXPECT().
+					AddFailure(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(int64(1), nil)
+				s.loginAttemptRepository.EXPECT().
+					Lock(gomock.Eq(mfaKey), gomock.Eq(time.Second)).
+					Times(1).
+					Return(nil)
+				s.sessionRepository.EXPECT().
+					Create(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
+		},
+		{
+			name: "WrongCode",
+			code: "000000",
 			buildStubs: func() {
-				s.userRepository.EXPECT().
-					FindByEmail(email).
+				s.nonceRepository.EXPECT().
+					Consume(gomock.Eq("mfa-token")).
 					Times(1).
-					Return(nil, gorm.ErrRecordNotFound)
+					Return("mfa:"+user.ID.String(), nil)
+				s.loginAttemptRepository.EXPECT().
+					LockTTL(gomock.Any()).
+					Times(2).
+					Return(time.Duration(0), nil)
+				s.mfaRepository.EXPECT().
+					FindTOTPByUserID(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&userTOTP, nil)
+				s.mfaRepository.EXPECT().
+					FindUnusedRecoveryCodes(gomock.Eq(user.ID)).
+					Times(1).
+					Return(nil, nil)
+				s.loginAttemptRepository.EXPECT().
+					AddFailure(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(int64(1), nil)
+				s.loginAttemptRepository.EXPECT().
+					Lock(gomock.Eq(mfaKey), gomock.Eq(time.Second)).
+					Times(1).
+					Return(nil)
+				s.sessionRepository.EXPECT().
+					Create(gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				var response ErrorResponse
-				err := json.Unmarshal(recorder.Body.Bytes(), &response)
-				require.NoError(t, err)
-
-				require.Equal(t, http.StatusText(http.StatusBadRequest), response.Status)
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "LockedOut",
+			code: "000000",
+			buildStubs: func() {
+				s.nonceRepository.EXPECT().
+					Consume(gomock.Eq("mfa-token")).
+					Times(1).
+					Return("mfa:"+user.ID.String(), nil)
+				s.loginAttemptRepository.EXPECT().
+					LockTTL(gomock.Any()).
+					Times(2).
+					Return(time.Duration(0), nil)
+				s.mfaRepository.EXPECT().
+					FindTOTPByUserID(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&userTOTP, nil)
+				s.mfaRepository.EXPECT().
+					FindUnusedRecoveryCodes(gomock.Eq(user.ID)).
+					Times(1).
+					Return(nil, nil)
+				s.loginAttemptRepository.EXPECT().
+					AddFailure(gomock.Not(gomock.Eq(mfaKey)), gomock.Any()).
+					Times(1).
+					Return(int64(5), nil)
+				s.loginAttemptRepository.EXPECT().
+					AddFailure(gomock.Eq(mfaKey), gomock.Any()).
+					Times(1).
+					Return(int64(5), nil)
+				s.loginAttemptRepository.EXPECT().
+					Lock(gomock.Eq(mfaKey), gomock.Eq(15*time.Minute)).
+					Times(1).
+					Return(nil)
+				s.loginAttemptRepository.EXPECT().
+					ResetFailures(gomock.Eq(mfaKey)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "TooManyAttempts",
+			code: currentCode,
+			buildStubs: func() {
+				s.nonceRepository.EXPECT().
+					Consume(gomock.Eq("mfa-token")).
+					Times(1).
+					Return("mfa:"+user.ID.String(), nil)
+				s.loginAttemptRepository.EXPECT().
+					LockTTL(gomock.Eq(mfaKey)).
+					Times(1).
+					Return(10*time.Minute, nil)
+				s.loginAttemptRepository.EXPECT().
+					LockTTL(gomock.Not(gomock.Eq(mfaKey))).
+					Times(1).
+					Return(time.Duration(0), nil)
+				s.mfaRepository.EXPECT().
+					FindTOTPByUserID(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+				require.Equal(t, "600", recorder.Header().Get("Retry-After"))
+			},
+		},
+		{
+			name: "ExpiredChallenge",
+			code: currentCode,
+			buildStubs: func() {
+				s.nonceRepository.EXPECT().
+					Consume(gomock.Eq("mfa-token")).
+					Times(1).
+					Return("", nil)
+				s.loginAttemptRepository.EXPECT().
+					LockTTL(gomock.Any()).
+					Times(0)
+				s.mfaRepository.EXPECT().
+					FindTOTPByUserID(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
 	}
@@ -408,12 +880,12 @@ func (s *AuthHandlerSuite) TestSendVerifyEmailAPI() {
 
 			tc.buildStubs()
 
-			r.POST("/send-verify-email", s.handler.SendVerifyEmail)
+			r.POST("/mfa/verify", s.handler.VerifyMfa)
 
-			requestBody, err := json.Marshal(tc.requestBody)
+			requestBody, err := json.Marshal(entity.MfaVerifyPayload{MfaToken: "mfa-token", Code: tc.code})
 			require.NoError(t, err)
 
-			request, err := http.NewRequest(http.MethodPost, "/send-verify-email", bytes.NewReader(requestBody))
+			request, err := http.NewRequest(http.MethodPost, "/mfa/verify", bytes.NewReader(requestBody))
 			require.NoError(t, err)
 
 			c.Request = request
@@ -424,6 +896,1563 @@ func (s *AuthHandlerSuite) TestSendVerifyEmailAPI() {
 	}
 }
 
+func (s *AuthHandlerSuite) TestRenewAccessTokenAPI() {
+	user := randomUser(s.T())
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, session *entity.Session)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder, session *entity.Session)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, session *entity.Session) {
+				sessionRepo.EXPECT().
+					FindByID(gomock.Eq(session.ID)).
+					Times(2).
+					Return(session, nil)
+				userRepo.EXPECT().
+					FindById(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&user, nil)
+				sessionRepo.EXPECT().
+					Rotate(gomock.Eq(session.ID), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ uuid.UUID, next *entity.Session) (bool, error) {
+						require.Equal(s.T(), session.FamilyID, next.FamilyID)
+						require.Equal(s.T(), session.ID, *next.ParentID)
+						return true, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, session *entity.Session) {
+				var response ResultResponse[entity.UserAuthenticateResponse]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusOK, response.StatusCode)
+				require.NotEmpty(t, response.Result.AccessToken)
+				require.NotEqual(t, session.RefreshToken, response.Result.RefreshToken)
+				require.NotEqual(t, session.ID.String(), response.Result.SessionID)
+			},
+		},
+		{
+			name: "ReusedToken",
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, session *entity.Session) {
+				rotatedAt := time.Now().Add(-time.Minute)
+				session.RotatedAt = &rotatedAt
+
+				sessionRepo.EXPECT().
+					FindByID(gomock.Eq(session.ID)).
+					Times(2).
+					Return(session, nil)
+				userRepo.EXPECT().
+					FindById(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&user, nil)
+				sessionRepo.EXPECT().
+					Rotate(gomock.Any(), gomock.Any()).
+					Times(0)
+				sessionRepo.EXPECT().
+					BlockFamily(gomock.Eq(session.FamilyID)).
+					Times(1).
+					Return(int64(3), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, session *entity.Session) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "ConcurrentRenewal",
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, session *entity.Session) {
+				sessionRepo.EXPECT().
+					FindByID(gomock.Eq(session.ID)).
+					Times(2).
+					Return(session, nil)
+				userRepo.EXPECT().
+					FindById(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&user, nil)
+				sessionRepo.EXPECT().
+					Rotate(gomock.Eq(session.ID), gomock.Any()).
+					Times(1).
+					Return(false, nil)
+				sessionRepo.EXPECT().
+					BlockFamily(gomock.Eq(session.FamilyID)).
+					Times(1).
+					Return(int64(2), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, session *entity.Session) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "BlockedSession",
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, session *entity.Session) {
+				session.IsBlocked = true
+
+				sessionRepo.EXPECT().
+					FindByID(gomock.Eq(session.ID)).
+					Times(1).
+					Return(session, nil)
+				sessionRepo.EXPECT().
+					Rotate(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, session *entity.Session) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			refreshToken, payload, err := s.tokenMaker.CreateToken(user.ID.String(), entity.RoleUser.String(), token.TypeRefresh, time.Hour)
+			require.NoError(t, err)
+
+			session := &entity.Session{
+				ID:           payload.ID,
+				UserID:       user.ID,
+				FamilyID:     uuid.New(),
+				RefreshToken: refreshToken,
+				ExpiredAt:    payload.ExpiredAt,
+			}
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			tc.buildStubs(s.userRepository, s.sessionRepository, session)
+
+			r.POST("/renew-token", s.handler.RenewAccessToken)
+
+			requestBody, err := json.Marshal(RenewAccessTokenPayload{RefreshToken: refreshToken})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/renew-token", bytes.NewBuffer(requestBody))
+			require.NoError(t, err)
+
+			c.Request = request
+
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder, session)
+		})
+	}
+}
+
+func (s *AuthHandlerSuite) TestLogoutAPI() {
+	user := randomUser(s.T())
+
+	testCases := []struct {
+		name          string
+		buildToken    func(t *testing.T) (string, *token.Payload)
+		buildStubs    func(sessionRepo *mocks.MockSessionRepository, payload *token.Payload)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildToken: func(t *testing.T) (string, *token.Payload) {
+				refreshToken, payload, err := s.tokenMaker.CreateToken(user.ID.String(), entity.RoleUser.String(), token.TypeRefresh, time.Hour)
+				require.NoError(t, err)
+				return refreshToken, payload
+			},
+			buildStubs: func(sessionRepo *mocks.MockSessionRepository, payload *token.Payload) {
+				familyID := uuid.New()
+				sessionRepo.EXPECT().
+					FindByID(gomock.Eq(payload.ID)).
+					Times(1).
+					Return(&entity.Session{ID: payload.ID, UserID: user.ID, FamilyID: familyID, ParentID: &familyID}, nil)
+				sessionRepo.EXPECT().
+					BlockFamily(gomock.Eq(familyID)).
+					Times(1).
+					Return(int64(2), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "RotatedToken",
+			buildToken: func(t *testing.T) (string, *token.Payload) {
+				refreshToken, payload, err := s.tokenMaker.CreateToken(user.ID.String(), entity.RoleUser.String(), token.TypeRefresh, time.Hour)
+				require.NoError(t, err)
+				return refreshToken, payload
+			},
+			buildStubs: func(sessionRepo *mocks.MockSessionRepository, payload *token.Payload) {
+				rotatedAt := time.Now().Add(-time.Minute)
+				sessionRepo.EXPECT().
+					FindByID(gomock.Eq(payload.ID)).
+					Times(1).
+					Return(&entity.Session{ID: payload.ID, UserID: user.ID, FamilyID: payload.ID, RotatedAt: &rotatedAt}, nil)
+				sessionRepo.EXPECT().
+					BlockFamily(gomock.Eq(payload.ID)).
+					Times(1).
+					Return(int64(2), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "SessionNotFound",
+			buildToken: func(t *testing.T) (string, *token.Payload) {
+				refreshToken, payload, err := s.tokenMaker.CreateToken(user.ID.String(), entity.RoleUser.String(), token.TypeRefresh, time.Hour)
+				require.NoError(t, err)
+				return refreshToken, payload
+			},
+			buildStubs: func(sessionRepo *mocks.MockSessionRepository, payload *token.Payload) {
+				sessionRepo.EXPECT().
+					FindByID(gomock.Eq(payload.ID)).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				sessionRepo.EXPECT().
+					BlockFamily(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "SessionOfAnotherUser",
+			buildToken: func(t *testing.T) (string, *token.Payload) {
+				refreshToken, payload, err := s.tokenMaker.CreateToken(user.ID.String(), entity.RoleUser.String(), token.TypeRefresh, time.Hour)
+				require.NoError(t, err)
+				return refreshToken, payload
+			},
+			buildStubs: func(sessionRepo *mocks.MockSessionRepository, payload *token.Payload) {
+				sessionRepo.EXPECT().
+					FindByID(gomock.Eq(payload.ID)).
+					Times(1).
+					Return(&entity.Session{ID: payload.ID, UserID: uuid.New(), FamilyID: payload.ID}, nil)
+				sessionRepo.EXPECT().
+					BlockFamily(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "AccessToken",
+			buildToken: func(t *testing.T) (string, *token.Payload) {
+				accessToken, payload, err := s.tokenMaker.CreateToken(user.ID.String(), entity.RoleUser.String(), token.TypeAccess, time.Hour)
+				require.NoError(t, err)
+				return accessToken, payload
+			},
+			buildStubs: func(sessionRepo *mocks.MockSessionRepository, payload *token.Payload) {
+				sessionRepo.EXPECT().
+					FindByID(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "ExpiredToken",
+			buildToken: func(t *testing.T) (string, *token.Payload) {
+				refreshToken, payload, err := s.tokenMaker.CreateToken(user.ID.String(), entity.RoleUser.String(), token.TypeRefresh, -time.Minute)
+				require.NoError(t, err)
+				return refreshToken, payload
+			},
+			buildStubs: func(sessionRepo *mocks.MockSessionRepository, payload *token.Payload) {
+				sessionRepo.EXPECT().
+					FindByID(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			refreshToken, payload := tc.buildToken(t)
+			tc.buildStubs(s.sessionRepository, payload)
+
+			r.POST("/logout", s.handler.Logout)
+
+			requestBody, err := json.Marshal(LogoutPayload{RefreshToken: refreshToken})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/logout", bytes.NewBuffer(requestBody))
+			require.NoError(t, err)
+
+			c.Request = request
+
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *AuthHandlerSuite) TestLogoutAllAPI() {
+	user := randomUser(s.T())
+
+	testCases := []struct {
+		name          string
+		tokenType     token.Type
+		buildStubs    func(sessionRepo *mocks.MockSessionRepository)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			tokenType: token.TypeAccess,
+			buildStubs: func(sessionRepo *mocks.MockSessionRepository) {
+				sessionRepo.EXPECT().
+					BlockAllByUserID(gomock.Eq(user.ID)).
+					Times(1).
+					Return(int64(3), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "RefreshToken",
+			tokenType: token.TypeRefresh,
+			buildStubs: func(sessionRepo *mocks.MockSessionRepository) {
+				sessionRepo.EXPECT().
+					BlockAllByUserID(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			tc.buildStubs(s.sessionRepository)
+
+			r.POST("/logout-all", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.LogoutAll)
+
+			request, err := http.NewRequest(http.MethodPost, "/logout-all", nil)
+			require.NoError(t, err)
+
+			bearerToken, _, err := s.tokenMaker.CreateToken(user.ID.String(), entity.RoleUser.String(), tc.tokenType, time.Minute)
+			require.NoError(t, err)
+			request.Header.Set(middleware.AuthorizationHeaderKey, fmt.Sprintf("%s %s", middleware.AuthorizationTypeBearer, bearerToken))
+
+			c.Request = request
+
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *AuthHandlerSuite) TestSendVerifyEmailAPI() {
+	email := random.NewEmail()
+
+	testCases := []struct {
+		name          string
+		requestBody   gin.H
+		buildStubs    func()
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			requestBody: gin.H{
+				"email": email,
+			},
+			buildStubs: func() {
+				s.userRepository.EXPECT().
+					FindByEmail(email).
+					Times(1).
+					Return(&entity.User{}, nil)
+
+				s.taskDistributor.EXPECT().
+					DistributeTaskSendVerifyEmail(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response MessageResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusText(http.StatusOK), response.Status)
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name:        "InvalidRequestBody",
+			requestBody: gin.H{},
+			buildStubs:  func() {},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ErrorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusText(http.StatusBadRequest), response.Status)
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name: "UserNotFound",
+			requestBody: gin.H{
+				"email": email,
+			},
+			buildStubs: func() {
+				s.userRepository.EXPECT().
+					FindByEmail(email).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ErrorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusText(http.StatusBadRequest), response.Status)
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			tc.buildStubs()
+
+			r.POST("/send-verify-email", s.handler.SendVerifyEmail)
+
+			requestBody, err := json.Marshal(tc.requestBody)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/send-verify-email", bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			c.Request = request
+
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *AuthHandlerSuite) TestForgotPasswordAPI() {
+	testCases := []struct {
+		name          string
+		requestBody   gin.H
+		buildStubs    func()
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			requestBody: gin.H{
+				"email": random.NewEmail(),
+			},
+			buildStubs: func() {
+				// The email is never looked up here, so unknown emails get the same response.
+				s.userRepository.EXPECT().
+					FindByEmail(gomock.Any()).
+					Times(0)
+
+				s.taskDistributor.EXPECT().
+					DistributeTaskSendPasswordResetEmail(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "InvalidRequestBody",
+			requestBody: gin.H{},
+			buildStubs: func() {
+				s.taskDistributor.EXPECT().
+					DistributeTaskSendPasswordResetEmail(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			tc.buildStubs()
+
+			r.POST("/forgot-password", s.handler.ForgotPassword)
+
+			requestBody, err := json.Marshal(tc.requestBody)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/forgot-password", bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			c.Request = request
+
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *AuthHandlerSuite) TestResetPasswordAPI() {
+	user := randomUser(s.T())
+	secretCode := random.NewString(32)
+	hash := sha256.Sum256([]byte(secretCode))
+
+	newPasswordReset := func(isUsed bool, expiredAt time.Time) *entity.PasswordReset {
+		return &entity.PasswordReset{
+			Base:           entity.Base{ID: uuid.New()},
+			UserID:         user.ID,
+			Email:          user.Email,
+			SecretCodeHash: hex.EncodeToString(hash[:]),
+			IsUsed:         isUsed,
+			ExpiredAt:      expiredAt,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		passwordReset *entity.PasswordReset
+		secretCode    string
+		password      string
+		buildStubs    func(passwordReset *entity.PasswordReset)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:          "OK",
+			passwordReset: newPasswordReset(false, time.Now().Add(time.Minute)),
+			secretCode:    secretCode,
+			password:      "N3w-Password",
+			buildStubs: func(passwordReset *entity.PasswordReset) {
+				s.passwordResetRepository.EXPECT().
+					FindByID(gomock.Eq(passwordReset.ID)).
+					Times(1).
+					Return(passwordReset, nil)
+				s.passwordResetRepository.EXPECT().
+					MarkUsed(gomock.Eq(passwordReset.ID)).
+					Times(1).
+					Return(true, nil)
+				s.userRepository.EXPECT().
+					UpdateByID(gomock.Eq(user.ID), gomock.Any()).
+					Times(1).
+					Return(&user, nil)
+				s.sessionRepository.EXPECT().
+					BlockAllByUserID(gomock.Eq(user.ID)).
+					Times(1).
+					Return(int64(2), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:          "WrongSecretCode",
+			passwordReset: newPasswordReset(false, time.Now().Add(time.Minute)),
+			secretCode:    random.NewString(32),
+			password:      "N3w-Password",
+			buildStubs: func(passwordReset *entity.PasswordReset) {
+				s.passwordResetRepository.EXPECT().
+					FindByID(gomock.Eq(passwordReset.ID)).
+					Times(1).
+					Return(passwordReset, nil)
+				s.passwordResetRepository.EXPECT().
+					MarkUsed(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:          "AlreadyUsed",
+			passwordReset: newPasswordReset(true, time.Now().Add(time.Minute)),
+			secretCode:    secretCode,
+			password:      "N3w-Password",
+			buildStubs: func(passwordReset *entity.PasswordReset) {
+				s.passwordResetRepository.EXPECT().
+					FindByID(gomock.Eq(passwordReset.ID)).
+					Times(1).
+					Return(passwordReset, nil)
+				s.passwordResetRepository.EXPECT().
+					MarkUsed(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:          "UsedConcurrently",
+			passwordReset: newPasswordReset(false, time.Now().Add(time.Minute)),
+			secretCode:    secretCode,
+			password:      "N3w-Password",
+			buildStubs: func(passwordReset *entity.PasswordReset) {
+				s.passwordResetRepository.EXPECT().
+					FindByID(gomock.Eq(passwordReset.ID)).
+					Times(1).
+					Return(passwordReset, nil)
+				s.passwordResetRepository.EXPECT().
+					MarkUsed(gomock.Eq(passwordReset.ID)).
+					Times(1).
+					Return(false, nil)
+				s.userRepository.EXPECT().
+					UpdateByID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:          "Expired",
+			passwordReset: newPasswordReset(false, time.Now().Add(-time.Minute)),
+			secretCode:    secretCode,
+			password:      "N3w-Password",
+			buildStubs: func(passwordReset *entity.PasswordReset) {
+				s.passwordResetRepository.EXPECT().
+					FindByID(gomock.Eq(passwordReset.ID)).
+					Times(1).
+					Return(passwordReset, nil)
+				s.passwordResetRepository.EXPECT().
+					MarkUsed(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:          "WeakPassword",
+			passwordReset: newPasswordReset(false, time.Now().Add(time.Minute)),
+			secretCode:    secretCode,
+			password:      "password",
+			buildStubs: func(passwordReset *entity.PasswordReset) {
+				s.passwordResetRepository.EXPECT().
+					FindByID(gomock.Eq(passwordReset.ID)).
+					Times(1).
+					Return(passwordReset, nil)
+				s.passwordResetRepository.EXPECT().
+					MarkUsed(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			tc.buildStubs(tc.passwordReset)
+
+			r.POST("/reset-password", s.handler.ResetPassword)
+
+			requestBody, err := json.Marshal(entity.PasswordResetPayload{
+				ID:                   tc.passwordReset.ID.String(),
+				SecretCode:           tc.secretCode,
+				Password:             tc.password,
+				PasswordConfirmation: tc.password,
+			})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/reset-password", bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			c.Request = request
+
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *AuthHandlerSuite) TestConfirmEmailChangeAPI() {
+	user := randomUser(s.T())
+	newEmail := random.NewEmail()
+	secretCode := random.NewString(32)
+	hash := sha256.Sum256([]byte(secretCode))
+
+	newEmailChange := func(isUsed bool, expiredAt time.Time) *entity.EmailChange {
+		return &entity.EmailChange{
+			Base:           entity.Base{ID: uuid.New()},
+			UserID:         user.ID,
+			NewEmail:       newEmail,
+			SecretCodeHash: hex.EncodeToString(hash[:]),
+			IsUsed:         isUsed,
+			ExpiredAt:      expiredAt,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		emailChange   *entity.EmailChange
+		secretCode    string
+		buildStubs    func(emailChange *entity.EmailChange)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			emailChange: newEmailChange(false, time.Now().Add(time.Minute)),
+			secretCode:  secretCode,
+			buildStubs: func(emailChange *entity.EmailChange) {
+				s.emailChangeRepository.EXPECT().
+					FindByID(gomock.Eq(emailChange.ID)).
+					Times(1).
+					Return(emailChange, nil)
+				s.userRepository.EXPECT().
+					FindByEmail(gomock.Eq(newEmail)).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				s.emailChangeRepository.EXPECT().
+					MarkUsed(gomock.Eq(emailChange.ID)).
+					Times(1).
+					Return(true, nil)
+				s.userRepository.EXPECT().
+					UpdateByID(gomock.Eq(user.ID), gomock.Eq(&entity.User{Email: newEmail, IsEmailVerified: true})).
+					Times(1).
+					DoAndReturn(func(_ uuid.UUID, update *entity.User) (*entity.User, error) {
+						updated := user
+						updated.Email = update.Email
+						updated.IsEmailVerified = true
+						return &updated, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response ResultResponse[entity.UserDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, newEmail, response.Result.Email)
+				require.True(t, response.Result.IsEmailVerified)
+			},
+		},
+		{
+			name:        "WrongSecretCode",
+			emailChange: newEmailChange(false, time.Now().Add(time.Minute)),
+			secretCode:  random.NewString(32),
+			buildStubs: func(emailChange *entity.EmailChange) {
+				s.emailChangeRepository.EXPECT().
+					FindByID(gomock.Eq(emailChange.ID)).
+					Times(1).
+					Return(emailChange, nil)
+				s.emailChangeRepository.EXPECT().
+					MarkUsed(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "Expired",
+			emailChange: newEmailChange(false, time.Now().Add(-time.Minute)),
+			secretCode:  secretCode,
+			buildStubs: func(emailChange *entity.EmailChange) {
+				s.emailChangeRepository.EXPECT().
+					FindByID(gomock.Eq(emailChange.ID)).
+					Times(1).
+					Return(emailChange, nil)
+				s.emailChangeRepository.EXPECT().
+					MarkUsed(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "EmailTakenMeanwhile",
+			emailChange: newEmailChange(false, time.Now().Add(time.Minute)),
+			secretCode:  secretCode,
+			buildStubs: func(emailChange *entity.EmailChange) {
+				other := randomUser(s.T())
+				s.emailChangeRepository.EXPECT().
+					FindByID(gomock.Eq(emailChange.ID)).
+					Times(1).
+					Return(emailChange, nil)
+				s.userRepository.EXPECT().
+					FindByEmail(gomock.Eq(newEmail)).
+					Times(1).
+					Return(&other, nil)
+				s.emailChangeRepository.EXPECT().
+					MarkUsed(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			tc.buildStubs(tc.emailChange)
+
+			r.POST("/confirm-email-change", s.handler.ConfirmEmailChange)
+
+			requestBody, err := json.Marshal(entity.EmailChangeConfirmPayload{
+				ID:         tc.emailChange.ID.String(),
+				SecretCode: tc.secretCode,
+			})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/confirm-email-change", bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			c.Request = request
+
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *AuthHandlerSuite) TestLoginWithWalletAPI() {
+	key, err := secp256k1.GeneratePrivateKey()
+	require.NoError(s.T(), err)
+	otherKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(s.T(), err)
+
+	address := walletAddress(key)
+	user := randomUser(s.T())
+	user.Wallets = []entity.UserWallet{{Address: address}}
+	nonce := "a1b2c3d4e5f60718"
+
+	validMessage := walletMessage("fund-o.app", address, nonce, time.Now().Add(5*time.Minute))
+	otherChainMessage := strings.Replace(validMessage, "Chain ID: 1", "Chain ID: 5", 1)
+
+	testCases := []struct {
+		name          string
+		requestBody   entity.UserWalletLoginPayload
+		buildStubs    func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			requestBody: entity.UserWalletLoginPayload{
+				Message:   validMessage,
+				Signature: signWalletMessage(key, validMessage),
+			},
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Eq(nonce)).
+					Times(1).
+					Return("login", nil)
+				userRepo.EXPECT().
+					FindByWalletAddress(gomock.Eq(address)).
+					Times(1).
+					Return(&user, nil)
+				s.mfaRepository.EXPECT().
+					FindTOTPByUserID(gomock.Eq(user.ID)).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				sessionRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(1).
+					Return(&entity.Session{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[entity.UserAuthenticateResponse]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusOK, response.StatusCode)
+				require.NotEmpty(t, response.Result.AccessToken)
+				require.NotEmpty(t, response.Result.RefreshToken)
+				require.Equal(t, user.Email, response.Result.User.Email)
+			},
+		},
+		{
+			name: "MfaRequired",
+			requestBody: entity.UserWalletLoginPayload{
+				Message:   validMessage,
+				Signature: signWalletMessage(key, validMessage),
+			},
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository) {
+				confirmedAt := time.Now()
+				nonceRepo.EXPECT().
+					Consume(gomock.Eq(nonce)).
+					Times(1).
+					Return("login", nil)
+				userRepo.EXPECT().
+					FindByWalletAddress(gomock.Eq(address)).
+					Times(1).
+					Return(&user, nil)
+				s.mfaRepository.EXPECT().
+					FindTOTPByUserID(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&entity.UserTOTP{UserID: user.ID, ConfirmedAt: &confirmedAt}, nil)
+				nonceRepo.EXPECT().
+					Create(gomock.Any(), gomock.Eq("mfa:"+user.ID.String()), gomock.Any()).
+					Times(1).
+					Return(nil)
+				sessionRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[entity.MfaChallengeResponse]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusAccepted, response.StatusCode)
+				require.True(t, response.Result.MfaRequired)
+				require.NotEmpty(t, response.Result.MfaToken)
+			},
+		},
+		{
+			name: "NewUser",
+			requestBody: entity.UserWalletLoginPayload{
+				Message:   validMessage,
+				Signature: signWalletMessage(key, validMessage),
+			},
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Eq(nonce)).
+					Times(1).
+					Return("login", nil)
+				userRepo.EXPECT().
+					FindByWalletAddress(gomock.Eq(address)).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				userRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(1).
+					DoAndReturn(func(user *entity.User) (*entity.User, error) {
+						require.Len(s.T(), user.Wallets, 1)
+						require.Equal(s.T(), address, user.Wallets[0].Address)
+						return user, nil
+					})
+				s.mfaRepository.EXPECT().
+					FindTOTPByUserID(gomock.Any()).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				sessionRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(1).
+					Return(&entity.Session{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[entity.UserAuthenticateResponse]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusOK, response.StatusCode)
+				require.Len(t, response.Result.User.Wallets, 1)
+				require.Equal(t, address, response.Result.User.Wallets[0].Address)
+			},
+		},
+		{
+			name: "NonceAlreadyUsed",
+			requestBody: entity.UserWalletLoginPayload{
+				Message:   validMessage,
+				Signature: signWalletMessage(key, validMessage),
+			},
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Eq(nonce)).
+					Times(1).
+					Return("", nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "SignedByAnotherWallet",
+			requestBody: entity.UserWalletLoginPayload{
+				Message:   validMessage,
+				Signature: signWalletMessage(otherKey, validMessage),
+			},
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "WrongDomain",
+			requestBody: entity.UserWalletLoginPayload{
+				Message:   walletMessage("evil.app", address, nonce, time.Now().Add(5*time.Minute)),
+				Signature: signWalletMessage(key, walletMessage("evil.app", address, nonce, time.Now().Add(5*time.Minute))),
+			},
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "WrongChainID",
+			requestBody: entity.UserWalletLoginPayload{
+				Message:   otherChainMessage,
+				Signature: signWalletMessage(key, otherChainMessage),
+			},
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidMessage",
+			requestBody: entity.UserWalletLoginPayload{
+				Message:   "hello",
+				Signature: signWalletMessage(key, "hello"),
+			},
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository) {
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			tc.buildStubs(s.userRepository, s.sessionRepository, s.nonceRepository)
+
+			r.POST("/wallet/verify", s.handler.LoginWithWallet)
+
+			requestBody, err := json.Marshal(tc.requestBody)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest("POST", "/wallet/verify", bytes.NewBuffer(requestBody))
+			require.NoError(t, err)
+
+			c.Request = request
+
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *AuthHandlerSuite) TestLoginWithGoogleAPI() {
+	verifiedUser := randomUser(s.T())
+	verifiedUser.IsEmailVerified = true
+	unverifiedUser := randomUser(s.T())
+	googleUser := oauth.UserInfo{
+		Subject:       "108234567890",
+		Email:         verifiedUser.Email,
+		EmailVerified: true,
+		Name:          "John Doe",
+	}
+
+	testCases := []struct {
+		name     string
+		userInfo oauth.UserInfo
+		// stateCookie returns the state cookie the callback is sent with, when it differs from the
+		// one set by the start endpoint. An empty state sends no cookie.
+		stateCookie   func(state string) string
+		buildStubs    func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository, stateSubject string)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "LinkedIdentity",
+			userInfo: googleUser,
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository, stateSubject string) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Any()).
+					Times(1).
+					Return(stateSubject, nil)
+				userRepo.EXPECT().
+					FindByIdentity(gomock.Eq(entity.IdentityProviderGoogle), gomock.Eq(googleUser.Subject)).
+					Times(1).
+					Return(&verifiedUser, nil)
+				userRepo.EXPECT().
+					CreateIdentity(gomock.Any()).
+					Times(0)
+				s.mfaRepository.EXPECT().
+					FindTOTPByUserID(gomock.Eq(verifiedUser.ID)).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				sessionRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(1).
+					Return(&entity.Session{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[entity.UserAuthenticateResponse]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusOK, response.StatusCode)
+				require.NotEmpty(t, response.Result.AccessToken)
+				require.NotEmpty(t, response.Result.RefreshToken)
+				require.Equal(t, verifiedUser.ID.String(), response.Result.User.ID)
+			},
+		},
+		{
+			name:     "MfaRequired",
+			userInfo: googleUser,
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository, stateSubject string) {
+				confirmedAt := time.Now()
+				nonceRepo.EXPECT().
+					Consume(gomock.Any()).
+					Times(1).
+					Return(stateSubject, nil)
+				userRepo.EXPECT().
+					FindByIdentity(gomock.Eq(entity.IdentityProviderGoogle), gomock.Eq(googleUser.Subject)).
+					Times(1).
+					Return(&verifiedUser, nil)
+				s.mfaRepository.EXPECT().
+					FindTOTPByUserID(gomock.Eq(verifiedUser.ID)).
+					Times(1).
+					Return(&entity.UserTOTP{UserID: verifiedUser.ID, ConfirmedAt: &confirmedAt}, nil)
+				nonceRepo.EXPECT().
+					Create(gomock.Any(), gomock.Eq("mfa:"+verifiedUser.ID.String()), gomock.Any()).
+					Times(1).
+					Return(nil)
+				sessionRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[entity.MfaChallengeResponse]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusAccepted, response.StatusCode)
+				require.True(t, response.Result.MfaRequired)
+				require.NotEmpty(t, response.Result.MfaToken)
+			},
+		},
+		{
+			name:     "LinkByVerifiedEmail",
+			userInfo: googleUser,
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository, stateSubject string) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Any()).
+					Times(1).
+					Return(stateSubject, nil)
+				userRepo.EXPECT().
+					FindByIdentity(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				userRepo.EXPECT().
+					FindByEmail(gomock.Eq(verifiedUser.Email)).
+					Times(1).
+					Return(&verifiedUser, nil)
+				userRepo.EXPECT().
+					CreateIdentity(gomock.Any()).
+					Times(1).
+					DoAndReturn(func(identity *entity.UserIdentity) (*entity.UserIdentity, error) {
+						require.Equal(s.T(), verifiedUser.ID, identity.UserID)
+						require.Equal(s.T(), googleUser.Subject, identity.Subject)
+						return identity, nil
+					})
+				s.mfaRepository.EXPECT().
+					FindTOTPByUserID(gomock.Eq(verifiedUser.ID)).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				sessionRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(1).
+					Return(&entity.Session{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NewUser",
+			userInfo: oauth.UserInfo{
+				Subject:       "108234567891",
+				Email:         "new-user@gmail.com",
+				EmailVerified: true,
+				Name:          "Jane Doe",
+			},
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository, stateSubject string) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Any()).
+					Times(1).
+					Return(stateSubject, nil)
+				userRepo.EXPECT().
+					FindByIdentity(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				userRepo.EXPECT().
+					FindByEmail(gomock.Eq("new-user@gmail.com")).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				userRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(1).
+					DoAndReturn(func(user *entity.User) (*entity.User, error) {
+						require.True(s.T(), user.IsEmailVerified)
+						require.Empty(s.T(), user.HashedPassword)
+						require.Len(s.T(), user.Identities, 1)
+						require.Equal(s.T(), "108234567891", user.Identities[0].Subject)
+						return user, nil
+					})
+				s.mfaRepository.EXPECT().
+					FindTOTPByUserID(gomock.Any()).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				sessionRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(1).
+					Return(&entity.Session{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[entity.UserAuthenticateResponse]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusOK, response.StatusCode)
+				require.Equal(t, "Jane Doe", response.Result.User.DisplayName)
+				require.True(t, response.Result.User.IsEmailVerified)
+			},
+		},
+		{
+			name: "UnverifiedAccount",
+			userInfo: oauth.UserInfo{
+				Subject:       "108234567892",
+				Email:         unverifiedUser.Email,
+				EmailVerified: true,
+			},
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository, stateSubject string) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Any()).
+					Times(1).
+					Return(stateSubject, nil)
+				userRepo.EXPECT().
+					FindByIdentity(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				userRepo.EXPECT().
+					FindByEmail(gomock.Eq(unverifiedUser.Email)).
+					Times(1).
+					Return(&unverifiedUser, nil)
+				userRepo.EXPECT().
+					CreateIdentity(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "EmailNotVerifiedByGoogle",
+			userInfo: oauth.UserInfo{
+				Subject:       "108234567893",
+				Email:         verifiedUser.Email,
+				EmailVerified: false,
+			},
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository, stateSubject string) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Any()).
+					Times(1).
+					Return(stateSubject, nil)
+				userRepo.EXPECT().
+					FindByIdentity(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				userRepo.EXPECT().
+					FindByEmail(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "StateAlreadyUsed",
+			userInfo: googleUser,
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository, stateSubject string) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Any()).
+					Times(1).
+					Return("", nil)
+				userRepo.EXPECT().
+					FindByIdentity(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "StateCookieMissing",
+			userInfo:    googleUser,
+			stateCookie: func(state string) string { return "" },
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository, stateSubject string) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "StateOfAnotherBrowser",
+			userInfo:    googleUser,
+			stateCookie: func(state string) string { return random.NewString(22) },
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository, stateSubject string) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "WrongCodeVerifier",
+			userInfo: googleUser,
+			buildStubs: func(userRepo *mocks.MockUserRepository, sessionRepo *mocks.MockSessionRepository, nonceRepo *mocks.MockNonceRepository, stateSubject string) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Any()).
+					Times(1).
+					Return("oauth:google:another-verifier", nil)
+				userRepo.EXPECT().
+					FindByIdentity(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			_, r := gin.CreateTestContext(httptest.NewRecorder())
+			r.GET("/google", s.handler.LoginWithGoogle)
+			r.POST("/google/callback", s.handler.GoogleCallback)
+
+			var stateSubject string
+			s.nonceRepository.EXPECT().
+				Create(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ string, subject string, _ time.Duration) error {
+					stateSubject = subject
+					return nil
+				})
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/google", nil)
+			require.NoError(t, err)
+			r.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusTemporaryRedirect, recorder.Code)
+
+			location, err := url.Parse(recorder.Header().Get("Location"))
+			require.NoError(t, err)
+			require.Equal(t, "S256", location.Query().Get("code_challenge_method"))
+
+			state := location.Query().Get("state")
+			cookies := recorder.Result().Cookies()
+			require.Len(t, cookies, 1)
+			require.Equal(t, googleStateCookie, cookies[0].Name)
+			require.Equal(t, state, cookies[0].Value)
+			require.True(t, cookies[0].HttpOnly)
+
+			// Sign in at the identity provider, which redirects the frontend back with a code.
+			code := s.googleServer.authorize(location.Query().Get("code_challenge"), tc.userInfo)
+			tc.buildStubs(s.userRepository, s.sessionRepository, s.nonceRepository, stateSubject)
+
+			requestBody, err := json.Marshal(entity.OAuthCallbackPayload{State: state, Code: code})
+			require.NoError(t, err)
+
+			recorder = httptest.NewRecorder()
+			request, err = http.NewRequest(http.MethodPost, "/google/callback", bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			cookie := cookies[0]
+			if tc.stateCookie != nil {
+				cookie.Value = tc.stateCookie(state)
+			}
+			if cookie.Value != "" {
+				request.AddCookie(cookie)
+			}
+
+			r.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+
+			// The state cookie is cleared whatever the outcome.
+			cookies = recorder.Result().Cookies()
+			require.Len(t, cookies, 1)
+			require.Equal(t, googleStateCookie, cookies[0].Name)
+			require.Negative(t, cookies[0].MaxAge)
+		})
+	}
+}
+
+func (s *AuthHandlerSuite) TestGetJWKSAPI() {
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(s.T(), err)
+
+	keySet, err := token.NewKeySet("key-1", privateKey)
+	require.NoError(s.T(), err)
+
+	testCases := []struct {
+		name          string
+		keySet        *token.KeySet
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			keySet: keySet,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var jwks token.JWKS
+				err := json.Unmarshal(recorder.Body.Bytes(), &jwks)
+				require.NoError(t, err)
+
+				require.Len(t, jwks.Keys, 1)
+				require.Equal(t, "key-1", jwks.Keys[0].KeyID)
+				require.Equal(t, "EdDSA", jwks.Keys[0].Algorithm)
+				require.Equal(t, base64.RawURLEncoding.EncodeToString(privateKey.Public().(ed25519.PublicKey)), jwks.Keys[0].X)
+			},
+		},
+		{
+			name:   "SharedSecret",
+			keySet: nil,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.JSONEq(t, `{"keys":[]}`, recorder.Body.String())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(recorder)
+
+			handler := NewAuthHandler(&AuthHandlerOptions{KeySet: tc.keySet})
+			r.GET("/.well-known/jwks.json", handler.GetJWKS)
+
+			request, err := http.NewRequest("GET", "/.well-known/jwks.json", nil)
+			require.NoError(t, err)
+
+			r.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+// fakeGoogleServer is a minimal identity provider implementing the authorization code flow with PKCE.
+type fakeGoogleServer struct {
+	server *httptest.Server
+	grants map[string]fakeGoogleGrant
+}
+
+type fakeGoogleGrant struct {
+	codeChallenge string
+	userInfo      oauth.UserInfo
+}
+
+func newFakeGoogleServer(t *testing.T) *fakeGoogleServer {
+	fake := &fakeGoogleServer{grants: make(map[string]fakeGoogleGrant)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		code := r.PostForm.Get("code")
+		grant, ok := fake.grants[code]
+		if !ok || oauth.CodeChallenge(r.PostForm.Get("code_verifier")) != grant.codeChallenge {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "access-" + code, "token_type": "Bearer"})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		grant, ok := fake.grants[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer access-")]
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(grant.userInfo)
+	})
+
+	fake.server = httptest.NewServer(mux)
+	t.Cleanup(fake.server.Close)
+	return fake
+}
+
+func (fake *fakeGoogleServer) provider() oauth.Provider {
+	return oauth.NewProvider(&oauth.Config{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		AuthURL:      fake.server.URL + "/auth",
+		TokenURL:     fake.server.URL + "/token",
+		UserInfoURL:  fake.server.URL + "/userinfo",
+		RedirectURL:  "http://localhost:3000/api/v1/auth/google/callback",
+		Scopes:       []string{"openid", "email", "profile"},
+	})
+}
+
+func (fake *fakeGoogleServer) authorize(codeChallenge string, userInfo oauth.UserInfo) string {
+	code := random.NewString(16)
+	fake.grants[code] = fakeGoogleGrant{codeChallenge: codeChallenge, userInfo: userInfo}
+	return code
+}
+
+func walletMessage(domain, address, nonce string, expiredAt time.Time) string {
+	return fmt.Sprintf(`%s wants you to sign in with your Ethereum account:
+%s
+
+Sign in to Fund-O
+
+URI: https://%s
+Version: 1
+Chain ID: 1
+Nonce: %s
+Issued At: %s
+Expiration Time: %s`, domain, address, domain, nonce, time.Now().UTC().Format(time.RFC3339), expiredAt.UTC().Format(time.RFC3339))
+}
+
+func walletAddress(key *secp256k1.PrivateKey) string {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(key.PubKey().SerializeUncompressed()[1:])
+	address, _ := chain.ChecksumAddress("0x" + hex.EncodeToString(hash.Sum(nil)[12:]))
+	return address
+}
+
+func signWalletMessage(key *secp256k1.PrivateKey, message string) string {
+	compact := ecdsa.SignCompact(key, chain.PersonalMessageHash(message), false)
+	return "0x" + hex.EncodeToString(append(compact[1:], compact[0]))
+}
+
 func TestAuthHandlerSuite(t *testing.T) {
 	suite.Run(t, new(AuthHandlerSuite))
 }
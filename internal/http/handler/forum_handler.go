@@ -103,6 +103,29 @@ func (h *ForumHandler) GetPostByID(c *gin.Context) {
 	c.JSON(makeHttpResponse(http.StatusOK, forumDto))
 }
 
+// DeletePost godoc
+// @summary Delete Post
+// @description Delete post as a moderator or admin
+// @tags forums
+// @id DeletePost
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "post id to delete"
+// @success 200 {object} handler.MessageResponse
+// @failure 400 {object} handler.ErrorResponse
+// @failure 403 {object} handler.ErrorResponse
+// @failure 404 {object} handler.ErrorResponse
+// @failure 500 {object} handler.ErrorResponse
+// @router /posts/{id} [delete]
+func (h *ForumHandler) DeletePost(c *gin.Context) {
+	if err := h.forumUseCase.DeletePost(c.Param("id")); err != nil {
+		c.JSON(makeHttpErrorResponse(err.Status(), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpMessageResponse(http.StatusOK, "post deleted successfully"))
+}
+
 // CreateComment godoc
 // @summary Create Comment
 // @description Create comment for forum
@@ -354,7 +354,7 @@ func (s *ForumTestSuite) TestCreatePostAPI() {
 			recorder := httptest.NewRecorder()
 			c, r := gin.CreateTestContext(recorder)
 
-			r.POST("/posts", middleware.AuthMiddleware(s.tokenMaker), s.handler.CreatePost)
+			r.POST("/posts", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.CreatePost)
 
 			requestBody, err := json.Marshal(tc.payload)
 			require.NoError(t, err)
@@ -465,7 +465,7 @@ func (s *ForumTestSuite) TestCreateCommentAPI() {
 			recorder := httptest.NewRecorder()
 			c, r := gin.CreateTestContext(recorder)
 
-			r.POST("/posts/:id/comments", middleware.AuthMiddleware(s.tokenMaker), s.handler.CreateComment)
+			r.POST("/posts/:id/comments", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.CreateComment)
 
 			requestBody, err := json.Marshal(tc.payload)
 			require.NoError(t, err)
@@ -575,7 +575,7 @@ func (s *ForumTestSuite) TestCreateReplyAPI() {
 			recorder := httptest.NewRecorder()
 			c, r := gin.CreateTestContext(recorder)
 
-			r.POST("/comments/:id/replies", middleware.AuthMiddleware(s.tokenMaker), s.handler.CreateReply)
+			r.POST("/comments/:id/replies", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.CreateReply)
 
 			requestBody, err := json.Marshal(tc.payload)
 			require.NoError(t, err)
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"fund-o/api-server/pkg/health"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	checker health.Checker
+}
+
+type HealthHandlerOptions struct {
+	health.Checker
+}
+
+func NewHealthHandler(options *HealthHandlerOptions) *HealthHandler {
+	return &HealthHandler{
+		checker: options.Checker,
+	}
+}
+
+// Healthz reports that the process is alive and serving requests. It checks no dependencies, so
+// an orchestrator never restarts the server because of an outage elsewhere.
+func (h *HealthHandler) Healthz(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{"status": health.StatusOK})
+}
+
+// Readyz reports whether the server should receive traffic, with the result of every dependency
+// check. It answers 503 when a check fails or the server is shutting down.
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	report := h.checker.Ready(c.Request.Context())
+
+	code := http.StatusOK
+	if !report.OK() {
+		code = http.StatusServiceUnavailable
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(code, report)
+}
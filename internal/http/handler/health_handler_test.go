@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fund-o/api-server/pkg/health"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthSuite struct {
+	suite.Suite
+}
+
+func (s *HealthSuite) TestHealthzAPI() {
+	checker := health.NewChecker()
+	checker.Register(health.Check{Name: "postgres", Timeout: time.Second, Probe: func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}})
+	checker.Drain()
+
+	recorder := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(recorder)
+	h := NewHealthHandler(&HealthHandlerOptions{Checker: checker})
+	r.GET("/healthz", h.Healthz)
+
+	r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(s.T(), http.StatusOK, recorder.Code)
+}
+
+func (s *HealthSuite) TestReadyzAPI() {
+	testCases := []struct {
+		name          string
+		buildChecker  func() health.Checker
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildChecker: func() health.Checker {
+				checker := health.NewChecker()
+				checker.Register(health.Check{Name: "postgres", Timeout: time.Second, Probe: func(ctx context.Context) error { return nil }})
+				checker.Register(health.Check{Name: "redis", Timeout: time.Second, Probe: func(ctx context.Context) error { return nil }})
+				return checker
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var report health.Report
+				err := json.Unmarshal(recorder.Body.Bytes(), &report)
+				require.NoError(t, err)
+				require.Equal(t, health.StatusOK, report.Status)
+				require.Len(t, report.Checks, 2)
+			},
+		},
+		{
+			name: "FailingCheck",
+			buildChecker: func() health.Checker {
+				checker := health.NewChecker()
+				checker.Register(health.Check{Name: "postgres", Timeout: time.Second, Probe: func(ctx context.Context) error { return nil }})
+				checker.Register(health.Check{Name: "redis", Timeout: time.Second, Probe: func(ctx context.Context) error {
+					return errors.New("connection refused")
+				}})
+				return checker
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+				var report health.Report
+				err := json.Unmarshal(recorder.Body.Bytes(), &report)
+				require.NoError(t, err)
+				require.Equal(t, health.StatusFail, report.Status)
+				require.Equal(t, health.StatusOK, report.Checks["postgres"].Status)
+				require.Equal(t, "connection refused", report.Checks["redis"].Error)
+			},
+		},
+		{
+			name: "Draining",
+			buildChecker: func() health.Checker {
+				checker := health.NewChecker()
+				checker.Register(health.Check{Name: "postgres", Timeout: time.Second, Probe: func(ctx context.Context) error { return nil }})
+				checker.Drain()
+				return checker
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+				var report health.Report
+				err := json.Unmarshal(recorder.Body.Bytes(), &report)
+				require.NoError(t, err)
+				require.Equal(t, health.StatusFail, report.Status)
+				require.True(t, report.Draining)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(recorder)
+			h := NewHealthHandler(&HealthHandlerOptions{Checker: tc.buildChecker()})
+			r.GET("/readyz", h.Readyz)
+
+			r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestHealthSuite(t *testing.T) {
+	suite.Run(t, new(HealthSuite))
+}
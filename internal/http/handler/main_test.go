@@ -29,7 +29,19 @@ func addAuthorization(
 	userID string,
 	duration time.Duration,
 ) {
-	tkn, payload, err := tokenMaker.CreateToken(userID, duration)
+	addRoleAuthorization(t, request, tokenMaker, authorizationType, userID, entity.RoleUser, duration)
+}
+
+func addRoleAuthorization(
+	t *testing.T,
+	request *http.Request,
+	tokenMaker token.Maker,
+	authorizationType string,
+	userID string,
+	role entity.UserRole,
+	duration time.Duration,
+) {
+	tkn, payload, err := tokenMaker.CreateToken(userID, role.String(), token.TypeAccess, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 
@@ -55,6 +67,7 @@ func randomUser(t *testing.T) entity.User {
 		DisplayName:    "John Doe",
 		BirthDate:      birthDate,
 		Gender:         entity.Male,
+		Role:           entity.RoleUser,
 	}
 
 	return user
@@ -1,27 +1,36 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
+	"fund-o/api-server/cmd/worker"
 	"fund-o/api-server/internal/entity"
 	"fund-o/api-server/internal/http/middleware"
 	"fund-o/api-server/internal/usecase"
+	"fund-o/api-server/pkg/apperrors"
+	"fund-o/api-server/pkg/chain"
 	"fund-o/api-server/pkg/token"
 	"github.com/shopspring/decimal"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
 )
 
 type ProjectHandler struct {
 	projectUseCase         usecase.ProjectUseCase
 	projectCategoryUseCase usecase.ProjectCategoryUseCase
 	userUseCase            usecase.UserUseCase
+	taskDistributor        worker.TaskDistributor
 }
 
 type ProjectHandlerOptions struct {
 	usecase.ProjectUseCase
 	usecase.ProjectCategoryUseCase
 	usecase.UserUseCase
+	worker.TaskDistributor
 }
 
 func NewProjectHandler(options *ProjectHandlerOptions) *ProjectHandler {
@@ -29,18 +38,21 @@ func NewProjectHandler(options *ProjectHandlerOptions) *ProjectHandler {
 		projectUseCase:         options.ProjectUseCase,
 		projectCategoryUseCase: options.ProjectCategoryUseCase,
 		userUseCase:            options.UserUseCase,
+		taskDistributor:        options.TaskDistributor,
 	}
 }
 
 // ListProjects godoc
 // @summary List Projects
-// @description List projects
+// @description List projects. Only moderators see the projects that are not live, succeeded or failed, besides the signed in user's own projects
 // @tags projects
 // @id ListProjects
 // @accept json
 // @produce json
+// @security ApiKeyAuth
 // @param page query int false "number of page"
 // @param size query int false "size of data per page"
+// @param status query string false "project status" Enums(draft, pending_review, live, succeeded, failed, cancelled)
 // @response 200 {object} handler.ResultResponse[pagination.PaginateResult[entity.ProjectDto]] "OK"
 // @response 400 {object} handler.ErrorResponse "Bad Request"
 // @response 500 {object} handler.ErrorResponse "Internal Server Error"
@@ -52,7 +64,12 @@ func (h *ProjectHandler) ListProjects(c *gin.Context) {
 		return
 	}
 
-	projects := h.projectUseCase.ListProjects(params)
+	projects, err := h.projectUseCase.ListProjects(optionalUserID(c), optionalUserRole(c), params)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(err.Status(), err.Error()))
+		return
+	}
+
 	c.JSON(makeHttpResponse(http.StatusOK, projects))
 }
 
@@ -88,6 +105,11 @@ func (h *ProjectHandler) CreateProject(c *gin.Context) {
 
 	projectDto, err := h.projectUseCase.CreateProject(&req)
 	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidFundingGoal) {
+			c.JSON(makeHttpErrorResponse(http.StatusBadRequest, fmt.Sprintf("error create project: %v", err.Error())))
+			return
+		}
+
 		c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error create project: %v", err.Error())))
 		return
 	}
@@ -99,20 +121,22 @@ func (h *ProjectHandler) CreateProject(c *gin.Context) {
 
 // GetProjectByID godoc
 // @summary Get Project by ID
-// @description Get project by ID
+// @description Get project by ID. Projects that are not live, succeeded or failed are only found by their owner and moderators
 // @tags projects
 // @id GetProjectByID
 // @accept json
 // @produce json
+// @security ApiKeyAuth
 // @param id path string true "Project ID"
 // @response 200 {object} handler.ResultResponse[entity.ProjectDto] "OK"
 // @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 404 {object} handler.ErrorResponse "Not Found"
 // @response 500 {object} handler.ErrorResponse "Internal Server Error"
 // @router /projects/{id} [get]
 func (h *ProjectHandler) GetProjectByID(c *gin.Context) {
 	projectID := c.Param("id")
 
-	projectDto, err := h.projectUseCase.GetProjectByID(projectID)
+	projectDto, err := h.projectUseCase.GetProjectByID(optionalUserID(c), optionalUserRole(c), projectID)
 	if err != nil {
 		c.JSON(makeHttpErrorResponse(err.Status(), err.Error()))
 		return
@@ -174,6 +198,36 @@ func (h *ProjectHandler) ListProjectCategories(c *gin.Context) {
 	c.JSON(makeHttpResponse(http.StatusOK, categories))
 }
 
+// CreateProjectCategory godoc
+// @summary Create Project Category
+// @description Create project category with its subcategories (admin)
+// @tags projects
+// @id CreateProjectCategory
+// @accept json
+// @produce json
+// @security ApiKeyAuth
+// @param ProjectCategory body entity.ProjectCategoryCreatePayload true "Category data to be created"
+// @response 201 {object} handler.ResultResponse[entity.ProjectCategoryDto] "Created"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 403 {object} handler.ErrorResponse "Forbidden"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /projects/categories [post]
+func (h *ProjectHandler) CreateProjectCategory(c *gin.Context) {
+	var req entity.ProjectCategoryCreatePayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, fmt.Sprintf("error create project category: %v", err.Error())))
+		return
+	}
+
+	category, err := h.projectCategoryUseCase.CreateProjectCategory(&req)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error create project category: %v", err.Error())))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusCreated, category))
+}
+
 // CreateProjectRating godoc
 // @summary Create Project Rating
 // @description Create project rating with required data
@@ -247,13 +301,541 @@ func (h *ProjectHandler) ContributeProject(c *gin.Context) {
 		return
 	}
 
-	err := h.projectUseCase.CreateBackProject(userID, &req)
+	backer, err := h.projectUseCase.CreateBackProject(c, userID, &req)
 	if err != nil {
-		c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, fmt.Sprintf("error create project rating: %v", err.Error())))
+		c.JSON(makeHttpErrorResponse(contributeErrorStatus(err), fmt.Sprintf("error contribute project: %v", err.Error())))
 		return
 	}
 
-	c.JSON(makeHttpMessageResponse(http.StatusCreated, "user backed project successfully"))
+	if backer.Status == entity.BackerPending.String() {
+		taskPayload := &worker.PayloadVerifyContribution{
+			BackerID: backer.ID,
+		}
+
+		opts := []asynq.Option{
+			asynq.MaxRetry(20),
+			asynq.ProcessIn(15 * time.Second),
+			asynq.Queue(worker.QueueDefault),
+		}
+
+		h.taskDistributor.DistributeTaskVerifyContribution(c, taskPayload, opts...)
+	}
+
+	c.JSON(makeHttpResponse(http.StatusCreated, backer))
+}
+
+// SubmitProject godoc
+// @summary Submit Project
+// @description Submit a draft project for review
+// @tags projects
+// @id SubmitProject
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "Project ID"
+// @response 200 {object} handler.ResultResponse[entity.ProjectDto] "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 403 {object} handler.ErrorResponse "Forbidden"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 409 {object} handler.ErrorResponse "Conflict"
+// @router /projects/{id}/submit [post]
+func (h *ProjectHandler) SubmitProject(c *gin.Context) {
+	projectID := c.Param("id")
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	projectDto, err := h.projectUseCase.SubmitProject(userID, projectID)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(err.Status(), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusOK, projectDto))
+}
+
+// PublishProject godoc
+// @summary Publish Project
+// @description Approve a project pending review so it starts accepting contributions (moderator or admin)
+// @tags projects
+// @id PublishProject
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "Project ID"
+// @response 200 {object} handler.ResultResponse[entity.ProjectDto] "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 403 {object} handler.ErrorResponse "Forbidden"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 409 {object} handler.ErrorResponse "Conflict"
+// @router /projects/{id}/publish [post]
+func (h *ProjectHandler) PublishProject(c *gin.Context) {
+	projectDto, err := h.projectUseCase.PublishProject(c.Param("id"))
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(err.Status(), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusOK, projectDto))
+}
+
+// RejectProject godoc
+// @summary Reject Project
+// @description Send a project pending review back to its owner as a draft (moderator or admin)
+// @tags projects
+// @id RejectProject
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "Project ID"
+// @response 200 {object} handler.ResultResponse[entity.ProjectDto] "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 403 {object} handler.ErrorResponse "Forbidden"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 409 {object} handler.ErrorResponse "Conflict"
+// @router /projects/{id}/reject [post]
+func (h *ProjectHandler) RejectProject(c *gin.Context) {
+	projectDto, err := h.projectUseCase.RejectProject(c.Param("id"))
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(err.Status(), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusOK, projectDto))
+}
+
+// CancelProject godoc
+// @summary Cancel Project
+// @description Cancel a project that has not finished yet
+// @tags projects
+// @id CancelProject
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "Project ID"
+// @response 200 {object} handler.ResultResponse[entity.ProjectDto] "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 403 {object} handler.ErrorResponse "Forbidden"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 409 {object} handler.ErrorResponse "Conflict"
+// @router /projects/{id}/cancel [post]
+func (h *ProjectHandler) CancelProject(c *gin.Context) {
+	projectID := c.Param("id")
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	projectDto, err := h.projectUseCase.CancelProject(userID, projectID)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(err.Status(), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusOK, projectDto))
+}
+
+// RequestRefund godoc
+// @summary Request Refund
+// @description Request a refund of own contributions to a failed or cancelled project
+// @tags projects
+// @id RequestRefund
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "Project ID"
+// @response 202 {object} handler.ResultResponse[[]entity.ProjectRefundDto] "Accepted"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 409 {object} handler.ErrorResponse "Conflict"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /projects/{id}/refunds [post]
+func (h *ProjectHandler) RequestRefund(c *gin.Context) {
+	projectID := c.Param("id")
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	refunds, err := h.projectUseCase.RequestRefund(userID, projectID)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(err.Status(), err.Error()))
+		return
+	}
+
+	h.distributeRefunds(c, refunds)
+	c.JSON(makeHttpResponse(http.StatusAccepted, refunds))
+}
+
+// InitiateRefunds godoc
+// @summary Initiate Refunds
+// @description Request a refund of every contribution to own failed or cancelled project. Admins may initiate the refunds of any project.
+// @tags projects
+// @id InitiateRefunds
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "Project ID"
+// @response 202 {object} handler.ResultResponse[[]entity.ProjectRefundDto] "Accepted"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 403 {object} handler.ErrorResponse "Forbidden"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 409 {object} handler.ErrorResponse "Conflict"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /projects/{id}/refunds/initiate [post]
+func (h *ProjectHandler) InitiateRefunds(c *gin.Context) {
+	projectID := c.Param("id")
+	payload := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	role, _ := entity.ParseUserRole(payload.Role)
+
+	refunds, err := h.projectUseCase.InitiateRefunds(payload.UserID, role, projectID)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(err.Status(), err.Error()))
+		return
+	}
+
+	h.distributeRefunds(c, refunds)
+	c.JSON(makeHttpResponse(http.StatusAccepted, refunds))
+}
+
+// distributeRefunds queues the processing of each requested refund. Refunds wait for the backer
+// to claim the funds from the contract, so they are retried for a long time before failing.
+func (h *ProjectHandler) distributeRefunds(c *gin.Context, refunds []entity.ProjectRefundDto) {
+	opts := []asynq.Option{
+		asynq.MaxRetry(25),
+		asynq.Queue(worker.QueueDefault),
+	}
+
+	for _, refund := range refunds {
+		h.taskDistributor.DistributeTaskProcessRefund(c, &worker.PayloadProcessRefund{RefundID: refund.ID}, opts...)
+	}
+}
+
+func contributeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, apperrors.ErrProjectNotLive),
+		errors.Is(err, apperrors.ErrInvalidRewardID),
+		errors.Is(err, chain.ErrInvalidHash),
+		errors.Is(err, apperrors.ErrRewardMinimumNotMet):
+		return http.StatusBadRequest
+	case errors.Is(err, apperrors.ErrRewardNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, apperrors.ErrRewardSoldOut),
+		errors.Is(err, apperrors.ErrTxHashAlreadyUsed):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ListProjectRewards godoc
+// @summary List Project Rewards
+// @description List reward tiers of a project
+// @tags projects
+// @id ListProjectRewards
+// @produce json
+// @param id path string true "Project ID"
+// @response 200 {object} handler.ResultResponse[[]entity.ProjectRewardDto] "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /projects/{id}/rewards [get]
+func (h *ProjectHandler) ListProjectRewards(c *gin.Context) {
+	projectID := c.Param("id")
+
+	rewards, err := h.projectUseCase.ListProjectRewards(projectID)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(err.Status(), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusOK, rewards))
+}
+
+// CreateProjectReward godoc
+// @summary Create Project Reward
+// @description Create reward tier for own project
+// @tags projects
+// @id CreateProjectReward
+// @accept json
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "Project ID"
+// @param ProjectReward body entity.ProjectRewardCreatePayload true "Reward data to be created"
+// @response 201 {object} handler.ResultResponse[entity.ProjectRewardDto] "Created"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 403 {object} handler.ErrorResponse "Forbidden"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /projects/{id}/rewards [post]
+func (h *ProjectHandler) CreateProjectReward(c *gin.Context) {
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	var req entity.ProjectRewardCreatePayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, fmt.Sprintf("error create project reward: %v", err.Error())))
+		return
+	}
+	req.ProjectID = c.Param("id")
+
+	reward, err := h.projectUseCase.CreateProjectReward(userID, &req)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(err.Status(), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusCreated, reward))
+}
+
+// UpdateProjectReward godoc
+// @summary Update Project Reward
+// @description Update reward tier of own project. Set clear_quantity_limit to remove its quantity limit
+// @tags projects
+// @id UpdateProjectReward
+// @accept json
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "Project ID"
+// @param rewardId path string true "Reward ID"
+// @param ProjectReward body entity.ProjectRewardUpdatePayload true "Reward data to be updated"
+// @response 200 {object} handler.ResultResponse[entity.ProjectRewardDto] "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 403 {object} handler.ErrorResponse "Forbidden"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 409 {object} handler.ErrorResponse "Conflict"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /projects/{id}/rewards/{rewardId} [patch]
+func (h *ProjectHandler) UpdateProjectReward(c *gin.Context) {
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	var req entity.ProjectRewardUpdatePayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, fmt.Sprintf("error update project reward: %v", err.Error())))
+		return
+	}
+	req.ProjectID = c.Param("id")
+	req.RewardID = c.Param("rewardId")
+
+	reward, err := h.projectUseCase.UpdateProjectReward(userID, &req)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(err.Status(), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusOK, reward))
+}
+
+// DeleteProjectReward godoc
+// @summary Delete Project Reward
+// @description Delete reward tier of own project that nobody has claimed yet
+// @tags projects
+// @id DeleteProjectReward
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "Project ID"
+// @param rewardId path string true "Reward ID"
+// @response 200 {object} handler.MessageResponse "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 403 {object} handler.ErrorResponse "Forbidden"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 409 {object} handler.ErrorResponse "Conflict"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /projects/{id}/rewards/{rewardId} [delete]
+func (h *ProjectHandler) DeleteProjectReward(c *gin.Context) {
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	err := h.projectUseCase.DeleteProjectReward(userID, c.Param("id"), c.Param("rewardId"))
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(err.Status(), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpMessageResponse(http.StatusOK, "project reward deleted successfully"))
+}
+
+// ListProjectUpdates godoc
+// @summary List Project Updates
+// @description List updates of a project, including backers-only updates for its owner and backers
+// @tags projects
+// @id ListProjectUpdates
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "Project ID"
+// @response 200 {object} handler.ResultResponse[[]entity.ProjectUpdateDto] "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /projects/{id}/updates [get]
+func (h *ProjectHandler) ListProjectUpdates(c *gin.Context) {
+	updates, err := h.projectUseCase.ListProjectUpdates(optionalUserID(c), c.Param("id"))
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(err.Status(), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusOK, updates))
+}
+
+// GetProjectUpdate godoc
+// @summary Get Project Update
+// @description Get an update of a project
+// @tags projects
+// @id GetProjectUpdate
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "Project ID"
+// @param updateId path string true "Update ID"
+// @response 200 {object} handler.ResultResponse[entity.ProjectUpdateDto] "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 403 {object} handler.ErrorResponse "Forbidden"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /projects/{id}/updates/{updateId} [get]
+func (h *ProjectHandler) GetProjectUpdate(c *gin.Context) {
+	update, err := h.projectUseCase.GetProjectUpdate(optionalUserID(c), c.Param("id"), c.Param("updateId"))
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(err.Status(), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusOK, update))
+}
+
+// CreateProjectUpdate godoc
+// @summary Create Project Update
+// @description Post an update to own project and notify its backers by email
+// @tags projects
+// @id CreateProjectUpdate
+// @accept json
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "Project ID"
+// @param ProjectUpdate body entity.ProjectUpdateCreatePayload true "Update data to be created"
+// @response 201 {object} handler.ResultResponse[entity.ProjectUpdateDto] "Created"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 403 {object} handler.ErrorResponse "Forbidden"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /projects/{id}/updates [post]
+func (h *ProjectHandler) CreateProjectUpdate(c *gin.Context) {
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	var req entity.ProjectUpdateCreatePayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, fmt.Sprintf("error create project update: %v", err.Error())))
+		return
+	}
+	req.ProjectID = c.Param("id")
+
+	update, err := h.projectUseCase.CreateProjectUpdate(userID, &req)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(err.Status(), err.Error()))
+		return
+	}
+
+	h.distributeProjectUpdateEmails(c, update)
+	c.JSON(makeHttpResponse(http.StatusCreated, update))
+}
+
+// UpdateProjectUpdate godoc
+// @summary Update Project Update
+// @description Edit an update of own project
+// @tags projects
+// @id UpdateProjectUpdate
+// @accept json
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "Project ID"
+// @param updateId path string true "Update ID"
+// @param ProjectUpdate body entity.ProjectUpdateUpdatePayload true "Update data to be updated"
+// @response 200 {object} handler.ResultResponse[entity.ProjectUpdateDto] "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 403 {object} handler.ErrorResponse "Forbidden"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /projects/{id}/updates/{updateId} [patch]
+func (h *ProjectHandler) UpdateProjectUpdate(c *gin.Context) {
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	var req entity.ProjectUpdateUpdatePayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, fmt.Sprintf("error update project update: %v", err.Error())))
+		return
+	}
+	req.ProjectID = c.Param("id")
+	req.UpdateID = c.Param("updateId")
+
+	update, err := h.projectUseCase.UpdateProjectUpdate(userID, &req)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(err.Status(), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusOK, update))
+}
+
+// DeleteProjectUpdate godoc
+// @summary Delete Project Update
+// @description Delete an update of own project
+// @tags projects
+// @id DeleteProjectUpdate
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "Project ID"
+// @param updateId path string true "Update ID"
+// @response 200 {object} handler.MessageResponse "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 403 {object} handler.ErrorResponse "Forbidden"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /projects/{id}/updates/{updateId} [delete]
+func (h *ProjectHandler) DeleteProjectUpdate(c *gin.Context) {
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	err := h.projectUseCase.DeleteProjectUpdate(userID, c.Param("id"), c.Param("updateId"))
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(err.Status(), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpMessageResponse(http.StatusOK, "project update deleted successfully"))
+}
+
+// distributeProjectUpdateEmails queues one notification email per backer of the project. The
+// update is already saved, so a failure here is logged and not returned.
+func (h *ProjectHandler) distributeProjectUpdateEmails(c *gin.Context, update *entity.ProjectUpdateDto) {
+	emails, err := h.projectUseCase.ListBackerEmails(update.ProjectID)
+	if err != nil {
+		log.Error().
+			Ctx(c.Request.Context()).
+			Err(err).
+			Str("project_id", update.ProjectID).
+			Str("update_id", update.ID).
+			Msg("failed to list the backers to notify of a project update")
+		return
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueDefault),
+	}
+
+	for _, email := range emails {
+		h.taskDistributor.DistributeTaskSendProjectUpdateEmail(c, &worker.PayloadSendProjectUpdateEmail{
+			UpdateID: update.ID,
+			Email:    email,
+		}, opts...)
+	}
+}
+
+// optionalUserID returns the ID of the signed in user behind OptionalAuthMiddleware, or an empty
+// string for anonymous requests.
+func optionalUserID(c *gin.Context) string {
+	payload, ok := c.Get(middleware.AuthorizationPayloadKey)
+	if !ok {
+		return ""
+	}
+
+	return payload.(*token.Payload).UserID
+}
+
+// optionalUserRole returns the role of the signed in user behind OptionalAuthMiddleware. Anonymous
+// requests get the role of a plain user.
+func optionalUserRole(c *gin.Context) entity.UserRole {
+	payload, ok := c.Get(middleware.AuthorizationPayloadKey)
+	if !ok {
+		return entity.RoleUser
+	}
+
+	role, _ := entity.ParseUserRole(payload.(*token.Payload).Role)
+	return role
 }
 
 type GetBackedProjectResponse struct {
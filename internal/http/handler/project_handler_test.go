@@ -1,19 +1,24 @@
 package handler
 
 import (
+	"bytes"
+	"crypto/rand"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"fund-o/api-server/internal/entity"
 	"fund-o/api-server/internal/http/middleware"
 	"fund-o/api-server/internal/usecase"
 	"fund-o/api-server/mocks"
+	"fund-o/api-server/pkg/apperrors"
 	"fund-o/api-server/pkg/pagination"
 	"fund-o/api-server/pkg/random"
 	"fund-o/api-server/pkg/token"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
@@ -29,6 +34,9 @@ type ProjectTestSuite struct {
 	tokenMaker                token.Maker
 	repository                *mocks.MockProjectRepository
 	projectCategoryRepository *mocks.MockProjectCategoryRepository
+	updateRepository          *mocks.MockProjectUpdateRepository
+	userRepository            *mocks.MockUserRepository
+	taskDistributor           *mocks.MockTaskDistributor
 	handler                   *ProjectHandler
 }
 
@@ -43,8 +51,13 @@ func (s *ProjectTestSuite) SetupSuite() {
 
 	s.repository = mocks.NewMockProjectRepository(ctrl)
 	s.projectCategoryRepository = mocks.NewMockProjectCategoryRepository(ctrl)
+	s.updateRepository = mocks.NewMockProjectUpdateRepository(ctrl)
+	s.userRepository = mocks.NewMockUserRepository(ctrl)
+	s.taskDistributor = mocks.NewMockTaskDistributor(ctrl)
 	projectUseCase := usecase.NewProjectUseCase(&usecase.ProjectUseCaseOptions{
-		ProjectRepository: s.repository,
+		ProjectRepository:       s.repository,
+		ProjectUpdateRepository: s.updateRepository,
+		UserRepository:          s.userRepository,
 	})
 	projectCategoryUseCase := usecase.NewProjectCategoryUseCase(&usecase.ProjectCategoryUseCaseOptions{
 		ProjectCategoryRepository: s.projectCategoryRepository,
@@ -52,15 +65,18 @@ func (s *ProjectTestSuite) SetupSuite() {
 	s.handler = NewProjectHandler(&ProjectHandlerOptions{
 		ProjectUseCase:         projectUseCase,
 		ProjectCategoryUseCase: projectCategoryUseCase,
+		TaskDistributor:        s.taskDistributor,
 	})
 }
 
 func (s *ProjectTestSuite) TestListProjectsAPI() {
 	projects := randomProjects(20)
+	user := randomUser(s.T())
 
 	testCases := []struct {
 		name          string
 		query         entity.ProjectListParams
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
 		buildStubs    func(repo *mocks.MockProjectRepository)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
@@ -73,13 +89,15 @@ func (s *ProjectTestSuite) TestListProjectsAPI() {
 				},
 				Query: "title",
 			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {},
 			buildStubs: func(repo *mocks.MockProjectRepository) {
+				listOptions := entity.ProjectListOptions{Query: "title", PublicOnly: true}
 				repo.EXPECT().
-					Count().
+					Count(gomock.Eq(listOptions)).
 					Times(1).
 					Return(int64(len(projects)))
 				repo.EXPECT().
-					FindAll(gomock.Any(), gomock.Any()).
+					FindAll(gomock.Any(), gomock.Eq(listOptions)).
 					Times(1).
 					Return(projects)
 			},
@@ -97,6 +115,79 @@ func (s *ProjectTestSuite) TestListProjectsAPI() {
 				require.Equal(t, 10, response.Result.PerPage)
 			},
 		},
+		{
+			name: "SignedIn",
+			query: entity.ProjectListParams{
+				PaginateOptions: pagination.PaginateOptions{
+					Page: 1,
+					Size: 10,
+				},
+				Status: entity.ProjectDraft.String(),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), time.Minute)
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository) {
+				listOptions := entity.ProjectListOptions{
+					Status:     entity.ProjectDraft,
+					PublicOnly: true,
+					ViewerID:   user.ID,
+				}
+				repo.EXPECT().
+					Count(gomock.Eq(listOptions)).
+					Times(1).
+					Return(int64(0))
+				repo.EXPECT().
+					FindAll(gomock.Any(), gomock.Eq(listOptions)).
+					Times(1).
+					Return([]entity.Project{})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Moderator",
+			query: entity.ProjectListParams{
+				PaginateOptions: pagination.PaginateOptions{
+					Page: 1,
+					Size: 10,
+				},
+				Status: entity.ProjectPendingReview.String(),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), entity.RoleModerator, time.Minute)
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository) {
+				listOptions := entity.ProjectListOptions{Status: entity.ProjectPendingReview}
+				repo.EXPECT().
+					Count(gomock.Eq(listOptions)).
+					Times(1).
+					Return(int64(len(projects)))
+				repo.EXPECT().
+					FindAll(gomock.Any(), gomock.Eq(listOptions)).
+					Times(1).
+					Return(projects)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidStatus",
+			query: entity.ProjectListParams{
+				PaginateOptions: pagination.PaginateOptions{
+					Page: 1,
+					Size: 10,
+				},
+				Status: "unknown",
+			},
+			setupAuth:  func(t *testing.T, request *http.Request, tokenMaker token.Maker) {},
+			buildStubs: func(repo *mocks.MockProjectRepository) {},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name: "Bad Request",
 			query: entity.ProjectListParams{
@@ -104,6 +195,7 @@ func (s *ProjectTestSuite) TestListProjectsAPI() {
 					Page: -1,
 				},
 			},
+			setupAuth:  func(t *testing.T, request *http.Request, tokenMaker token.Maker) {},
 			buildStubs: func(repo *mocks.MockProjectRepository) {},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				var response ErrorResponse
@@ -124,7 +216,7 @@ func (s *ProjectTestSuite) TestListProjectsAPI() {
 			recorder := httptest.NewRecorder()
 			c, r := gin.CreateTestContext(recorder)
 
-			r.GET("/projects", s.handler.ListProjects)
+			r.GET("/projects", middleware.OptionalAuthMiddleware(s.tokenMaker, nil), s.handler.ListProjects)
 
 			url := "/projects"
 			request, err := http.NewRequest(http.MethodGet, url, nil)
@@ -134,6 +226,9 @@ func (s *ProjectTestSuite) TestListProjectsAPI() {
 			q.Add("page", strconv.Itoa(tc.query.Page))
 			q.Add("size", strconv.Itoa(tc.query.Size))
 			q.Add("q", tc.query.Query)
+			q.Add("status", tc.query.Status)
+
+			tc.setupAuth(t, request, s.tokenMaker)
 
 			c.Request = request
 			c.Request.URL.RawQuery = q.Encode()
@@ -146,16 +241,23 @@ func (s *ProjectTestSuite) TestListProjectsAPI() {
 
 func (s *ProjectTestSuite) TestGetProjectAPI() {
 	project := randomProjects(1)[0]
+	project.Raised = decimal.NewFromInt(25)
+	project.BackerCount = 3
+
+	draft := randomProjects(1)[0]
+	draft.Status = entity.ProjectDraft
 
 	testCases := []struct {
 		name          string
 		projectID     string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
 		buildStubs    func(repo *mocks.MockProjectRepository)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
 			name:      "OK",
 			projectID: uuid.NewString(),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {},
 			buildStubs: func(repo *mocks.MockProjectRepository) {
 				repo.EXPECT().
 					FindByID(gomock.Any()).
@@ -172,11 +274,78 @@ func (s *ProjectTestSuite) TestGetProjectAPI() {
 
 				require.NotNil(t, response.Result)
 				require.Equal(t, project.ID.String(), response.Result.ID)
+				require.True(t, project.Goal.Equal(response.Result.Goal))
+				require.True(t, project.Raised.Equal(response.Result.Raised))
+				require.Equal(t, float64(25), response.Result.PercentFunded)
+				require.Equal(t, int64(3), response.Result.BackerCount)
+			},
+		},
+		{
+			name:      "DraftHidden",
+			projectID: draft.ID.String(),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {},
+			buildStubs: func(repo *mocks.MockProjectRepository) {
+				repo.EXPECT().
+					FindByID(gomock.Eq(draft.ID)).
+					Times(1).
+					Return(&draft, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "DraftHiddenFromOtherUser",
+			projectID: draft.ID.String(),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, uuid.NewString(), time.Minute)
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository) {
+				repo.EXPECT().
+					FindByID(gomock.Eq(draft.ID)).
+					Times(1).
+					Return(&draft, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "DraftOwner",
+			projectID: draft.ID.String(),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, draft.OwnerID.String(), time.Minute)
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository) {
+				repo.EXPECT().
+					FindByID(gomock.Eq(draft.ID)).
+					Times(1).
+					Return(&draft, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "DraftModerator",
+			projectID: draft.ID.String(),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, uuid.NewString(), entity.RoleModerator, time.Minute)
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository) {
+				repo.EXPECT().
+					FindByID(gomock.Eq(draft.ID)).
+					Times(1).
+					Return(&draft, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
 		{
 			name:       "Bad Request",
 			projectID:  "invalid-uuid",
+			setupAuth:  func(t *testing.T, request *http.Request, tokenMaker token.Maker) {},
 			buildStubs: func(repo *mocks.MockProjectRepository) {},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				var response ErrorResponse
@@ -191,6 +360,7 @@ func (s *ProjectTestSuite) TestGetProjectAPI() {
 		{
 			name:      "Not Found",
 			projectID: uuid.NewString(),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {},
 			buildStubs: func(repo *mocks.MockProjectRepository) {
 				repo.EXPECT().
 					FindByID(gomock.Any()).
@@ -210,6 +380,7 @@ func (s *ProjectTestSuite) TestGetProjectAPI() {
 		{
 			name:      "Internal Server Error",
 			projectID: uuid.NewString(),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {},
 			buildStubs: func(repo *mocks.MockProjectRepository) {
 				repo.EXPECT().
 					FindByID(gomock.Any()).
@@ -235,10 +406,11 @@ func (s *ProjectTestSuite) TestGetProjectAPI() {
 			recorder := httptest.NewRecorder()
 			c, r := gin.CreateTestContext(recorder)
 
-			r.GET("/projects/:id", s.handler.GetProjectByID)
+			r.GET("/projects/:id", middleware.OptionalAuthMiddleware(s.tokenMaker, nil), s.handler.GetProjectByID)
 
 			url := fmt.Sprintf("/projects/%s", tc.projectID)
 			c.Request = httptest.NewRequest(http.MethodGet, url, nil)
+			tc.setupAuth(t, c.Request, s.tokenMaker)
 
 			r.ServeHTTP(recorder, c.Request)
 			tc.checkResponse(t, recorder)
@@ -313,7 +485,7 @@ func (s *ProjectTestSuite) TestGetOwnProjectsAPI() {
 			recorder := httptest.NewRecorder()
 			c, r := gin.CreateTestContext(recorder)
 
-			r.GET("/projects/me", middleware.AuthMiddleware(s.tokenMaker), s.handler.GetOwnProjects)
+			r.GET("/projects/me", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.GetOwnProjects)
 
 			url := "/projects/me"
 			request, err := http.NewRequest(http.MethodGet, url, nil)
@@ -486,36 +658,1194 @@ func (s *ProjectTestSuite) TestListProjectCategoriesAPI() {
 	}
 }
 
-func randomProjects(n int) []entity.Project {
-	projects := make([]entity.Project, n)
-	for i := 0; i < n; i++ {
-		projectRatings := make([]entity.ProjectRating, n)
-		for j := 0; j < n; j++ {
-			projectRatings[j] = entity.ProjectRating{
-				Rating: random.NewFloat32(1, 5),
-				UserID: uuid.New(),
-			}
-		}
+func (s *ProjectTestSuite) TestSubmitProjectAPI() {
+	user := randomUser(s.T())
 
-		projects[i] = entity.Project{
-			Base: entity.Base{
-				ID: uuid.New(),
+	testCases := []struct {
+		name          string
+		buildProject  func() entity.Project
+		buildStubs    func(repo *mocks.MockProjectRepository, project entity.Project)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildProject: func() entity.Project {
+				project := randomProjects(1)[0]
+				project.OwnerID = user.ID
+				project.Status = entity.ProjectDraft
+				return project
 			},
-			ProjectContractID: strconv.Itoa(random.NewInt(1, 1000)),
-			Title:             fmt.Sprintf("title %d", i+1),
-			SubTitle:          fmt.Sprintf("sub title %d", i+1),
-			CategoryID:        uuid.New(),
-			SubCategoryID:     uuid.New(),
-			Location:          "Bangkok, Thailand",
-			Ratings:           projectRatings,
-			StartDate:         time.Now(),
-			EndDate:           time.Now().AddDate(0, 0, 30),
-			OwnerID:           uuid.New(),
-		}
+			buildStubs: func(repo *mocks.MockProjectRepository, project entity.Project) {
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				repo.EXPECT().
+					UpdateStatus(gomock.Eq(project.ID), gomock.Eq(entity.ProjectDraft), gomock.Eq(entity.ProjectPendingReview)).
+					Times(1).
+					Return(true, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[entity.ProjectDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusOK, response.StatusCode)
+				require.Equal(t, entity.ProjectPendingReview.String(), response.Result.Status)
+			},
+		},
+		{
+			name: "Forbidden",
+			buildProject: func() entity.Project {
+				project := randomProjects(1)[0]
+				project.Status = entity.ProjectDraft
+				return project
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, project entity.Project) {
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ErrorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+		{
+			name: "Conflict",
+			buildProject: func() entity.Project {
+				project := randomProjects(1)[0]
+				project.OwnerID = user.ID
+				project.Status = entity.ProjectSucceeded
+				return project
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, project entity.Project) {
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ErrorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusConflict, response.StatusCode)
+				require.Equal(t, apperrors.ErrInvalidProjectStatusTransition.Error(), response.Error)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			project := tc.buildProject()
+			tc.buildStubs(s.repository, project)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.POST("/projects/:id/submit", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.SubmitProject)
+
+			url := fmt.Sprintf("/projects/%s/submit", project.ID)
+			c.Request = httptest.NewRequest(http.MethodPost, url, nil)
+
+			addAuthorization(t, c.Request, s.tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), time.Minute)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
 	}
-	return projects
 }
 
-func TestProjectSuite(t *testing.T) {
-	suite.Run(t, new(ProjectTestSuite))
+func (s *ProjectTestSuite) TestPublishProjectAPI() {
+	user := randomUser(s.T())
+
+	testCases := []struct {
+		name          string
+		role          entity.UserRole
+		buildProject  func() entity.Project
+		buildStubs    func(repo *mocks.MockProjectRepository, project entity.Project)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: entity.RoleModerator,
+			buildProject: func() entity.Project {
+				project := randomProjects(1)[0]
+				project.Status = entity.ProjectPendingReview
+				return project
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, project entity.Project) {
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				repo.EXPECT().
+					UpdateStatus(gomock.Eq(project.ID), gomock.Eq(entity.ProjectPendingReview), gomock.Eq(entity.ProjectLive)).
+					Times(1).
+					Return(true, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[entity.ProjectDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusOK, response.StatusCode)
+				require.Equal(t, entity.ProjectLive.String(), response.Result.Status)
+			},
+		},
+		{
+			name: "NotModerator",
+			role: entity.RoleCreator,
+			buildProject: func() entity.Project {
+				project := randomProjects(1)[0]
+				project.OwnerID = user.ID
+				project.Status = entity.ProjectPendingReview
+				return project
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, project entity.Project) {
+				repo.EXPECT().
+					FindByID(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotPendingReview",
+			role: entity.RoleAdmin,
+			buildProject: func() entity.Project {
+				project := randomProjects(1)[0]
+				project.Status = entity.ProjectDraft
+				return project
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, project entity.Project) {
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				repo.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ErrorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusConflict, response.StatusCode)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			project := tc.buildProject()
+			tc.buildStubs(s.repository, project)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.POST("/projects/:id/publish",
+				middleware.AuthMiddleware(s.tokenMaker, nil),
+				middleware.RequireRole(entity.RoleModerator, entity.RoleAdmin),
+				s.handler.PublishProject,
+			)
+
+			url := fmt.Sprintf("/projects/%s/publish", project.ID)
+			c.Request = httptest.NewRequest(http.MethodPost, url, nil)
+
+			addRoleAuthorization(t, c.Request, s.tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), tc.role, time.Minute)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *ProjectTestSuite) TestContributeProjectAPI() {
+	user := randomUser(s.T())
+	rewardID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		rewardID      string
+		txHash        string
+		buildProject  func() entity.Project
+		buildStubs    func(repo *mocks.MockProjectRepository, distributor *mocks.MockTaskDistributor, project entity.Project, txHash string)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			txHash: randomTxHash(),
+			buildProject: func() entity.Project {
+				return randomProjects(1)[0]
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, distributor *mocks.MockTaskDistributor, project entity.Project, txHash string) {
+				repo.EXPECT().
+					FindProjectBackerByTxHash(gomock.Eq(txHash)).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				repo.EXPECT().
+					CreateProjectBacker(gomock.Any()).
+					Times(1).
+					DoAndReturn(func(backer *entity.ProjectBacker) (*entity.ProjectBacker, error) {
+						backer.ID = uuid.New()
+						return backer, nil
+					})
+				distributor.EXPECT().
+					DistributeTaskVerifyContribution(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var response ResultResponse[entity.ProjectBackerDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, entity.BackerPending.String(), response.Result.Status)
+			},
+		},
+		{
+			name:   "Replay",
+			txHash: randomTxHash(),
+			buildProject: func() entity.Project {
+				return randomProjects(1)[0]
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, distributor *mocks.MockTaskDistributor, project entity.Project, txHash string) {
+				repo.EXPECT().
+					FindProjectBackerByTxHash(gomock.Eq(txHash)).
+					Times(1).
+					Return(&entity.ProjectBacker{
+						Base:      entity.Base{ID: uuid.New()},
+						ProjectID: project.ID,
+						UserID:    user.ID,
+						Amount:    decimal.NewFromFloat(1.5),
+						TxHash:    &txHash,
+						Status:    entity.BackerConfirmed,
+					}, nil)
+				repo.EXPECT().
+					CreateProjectBacker(gomock.Any()).
+					Times(0)
+				distributor.EXPECT().
+					DistributeTaskVerifyContribution(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var response ResultResponse[entity.ProjectBackerDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, entity.BackerConfirmed.String(), response.Result.Status)
+			},
+		},
+		{
+			name:   "TxHashUsedByAnotherUser",
+			txHash: randomTxHash(),
+			buildProject: func() entity.Project {
+				return randomProjects(1)[0]
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, distributor *mocks.MockTaskDistributor, project entity.Project, txHash string) {
+				repo.EXPECT().
+					FindProjectBackerByTxHash(gomock.Eq(txHash)).
+					Times(1).
+					Return(&entity.ProjectBacker{
+						Base:      entity.Base{ID: uuid.New()},
+						ProjectID: project.ID,
+						UserID:    uuid.New(),
+						TxHash:    &txHash,
+						Status:    entity.BackerPending,
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidTxHash",
+			txHash: "0x1234",
+			buildProject: func() entity.Project {
+				return randomProjects(1)[0]
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, distributor *mocks.MockTaskDistributor, project entity.Project, txHash string) {
+				repo.EXPECT().
+					FindProjectBackerByTxHash(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "NotLive",
+			txHash: randomTxHash(),
+			buildProject: func() entity.Project {
+				project := randomProjects(1)[0]
+				project.Status = entity.ProjectDraft
+				return project
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, distributor *mocks.MockTaskDistributor, project entity.Project, txHash string) {
+				repo.EXPECT().
+					FindProjectBackerByTxHash(gomock.Eq(txHash)).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ErrorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:     "RewardMinimumNotMet",
+			rewardID: rewardID.String(),
+			txHash:   randomTxHash(),
+			buildProject: func() entity.Project {
+				return randomProjects(1)[0]
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, distributor *mocks.MockTaskDistributor, project entity.Project, txHash string) {
+				repo.EXPECT().
+					FindProjectBackerByTxHash(gomock.Eq(txHash)).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				repo.EXPECT().
+					FindRewardByID(gomock.Eq(rewardID)).
+					Times(1).
+					Return(&entity.ProjectReward{
+						Base:          entity.Base{ID: rewardID},
+						ProjectID:     project.ID,
+						MinimumAmount: decimal.NewFromInt(10),
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ErrorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+				require.Contains(t, response.Error, apperrors.ErrRewardMinimumNotMet.Error())
+			},
+		},
+		{
+			name:     "RewardSoldOut",
+			rewardID: rewardID.String(),
+			txHash:   randomTxHash(),
+			buildProject: func() entity.Project {
+				return randomProjects(1)[0]
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, distributor *mocks.MockTaskDistributor, project entity.Project, txHash string) {
+				repo.EXPECT().
+					FindProjectBackerByTxHash(gomock.Eq(txHash)).
+					Times(2).
+					Return(nil, gorm.ErrRecordNotFound)
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				repo.EXPECT().
+					FindRewardByID(gomock.Eq(rewardID)).
+					Times(1).
+					Return(&entity.ProjectReward{
+						Base:          entity.Base{ID: rewardID},
+						ProjectID:     project.ID,
+						MinimumAmount: decimal.NewFromInt(1),
+					}, nil)
+				repo.EXPECT().
+					CreateProjectBacker(gomock.Any()).
+					Times(1).
+					Return(nil, apperrors.ErrRewardSoldOut)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "Ended",
+			txHash: randomTxHash(),
+			buildProject: func() entity.Project {
+				project := randomProjects(1)[0]
+				project.EndDate = time.Now().Add(-time.Hour)
+				return project
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, distributor *mocks.MockTaskDistributor, project entity.Project, txHash string) {
+				repo.EXPECT().
+					FindProjectBackerByTxHash(gomock.Eq(txHash)).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			project := tc.buildProject()
+			tc.buildStubs(s.repository, s.taskDistributor, project, tc.txHash)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.POST("/projects/:id/contribute", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.ContributeProject)
+
+			body, err := json.Marshal(gin.H{"amount": 1.5, "reward_id": tc.rewardID, "tx_hash": tc.txHash})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/projects/%s/contribute", project.ID)
+			c.Request = httptest.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+
+			addAuthorization(t, c.Request, s.tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), time.Minute)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *ProjectTestSuite) TestRequestRefundAPI() {
+	user := randomUser(s.T())
+
+	testCases := []struct {
+		name          string
+		buildProject  func() entity.Project
+		buildStubs    func(repo *mocks.MockProjectRepository, distributor *mocks.MockTaskDistributor, project entity.Project)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildProject: func() entity.Project {
+				project := randomProjects(1)[0]
+				project.Status = entity.ProjectFailed
+				return project
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, distributor *mocks.MockTaskDistributor, project entity.Project) {
+				backers := []entity.ProjectBacker{
+					{Base: entity.Base{ID: uuid.New()}, ProjectID: project.ID, UserID: user.ID, Amount: decimal.NewFromInt(1)},
+					{Base: entity.Base{ID: uuid.New()}, ProjectID: project.ID, UserID: user.ID, Amount: decimal.NewFromInt(2)},
+				}
+
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				repo.EXPECT().
+					FindRefundableBackers(gomock.Eq(project.ID), gomock.Eq(user.ID)).
+					Times(1).
+					Return(backers, nil)
+				repo.EXPECT().
+					RequestRefunds(gomock.Eq(backers)).
+					Times(1).
+					DoAndReturn(func(backers []entity.ProjectBacker) ([]entity.ProjectRefund, error) {
+						refunds := make([]entity.ProjectRefund, 0, len(backers))
+						for _, backer := range backers {
+							refunds = append(refunds, entity.ProjectRefund{
+								Base:      entity.Base{ID: uuid.New()},
+								BackerID:  backer.ID,
+								ProjectID: backer.ProjectID,
+								UserID:    backer.UserID,
+								Amount:    backer.Amount,
+								Status:    entity.RefundRequested,
+							})
+						}
+						return refunds, nil
+					})
+				distributor.EXPECT().
+					DistributeTaskProcessRefund(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(2)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[[]entity.ProjectRefundDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusAccepted, response.StatusCode)
+				require.Len(t, response.Result, 2)
+				require.Equal(t, entity.RefundRequested.String(), response.Result[0].Status)
+			},
+		},
+		{
+			name: "ProjectStillLive",
+			buildProject: func() entity.Project {
+				return randomProjects(1)[0]
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, distributor *mocks.MockTaskDistributor, project entity.Project) {
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				repo.EXPECT().
+					FindRefundableBackers(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ErrorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusConflict, response.StatusCode)
+				require.Equal(t, apperrors.ErrProjectNotRefundable.Error(), response.Error)
+			},
+		},
+		{
+			name: "NothingToRefund",
+			buildProject: func() entity.Project {
+				project := randomProjects(1)[0]
+				project.Status = entity.ProjectCancelled
+				return project
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, distributor *mocks.MockTaskDistributor, project entity.Project) {
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				repo.EXPECT().
+					FindRefundableBackers(gomock.Eq(project.ID), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]entity.ProjectBacker{}, nil)
+				repo.EXPECT().
+					RequestRefunds(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ErrorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			project := tc.buildProject()
+			tc.buildStubs(s.repository, s.taskDistributor, project)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.POST("/projects/:id/refunds", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.RequestRefund)
+
+			url := fmt.Sprintf("/projects/%s/refunds", project.ID)
+			c.Request = httptest.NewRequest(http.MethodPost, url, nil)
+
+			addAuthorization(t, c.Request, s.tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), time.Minute)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *ProjectTestSuite) TestInitiateRefundsAPI() {
+	user := randomUser(s.T())
+
+	testCases := []struct {
+		name          string
+		role          entity.UserRole
+		buildProject  func() entity.Project
+		buildStubs    func(repo *mocks.MockProjectRepository, distributor *mocks.MockTaskDistributor, project entity.Project)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: entity.RoleUser,
+			buildProject: func() entity.Project {
+				project := randomProjects(1)[0]
+				project.OwnerID = user.ID
+				project.Status = entity.ProjectFailed
+				return project
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, distributor *mocks.MockTaskDistributor, project entity.Project) {
+				backers := []entity.ProjectBacker{
+					{Base: entity.Base{ID: uuid.New()}, ProjectID: project.ID, UserID: uuid.New(), Amount: decimal.NewFromInt(1)},
+				}
+
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				repo.EXPECT().
+					FindRefundableBackers(gomock.Eq(project.ID), gomock.Eq(uuid.Nil)).
+					Times(1).
+					Return(backers, nil)
+				repo.EXPECT().
+					RequestRefunds(gomock.Eq(backers)).
+					Times(1).
+					Return([]entity.ProjectRefund{{Base: entity.Base{ID: uuid.New()}, BackerID: backers[0].ID, Status: entity.RefundRequested}}, nil)
+				distributor.EXPECT().
+					DistributeTaskProcessRefund(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[[]entity.ProjectRefundDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusAccepted, response.StatusCode)
+				require.Len(t, response.Result, 1)
+			},
+		},
+		{
+			name: "Admin",
+			role: entity.RoleAdmin,
+			buildProject: func() entity.Project {
+				project := randomProjects(1)[0]
+				project.Status = entity.ProjectCancelled
+				return project
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, distributor *mocks.MockTaskDistributor, project entity.Project) {
+				backers := []entity.ProjectBacker{
+					{Base: entity.Base{ID: uuid.New()}, ProjectID: project.ID, UserID: uuid.New(), Amount: decimal.NewFromInt(1)},
+					{Base: entity.Base{ID: uuid.New()}, ProjectID: project.ID, UserID: uuid.New(), Amount: decimal.NewFromInt(2)},
+				}
+
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				repo.EXPECT().
+					FindRefundableBackers(gomock.Eq(project.ID), gomock.Eq(uuid.Nil)).
+					Times(1).
+					Return(backers, nil)
+				repo.EXPECT().
+					RequestRefunds(gomock.Eq(backers)).
+					Times(1).
+					Return([]entity.ProjectRefund{
+						{Base: entity.Base{ID: uuid.New()}, BackerID: backers[0].ID, Status: entity.RefundRequested},
+						{Base: entity.Base{ID: uuid.New()}, BackerID: backers[1].ID, Status: entity.RefundRequested},
+					}, nil)
+				distributor.EXPECT().
+					DistributeTaskProcessRefund(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(2)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[[]entity.ProjectRefundDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusAccepted, response.StatusCode)
+				require.Len(t, response.Result, 2)
+			},
+		},
+		{
+			name: "Forbidden",
+			role: entity.RoleModerator,
+			buildProject: func() entity.Project {
+				project := randomProjects(1)[0]
+				project.Status = entity.ProjectFailed
+				return project
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, distributor *mocks.MockTaskDistributor, project entity.Project) {
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				repo.EXPECT().
+					FindRefundableBackers(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ErrorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			project := tc.buildProject()
+			tc.buildStubs(s.repository, s.taskDistributor, project)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.POST("/projects/:id/refunds/initiate", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.InitiateRefunds)
+
+			url := fmt.Sprintf("/projects/%s/refunds/initiate", project.ID)
+			c.Request = httptest.NewRequest(http.MethodPost, url, nil)
+
+			addRoleAuthorization(t, c.Request, s.tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), tc.role, time.Minute)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *ProjectTestSuite) TestUpdateProjectRewardAPI() {
+	user := randomUser(s.T())
+	project := randomProjects(1)[0]
+	project.OwnerID = user.ID
+	limit := int64(50)
+	reward := entity.ProjectReward{
+		Base:              entity.Base{ID: uuid.New()},
+		ProjectID:         project.ID,
+		Title:             "Early bird",
+		MinimumAmount:     decimal.NewFromInt(10),
+		EstimatedDelivery: time.Now().AddDate(0, 3, 0),
+		QuantityLimit:     &limit,
+		QuantityClaimed:   20,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(repo *mocks.MockProjectRepository)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"title": "Super early bird", "quantity_limit": 30},
+			buildStubs: func(repo *mocks.MockProjectRepository) {
+				found := reward
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				repo.EXPECT().
+					FindRewardByID(gomock.Eq(reward.ID)).
+					Times(1).
+					Return(&found, nil)
+				repo.EXPECT().
+					UpdateReward(gomock.Any()).
+					Times(1).
+					DoAndReturn(func(updated *entity.ProjectReward) (*entity.ProjectReward, error) {
+						require.Equal(s.T(), "Super early bird", updated.Title)
+						require.Equal(s.T(), int64(30), *updated.QuantityLimit)
+						return updated, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[entity.ProjectRewardDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusOK, response.StatusCode)
+				require.Equal(t, int64(10), *response.Result.Remaining)
+			},
+		},
+		{
+			name: "ClearQuantityLimit",
+			body: gin.H{"clear_quantity_limit": true},
+			buildStubs: func(repo *mocks.MockProjectRepository) {
+				found := reward
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				repo.EXPECT().
+					FindRewardByID(gomock.Eq(reward.ID)).
+					Times(1).
+					Return(&found, nil)
+				repo.EXPECT().
+					UpdateReward(gomock.Any()).
+					Times(1).
+					DoAndReturn(func(updated *entity.ProjectReward) (*entity.ProjectReward, error) {
+						require.Nil(s.T(), updated.QuantityLimit)
+						require.Equal(s.T(), reward.Title, updated.Title)
+						return updated, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[entity.ProjectRewardDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusOK, response.StatusCode)
+				require.Nil(t, response.Result.QuantityLimit)
+				require.Nil(t, response.Result.Remaining)
+			},
+		},
+		{
+			name: "LimitAndClearQuantityLimit",
+			body: gin.H{"quantity_limit": 30, "clear_quantity_limit": true},
+			buildStubs: func(repo *mocks.MockProjectRepository) {
+				repo.EXPECT().
+					FindByID(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "LimitBelowClaimed",
+			body: gin.H{"quantity_limit": 10},
+			buildStubs: func(repo *mocks.MockProjectRepository) {
+				found := reward
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				repo.EXPECT().
+					FindRewardByID(gomock.Eq(reward.ID)).
+					Times(1).
+					Return(&found, nil)
+				repo.EXPECT().
+					UpdateReward(gomock.Any()).
+					Times(1).
+					Return(nil, apperrors.ErrRewardLimitBelowClaimed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.buildStubs(s.repository)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.PATCH("/projects/:id/rewards/:rewardId", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.UpdateProjectReward)
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/projects/%s/rewards/%s", project.ID, reward.ID)
+			c.Request = httptest.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+
+			addAuthorization(t, c.Request, s.tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), time.Minute)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *ProjectTestSuite) TestCreateProjectUpdateAPI() {
+	user := randomUser(s.T())
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildProject  func() entity.Project
+		buildStubs    func(repo *mocks.MockProjectRepository, updateRepo *mocks.MockProjectUpdateRepository, distributor *mocks.MockTaskDistributor, project entity.Project)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"title": "Prototype ready", "body": "<p>We finished the prototype.</p>", "visibility": "backers_only"},
+			buildProject: func() entity.Project {
+				project := randomProjects(1)[0]
+				project.OwnerID = user.ID
+				return project
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, updateRepo *mocks.MockProjectUpdateRepository, distributor *mocks.MockTaskDistributor, project entity.Project) {
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				updateRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(1).
+					DoAndReturn(func(update *entity.ProjectUpdate) (*entity.ProjectUpdate, error) {
+						require.Equal(s.T(), entity.UpdateVisibilityBackersOnly, update.Visibility)
+						require.Equal(s.T(), user.ID, update.AuthorID)
+						update.ID = uuid.New()
+						return update, nil
+					})
+				repo.EXPECT().
+					FindBackerEmails(gomock.Eq(project.ID)).
+					Times(1).
+					Return([]string{"backer1@example.com", "backer2@example.com"}, nil)
+				distributor.EXPECT().
+					DistributeTaskSendProjectUpdateEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(2)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[entity.ProjectUpdateDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusCreated, response.StatusCode)
+				require.Equal(t, "backers_only", response.Result.Visibility)
+			},
+		},
+		{
+			name: "BackerEmailsError",
+			body: gin.H{"title": "Prototype ready", "body": "<p>We finished the prototype.</p>"},
+			buildProject: func() entity.Project {
+				project := randomProjects(1)[0]
+				project.OwnerID = user.ID
+				return project
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, updateRepo *mocks.MockProjectUpdateRepository, distributor *mocks.MockTaskDistributor, project entity.Project) {
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				updateRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(1).
+					DoAndReturn(func(update *entity.ProjectUpdate) (*entity.ProjectUpdate, error) {
+						update.ID = uuid.New()
+						return update, nil
+					})
+				repo.EXPECT().
+					FindBackerEmails(gomock.Eq(project.ID)).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+				distributor.EXPECT().
+					DistributeTaskSendProjectUpdateEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden",
+			body: gin.H{"title": "Prototype ready", "body": "<p>We finished the prototype.</p>"},
+			buildProject: func() entity.Project {
+				return randomProjects(1)[0]
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, updateRepo *mocks.MockProjectUpdateRepository, distributor *mocks.MockTaskDistributor, project entity.Project) {
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				updateRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(0)
+				distributor.EXPECT().
+					DistributeTaskSendProjectUpdateEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ErrorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+		{
+			name: "BadRequest",
+			body: gin.H{"title": "Prototype ready", "body": "<p>We finished the prototype.</p>", "visibility": "friends"},
+			buildProject: func() entity.Project {
+				return randomProjects(1)[0]
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, updateRepo *mocks.MockProjectUpdateRepository, distributor *mocks.MockTaskDistributor, project entity.Project) {
+				repo.EXPECT().
+					FindByID(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ErrorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			project := tc.buildProject()
+			tc.buildStubs(s.repository, s.updateRepository, s.taskDistributor, project)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.POST("/projects/:id/updates", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.CreateProjectUpdate)
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/projects/%s/updates", project.ID)
+			c.Request = httptest.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+
+			addAuthorization(t, c.Request, s.tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), time.Minute)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *ProjectTestSuite) TestGetProjectUpdateAPI() {
+	user := randomUser(s.T())
+	project := randomProjects(1)[0]
+	update := entity.ProjectUpdate{
+		Base:       entity.Base{ID: uuid.New()},
+		ProjectID:  project.ID,
+		AuthorID:   project.OwnerID,
+		Title:      "Shipping soon",
+		Body:       "<p>Rewards ship next week.</p>",
+		Visibility: entity.UpdateVisibilityBackersOnly,
+	}
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request)
+		buildStubs    func(repo *mocks.MockProjectRepository, updateRepo *mocks.MockProjectUpdateRepository)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Backer",
+			setupAuth: func(t *testing.T, request *http.Request) {
+				addAuthorization(t, request, s.tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), time.Minute)
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, updateRepo *mocks.MockProjectUpdateRepository) {
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				updateRepo.EXPECT().
+					FindByID(gomock.Eq(update.ID)).
+					Times(1).
+					Return(&update, nil)
+				repo.EXPECT().
+					IsProjectBacker(gomock.Eq(project.ID), gomock.Eq(user.ID)).
+					Times(1).
+					Return(true, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[entity.ProjectUpdateDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusOK, response.StatusCode)
+				require.Equal(t, update.ID.String(), response.Result.ID)
+			},
+		},
+		{
+			name: "NotBacker",
+			setupAuth: func(t *testing.T, request *http.Request) {
+				addAuthorization(t, request, s.tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), time.Minute)
+			},
+			buildStubs: func(repo *mocks.MockProjectRepository, updateRepo *mocks.MockProjectUpdateRepository) {
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				updateRepo.EXPECT().
+					FindByID(gomock.Eq(update.ID)).
+					Times(1).
+					Return(&update, nil)
+				repo.EXPECT().
+					IsProjectBacker(gomock.Eq(project.ID), gomock.Eq(user.ID)).
+					Times(1).
+					Return(false, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ErrorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+		{
+			name:      "Anonymous",
+			setupAuth: func(t *testing.T, request *http.Request) {},
+			buildStubs: func(repo *mocks.MockProjectRepository, updateRepo *mocks.MockProjectUpdateRepository) {
+				repo.EXPECT().
+					FindByID(gomock.Eq(project.ID)).
+					Times(1).
+					Return(&project, nil)
+				updateRepo.EXPECT().
+					FindByID(gomock.Eq(update.ID)).
+					Times(1).
+					Return(&update, nil)
+				repo.EXPECT().
+					IsProjectBacker(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ErrorResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.buildStubs(s.repository, s.updateRepository)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.GET("/projects/:id/updates/:updateId", middleware.OptionalAuthMiddleware(s.tokenMaker, nil), s.handler.GetProjectUpdate)
+
+			url := fmt.Sprintf("/projects/%s/updates/%s", project.ID, update.ID)
+			c.Request = httptest.NewRequest(http.MethodGet, url, nil)
+
+			tc.setupAuth(t, c.Request)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func randomProjects(n int) []entity.Project {
+	projects := make([]entity.Project, n)
+	for i := 0; i < n; i++ {
+		projectRatings := make([]entity.ProjectRating, n)
+		for j := 0; j < n; j++ {
+			projectRatings[j] = entity.ProjectRating{
+				Rating: random.NewFloat32(1, 5),
+				UserID: uuid.New(),
+			}
+		}
+
+		projects[i] = entity.Project{
+			Base: entity.Base{
+				ID: uuid.New(),
+			},
+			ProjectContractID: strconv.Itoa(random.NewInt(1, 1000)),
+			Title:             fmt.Sprintf("title %d", i+1),
+			SubTitle:          fmt.Sprintf("sub title %d", i+1),
+			CategoryID:        uuid.New(),
+			SubCategoryID:     uuid.New(),
+			Location:          "Bangkok, Thailand",
+			Ratings:           projectRatings,
+			StartDate:         time.Now(),
+			EndDate:           time.Now().AddDate(0, 0, 30),
+			Status:            entity.ProjectLive,
+			Goal:              decimal.NewFromInt(100),
+			Currency:          entity.DefaultProjectCurrency,
+			OwnerID:           uuid.New(),
+		}
+	}
+	return projects
+}
+
+func TestProjectSuite(t *testing.T) {
+	suite.Run(t, new(ProjectTestSuite))
+}
+
+func randomTxHash() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return "0x" + hex.EncodeToString(b)
 }
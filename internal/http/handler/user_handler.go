@@ -2,27 +2,54 @@ package handler
 
 import (
 	"errors"
+	"fmt"
+	"fund-o/api-server/cmd/worker"
 	"fund-o/api-server/internal/entity"
 	"fund-o/api-server/internal/http/middleware"
 	"fund-o/api-server/internal/usecase"
 	"fund-o/api-server/pkg/apperrors"
+	"fund-o/api-server/pkg/chain"
+	"fund-o/api-server/pkg/siwe"
 	"fund-o/api-server/pkg/token"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
 )
 
 type UserHandlerOptions struct {
 	usecase.UserUseCase
+	usecase.WalletAuthUseCase
+	usecase.SessionUseCase
+	usecase.MfaUseCase
+	usecase.ApiKeyUseCase
+	usecase.EmailChangeUseCase
+	usecase.AccountUseCase
+	worker.TaskDistributor
 }
 
 type UserHandler struct {
-	userUseCase usecase.UserUseCase
+	userUseCase        usecase.UserUseCase
+	walletAuthUseCase  usecase.WalletAuthUseCase
+	sessionUseCase     usecase.SessionUseCase
+	mfaUseCase         usecase.MfaUseCase
+	apiKeyUseCase      usecase.ApiKeyUseCase
+	emailChangeUseCase usecase.EmailChangeUseCase
+	accountUseCase     usecase.AccountUseCase
+	taskDistributor    worker.TaskDistributor
 }
 
 func NewUserHandler(options *UserHandlerOptions) *UserHandler {
 	return &UserHandler{
-		userUseCase: options.UserUseCase,
+		userUseCase:        options.UserUseCase,
+		walletAuthUseCase:  options.WalletAuthUseCase,
+		sessionUseCase:     options.SessionUseCase,
+		mfaUseCase:         options.MfaUseCase,
+		apiKeyUseCase:      options.ApiKeyUseCase,
+		emailChangeUseCase: options.EmailChangeUseCase,
+		accountUseCase:     options.AccountUseCase,
+		taskDistributor:    options.TaskDistributor,
 	}
 }
 
@@ -97,3 +124,601 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 
 	c.JSON(makeHttpResponse(http.StatusOK, user))
 }
+
+// UpdateUserRole godoc
+// @summary Update user role
+// @description Change the role of another user (admin). The new role applies from the user's next token renewal
+// @tags users
+// @id UpdateUserRole
+// @accept json
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "User ID"
+// @param Role body entity.UserRoleUpdatePayload true "New role"
+// @response 200 {object} handler.ResultResponse[entity.UserDto] "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 403 {object} handler.ErrorResponse "Forbidden"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /users/{id}/role [patch]
+func (h *UserHandler) UpdateUserRole(c *gin.Context) {
+	id := c.Param("id")
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	// Keeps admins from locking themselves out; another admin has to do it.
+	if id == userID {
+		err := errors.New("forbidden: admin cannot change their own role")
+		c.JSON(makeHttpErrorResponse(http.StatusForbidden, err.Error()))
+		return
+	}
+
+	var payload entity.UserRoleUpdatePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	role, ok := entity.ParseUserRole(payload.Role)
+	if !ok {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, "invalid role"))
+		return
+	}
+
+	user, err := h.userUseCase.UpdateUserRole(id, role)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrInvalidUserID):
+			c.JSON(makeHttpErrorResponse(http.StatusBadRequest, err.Error()))
+		case errors.Is(err, apperrors.ErrUserNotFound):
+			c.JSON(makeHttpErrorResponse(http.StatusNotFound, err.Error()))
+		default:
+			c.JSON(makeHttpErrorResponse(http.StatusInternalServerError, err.Error()))
+		}
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusOK, user))
+}
+
+// ListSessions godoc
+// @summary List sessions
+// @description List the active sessions of the current user
+// @tags users
+// @id ListSessions
+// @produce json
+// @security ApiKeyAuth
+// @response 200 {object} handler.ResultResponse[[]entity.UserSessionDto] "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /users/me/sessions [get]
+func (h *UserHandler) ListSessions(c *gin.Context) {
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	sessions, err := h.sessionUseCase.ListActiveSessions(userID)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(sessionErrorStatus(err), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusOK, sessions))
+}
+
+// RevokeSession godoc
+// @summary Revoke session
+// @description Block a session of the current user, signing out that device
+// @tags users
+// @id RevokeSession
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "Session ID"
+// @response 200 {object} handler.MessageResponse "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /users/me/sessions/{id} [delete]
+func (h *UserHandler) RevokeSession(c *gin.Context) {
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	if err := h.sessionUseCase.RevokeSession(userID, c.Param("id")); err != nil {
+		c.JSON(makeHttpErrorResponse(sessionErrorStatus(err), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpMessageResponse(http.StatusOK, "session revoked successfully"))
+}
+
+// ListApiKeys godoc
+// @summary List API keys
+// @description List the API keys of the current user. The keys themselves are never returned again after creation
+// @tags users
+// @id ListApiKeys
+// @produce json
+// @security ApiKeyAuth
+// @response 200 {object} handler.ResultResponse[[]entity.ApiKeyDto] "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 403 {object} handler.ErrorResponse "Forbidden"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /users/me/api-keys [get]
+func (h *UserHandler) ListApiKeys(c *gin.Context) {
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	apiKeys, err := h.apiKeyUseCase.ListApiKeys(userID)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(apiKeyErrorStatus(err), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusOK, apiKeys))
+}
+
+// CreateApiKey godoc
+// @summary Create API key
+// @description Create a named API key for scripts. Send it in the X-Api-Key header or as "Authorization: ApiKey <key>". Keys with the read scope may only make GET requests, write is needed for anything else. The key is only returned in this response
+// @tags users
+// @id CreateApiKey
+// @accept json
+// @produce json
+// @security ApiKeyAuth
+// @param ApiKey body entity.ApiKeyCreatePayload true "Name, scopes and optional expiry of the key"
+// @response 201 {object} handler.ResultResponse[entity.ApiKeyCreateResponse] "Created"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 403 {object} handler.ErrorResponse "Forbidden"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /users/me/api-keys [post]
+func (h *UserHandler) CreateApiKey(c *gin.Context) {
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	var req entity.ApiKeyCreatePayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	apiKey, err := h.apiKeyUseCase.CreateApiKey(userID, &req)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(apiKeyErrorStatus(err), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusCreated, apiKey))
+}
+
+// DeleteApiKey godoc
+// @summary Delete API key
+// @description Delete an API key of the current user, so it stops working immediately
+// @tags users
+// @id DeleteApiKey
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "API key ID"
+// @response 200 {object} handler.MessageResponse "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 403 {object} handler.ErrorResponse "Forbidden"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /users/me/api-keys/{id} [delete]
+func (h *UserHandler) DeleteApiKey(c *gin.Context) {
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	if err := h.apiKeyUseCase.DeleteApiKey(userID, c.Param("id")); err != nil {
+		c.JSON(makeHttpErrorResponse(apiKeyErrorStatus(err), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpMessageResponse(http.StatusOK, "api key deleted successfully"))
+}
+
+func apiKeyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, apperrors.ErrInvalidUserID),
+		errors.Is(err, apperrors.ErrInvalidApiKeyID),
+		errors.Is(err, apperrors.ErrInvalidApiKeyExpiry):
+		return http.StatusBadRequest
+	case errors.Is(err, apperrors.ErrApiKeyNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ChangeEmail godoc
+// @summary Change email
+// @description Send a confirmation link to the new email. The email of the account only changes once the link is followed, and the current email is notified. Accounts with a password have to enter it
+// @tags users
+// @id ChangeEmail
+// @accept json
+// @produce json
+// @security ApiKeyAuth
+// @param Email body entity.EmailChangePayload true "New email and current password"
+// @response 202 {object} handler.MessageResponse "Accepted"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /users/me/email [post]
+func (h *UserHandler) ChangeEmail(c *gin.Context) {
+	var req entity.EmailChangePayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	payload := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	if err := h.emailChangeUseCase.RequestEmailChange(payload.UserID, &req); err != nil {
+		c.JSON(makeHttpErrorResponse(emailChangeErrorStatus(err), err.Error()))
+		return
+	}
+
+	// Whether the new email is free is checked by the worker, so the response does not tell
+	// whether another account uses it.
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueCritical),
+	}
+
+	h.taskDistributor.DistributeTaskSendEmailChangeEmail(c, &worker.PayloadSendEmailChangeEmail{
+		UserID:   payload.UserID,
+		NewEmail: req.Email,
+	}, opts...)
+
+	c.JSON(makeHttpMessageResponse(http.StatusAccepted, "a confirmation link has been sent to the new email"))
+}
+
+func emailChangeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, apperrors.ErrInvalidUserID),
+		errors.Is(err, apperrors.ErrSameEmail),
+		errors.Is(err, apperrors.ErrInvalidEmailChange),
+		errors.Is(err, apperrors.ErrEmailChangeExpired),
+		errors.Is(err, apperrors.ErrEmailAlreadyUsed):
+		return http.StatusBadRequest
+	case errors.Is(err, apperrors.ErrIncorrectPassword):
+		return http.StatusUnauthorized
+	case errors.Is(err, apperrors.ErrUserNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ExportData godoc
+// @summary Export personal data
+// @description Generate an archive of the personal data of the current user. A link to download it is sent by email once it is ready and expires after 24 hours, and accounts without an email address get it from the export status instead. While an export is being generated, that export is returned instead of starting another one
+// @tags users
+// @id ExportData
+// @produce json
+// @security ApiKeyAuth
+// @response 202 {object} handler.ResultResponse[entity.DataExportDto] "Accepted"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /users/me/export [get]
+func (h *UserHandler) ExportData(c *gin.Context) {
+	payload := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	export, created, err := h.accountUseCase.RequestDataExport(payload.UserID)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(accountErrorStatus(err), err.Error()))
+		return
+	}
+
+	if created {
+		opts := []asynq.Option{
+			asynq.MaxRetry(5),
+			asynq.Queue(worker.QueueDefault),
+		}
+
+		h.taskDistributor.DistributeTaskGenerateDataExport(c, &worker.PayloadGenerateDataExport{
+			ExportID: export.ID,
+		}, opts...)
+	}
+
+	c.JSON(makeHttpResponse(http.StatusAccepted, export))
+}
+
+// GetDataExport godoc
+// @summary Get data export
+// @description Get the status of a data export of the current user. Accounts without an email address, which cannot be sent the link, get a download link once the export is ready; each call replaces the link
+// @tags users
+// @id GetDataExport
+// @produce json
+// @security ApiKeyAuth
+// @param id path string true "Data export ID"
+// @response 200 {object} handler.ResultResponse[entity.DataExportDto] "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /users/me/exports/{id} [get]
+func (h *UserHandler) GetDataExport(c *gin.Context) {
+	payload := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	export, err := h.accountUseCase.GetDataExport(payload.UserID, c.Param("id"))
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(accountErrorStatus(err), err.Error()))
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(makeHttpResponse(http.StatusOK, export))
+}
+
+// DownloadDataExport godoc
+// @summary Download personal data
+// @description Download the archive of a data export with the link sent by email
+// @tags users
+// @id DownloadDataExport
+// @produce json
+// @param id path string true "Data export ID"
+// @param code query string true "Secret code of the download link"
+// @response 200 {object} entity.UserDataArchive "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /exports/{id} [get]
+func (h *UserHandler) DownloadDataExport(c *gin.Context) {
+	archive, err := h.accountUseCase.DownloadDataExport(c.Param("id"), c.Query("code"))
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(accountErrorStatus(err), err.Error()))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"fundo-export-%s.json\"", c.Param("id")))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/json", archive)
+}
+
+// DeleteMe godoc
+// @summary Delete account
+// @description Delete the account of the current user. The profile is anonymised and every way to sign in is removed, while contributions and refunds are kept for accounting. Accounts with a password have to enter it, and accounts owning a project in review or live cannot be deleted
+// @tags users
+// @id DeleteMe
+// @accept json
+// @produce json
+// @security ApiKeyAuth
+// @param Account body entity.AccountDeletePayload false "Current password"
+// @response 200 {object} handler.MessageResponse "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 409 {object} handler.ErrorResponse "Conflict"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /users/me [delete]
+func (h *UserHandler) DeleteMe(c *gin.Context) {
+	var req entity.AccountDeletePayload
+	// The body is optional since accounts without a password have nothing to confirm.
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	payload := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload)
+	if err := h.accountUseCase.DeleteAccount(payload.UserID, &req); err != nil {
+		c.JSON(makeHttpErrorResponse(accountErrorStatus(err), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpMessageResponse(http.StatusOK, "account deleted successfully"))
+}
+
+func accountErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, apperrors.ErrInvalidUserID),
+		errors.Is(err, apperrors.ErrInvalidDataExport),
+		errors.Is(err, apperrors.ErrDataExportExpired):
+		return http.StatusBadRequest
+	case errors.Is(err, apperrors.ErrIncorrectPassword):
+		return http.StatusUnauthorized
+	case errors.Is(err, apperrors.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, apperrors.ErrActiveProjects):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// EnrollTOTP godoc
+// @summary Enroll authenticator
+// @description Create the secret of an authenticator app for two-factor authentication. It is turned on once confirmed with a first code
+// @tags users
+// @id EnrollTOTP
+// @produce json
+// @security ApiKeyAuth
+// @response 200 {object} handler.ResultResponse[entity.TOTPEnrollmentResponse] "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 409 {object} handler.ErrorResponse "Conflict"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /users/me/mfa/totp [post]
+func (h *UserHandler) EnrollTOTP(c *gin.Context) {
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	enrollment, err := h.mfaUseCase.EnrollTOTP(userID)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(mfaErrorStatus(err), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusOK, enrollment))
+}
+
+// ConfirmTOTP godoc
+// @summary Confirm authenticator
+// @description Turn on two-factor authentication with a first code of the enrolled authenticator. The recovery codes are only returned here
+// @tags users
+// @id ConfirmTOTP
+// @accept json
+// @produce json
+// @security ApiKeyAuth
+// @param Code body entity.TOTPCodePayload true "Authenticator code"
+// @response 200 {object} handler.ResultResponse[entity.RecoveryCodesResponse] "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 409 {object} handler.ErrorResponse "Conflict"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /users/me/mfa/totp/confirm [post]
+func (h *UserHandler) ConfirmTOTP(c *gin.Context) {
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	var req entity.TOTPCodePayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	recoveryCodes, err := h.mfaUseCase.ConfirmTOTP(userID, req.Code)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(mfaErrorStatus(err), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusOK, recoveryCodes))
+}
+
+// DisableTOTP godoc
+// @summary Disable authenticator
+// @description Turn off two-factor authentication with an authenticator code or a recovery code
+// @tags users
+// @id DisableTOTP
+// @accept json
+// @produce json
+// @security ApiKeyAuth
+// @param Code body entity.TOTPCodePayload true "Authenticator code or recovery code"
+// @response 200 {object} handler.MessageResponse "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /users/me/mfa/totp/disable [post]
+func (h *UserHandler) DisableTOTP(c *gin.Context) {
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	var req entity.TOTPCodePayload
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	if err := h.mfaUseCase.DisableTOTP(userID, req.Code); err != nil {
+		c.JSON(makeHttpErrorResponse(mfaErrorStatus(err), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpMessageResponse(http.StatusOK, "two-factor authentication disabled successfully"))
+}
+
+// CreateWalletChallenge godoc
+// @summary Create wallet link challenge
+// @description Create a Sign-In with Ethereum message the user has to sign to link the wallet
+// @tags users
+// @id CreateWalletChallenge
+// @accept json
+// @produce json
+// @security ApiKeyAuth
+// @param Wallet body entity.UserWalletChallengePayload true "Wallet address"
+// @response 200 {object} handler.ResultResponse[entity.UserWalletChallengeResponse] "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /users/me/wallets/challenge [post]
+func (h *UserHandler) CreateWalletChallenge(c *gin.Context) {
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	var payload entity.UserWalletChallengePayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	challenge, err := h.walletAuthUseCase.CreateLinkChallenge(c, userID, &payload)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(walletLinkErrorStatus(err), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusOK, challenge))
+}
+
+// LinkWallet godoc
+// @summary Link wallet
+// @description Link a wallet to the current user with the signed challenge message
+// @tags users
+// @id LinkWallet
+// @accept json
+// @produce json
+// @security ApiKeyAuth
+// @param Wallet body entity.UserWalletLinkPayload true "Signed challenge"
+// @response 201 {object} handler.ResultResponse[entity.UserDto] "Created"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 409 {object} handler.ErrorResponse "Conflict"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /users/me/wallets [post]
+func (h *UserHandler) LinkWallet(c *gin.Context) {
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	var payload entity.UserWalletLinkPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(makeHttpErrorResponse(http.StatusBadRequest, err.Error()))
+		return
+	}
+
+	user, err := h.walletAuthUseCase.LinkWallet(c, userID, &payload)
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(walletLinkErrorStatus(err), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusCreated, user))
+}
+
+// UnlinkWallet godoc
+// @summary Unlink wallet
+// @description Unlink a wallet from the current user
+// @tags users
+// @id UnlinkWallet
+// @produce json
+// @security ApiKeyAuth
+// @param address path string true "Wallet address"
+// @response 200 {object} handler.ResultResponse[entity.UserDto] "OK"
+// @response 400 {object} handler.ErrorResponse "Bad Request"
+// @response 401 {object} handler.ErrorResponse "Unauthorized"
+// @response 404 {object} handler.ErrorResponse "Not Found"
+// @response 409 {object} handler.ErrorResponse "Conflict"
+// @response 500 {object} handler.ErrorResponse "Internal Server Error"
+// @router /users/me/wallets/{address} [delete]
+func (h *UserHandler) UnlinkWallet(c *gin.Context) {
+	userID := c.MustGet(middleware.AuthorizationPayloadKey).(*token.Payload).UserID
+
+	user, err := h.walletAuthUseCase.UnlinkWallet(userID, c.Param("address"))
+	if err != nil {
+		c.JSON(makeHttpErrorResponse(walletLinkErrorStatus(err), err.Error()))
+		return
+	}
+
+	c.JSON(makeHttpResponse(http.StatusOK, user))
+}
+
+func walletLinkErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, apperrors.ErrInvalidUserID),
+		errors.Is(err, chain.ErrInvalidAddress),
+		errors.Is(err, chain.ErrInvalidSignature),
+		errors.Is(err, siwe.ErrInvalidMessage),
+		errors.Is(err, siwe.ErrDomainMismatch),
+		errors.Is(err, siwe.ErrChainIDMismatch),
+		errors.Is(err, siwe.ErrMessageExpired),
+		errors.Is(err, siwe.ErrMessageNotYetValid),
+		errors.Is(err, apperrors.ErrWalletSignatureMismatch),
+		errors.Is(err, apperrors.ErrInvalidNonce):
+		return http.StatusBadRequest
+	case errors.Is(err, apperrors.ErrUserNotFound),
+		errors.Is(err, apperrors.ErrWalletNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, apperrors.ErrWalletAlreadyLinked),
+		errors.Is(err, apperrors.ErrLastSignInMethod):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
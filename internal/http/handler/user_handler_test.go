@@ -2,23 +2,33 @@ package handler
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"fund-o/api-server/cmd/worker"
 	"fund-o/api-server/internal/entity"
 	"fund-o/api-server/internal/http/middleware"
 	"fund-o/api-server/internal/usecase"
 	"fund-o/api-server/mocks"
+	"fund-o/api-server/pkg/random"
+	"fund-o/api-server/pkg/siwe"
 	"fund-o/api-server/pkg/token"
+	"fund-o/api-server/pkg/totp"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
@@ -26,9 +36,17 @@ import (
 
 type UserTestSuite struct {
 	suite.Suite
-	tokenMaker     token.Maker
-	userRepository *mocks.MockUserRepository
-	handler        *UserHandler
+	tokenMaker        token.Maker
+	userRepository    *mocks.MockUserRepository
+	nonceRepository   *mocks.MockNonceRepository
+	sessionRepository *mocks.MockSessionRepository
+	mfaRepository     *mocks.MockMfaRepository
+	apiKeyRepository  *mocks.MockApiKeyRepository
+	apiKeyUseCase     usecase.ApiKeyUseCase
+	taskDistributor   *mocks.MockTaskDistributor
+	dataExportRepo    *mocks.MockDataExportRepository
+	projectRepository *mocks.MockProjectRepository
+	handler           *UserHandler
 }
 
 func (s *UserTestSuite) SetupSuite() {
@@ -42,11 +60,50 @@ func (s *UserTestSuite) SetupSuite() {
 	defer ctrl.Finish()
 
 	s.userRepository = mocks.NewMockUserRepository(ctrl)
+	s.nonceRepository = mocks.NewMockNonceRepository(ctrl)
+	s.sessionRepository = mocks.NewMockSessionRepository(ctrl)
 	useUseCase := usecase.NewUserUseCase(&usecase.UserUseCaseOptions{
 		UserRepository: s.userRepository,
 	})
+	walletAuthUseCase := usecase.NewWalletAuthUseCase(&usecase.WalletAuthUseCaseOptions{
+		UserRepository:  s.userRepository,
+		NonceRepository: s.nonceRepository,
+		Domain:          "fund-o.app",
+	})
+	sessionUseCase := usecase.NewSessionUseCase(&usecase.SessionUseCaseOptions{
+		SessionRepository: s.sessionRepository,
+	})
+	s.mfaRepository = mocks.NewMockMfaRepository(ctrl)
+	mfaUseCase := usecase.NewMfaUseCase(&usecase.MfaUseCaseOptions{
+		MfaRepository:   s.mfaRepository,
+		UserRepository:  s.userRepository,
+		NonceRepository: s.nonceRepository,
+	})
+	s.apiKeyRepository = mocks.NewMockApiKeyRepository(ctrl)
+	s.apiKeyUseCase = usecase.NewApiKeyUseCase(&usecase.ApiKeyUseCaseOptions{
+		ApiKeyRepository: s.apiKeyRepository,
+	})
+	emailChangeUseCase := usecase.NewEmailChangeUseCase(&usecase.EmailChangeUseCaseOptions{
+		EmailChangeRepository: mocks.NewMockEmailChangeRepository(ctrl),
+		UserRepository:        s.userRepository,
+	})
+	s.dataExportRepo = mocks.NewMockDataExportRepository(ctrl)
+	s.projectRepository = mocks.NewMockProjectRepository(ctrl)
+	accountUseCase := usecase.NewAccountUseCase(&usecase.AccountUseCaseOptions{
+		DataExportRepository: s.dataExportRepo,
+		UserRepository:       s.userRepository,
+		ProjectRepository:    s.projectRepository,
+	})
+	s.taskDistributor = mocks.NewMockTaskDistributor(ctrl)
 	s.handler = NewUserHandler(&UserHandlerOptions{
-		UserUseCase: useUseCase,
+		UserUseCase:        useUseCase,
+		WalletAuthUseCase:  walletAuthUseCase,
+		SessionUseCase:     sessionUseCase,
+		MfaUseCase:         mfaUseCase,
+		ApiKeyUseCase:      s.apiKeyUseCase,
+		EmailChangeUseCase: emailChangeUseCase,
+		AccountUseCase:     accountUseCase,
+		TaskDistributor:    s.taskDistributor,
 	})
 }
 
@@ -149,7 +206,7 @@ func (s *UserTestSuite) TestGetUserAPI() {
 			recorder := httptest.NewRecorder()
 			c, r := gin.CreateTestContext(recorder)
 
-			r.GET("/users/me", middleware.AuthMiddleware(s.tokenMaker), s.handler.GetMe)
+			r.GET("/users/me", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.GetMe)
 
 			request, err := http.NewRequest(http.MethodGet, "/users/me", nil)
 			require.NoError(t, err)
@@ -207,6 +264,32 @@ func (s *UserTestSuite) TestUpdateUserAPI() {
 				require.Equal(t, newDisplayName, response.Result.DisplayName)
 			},
 		},
+		{
+			name:   "IgnoresEmailFields",
+			userID: user.ID.String(),
+			body: gin.H{
+				"display_name":      newDisplayName,
+				"email":             random.NewEmail(),
+				"is_email_verified": "true",
+			},
+			buildStubs: func(repo *mocks.MockUserRepository) {
+				// Email changes go through a confirmation link, so the profile form cannot set them.
+				updatedUser := entity.User{
+					DisplayName: newDisplayName,
+				}
+
+				repo.EXPECT().
+					UpdateByID(gomock.Eq(user.ID), &updatedUser).
+					Times(1).
+					Return(&user, nil)
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, s.tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
 		{
 			name:       "Unauthorized",
 			userID:     uuid.New().String(),
@@ -260,7 +343,7 @@ func (s *UserTestSuite) TestUpdateUserAPI() {
 			recorder := httptest.NewRecorder()
 			c, r := gin.CreateTestContext(recorder)
 
-			r.PATCH("users/:id", middleware.AuthMiddleware(s.tokenMaker), s.handler.UpdateUser)
+			r.PATCH("users/:id", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.UpdateUser)
 
 			body := &bytes.Buffer{}
 			writer := multipart.NewWriter(body)
@@ -287,6 +370,1426 @@ func (s *UserTestSuite) TestUpdateUserAPI() {
 	}
 }
 
+func (s *UserTestSuite) TestUpdateUserRoleAPI() {
+	admin := randomUser(s.T())
+	admin.Role = entity.RoleAdmin
+	user := randomUser(s.T())
+	apiKey := "fundo_" + strings.Repeat("ab", 32)
+
+	testCases := []struct {
+		name          string
+		userID        string
+		role          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mocks.MockUserRepository, apiKeyRepo *mocks.MockApiKeyRepository)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			userID: user.ID.String(),
+			role:   "moderator",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, admin.ID.String(), entity.RoleAdmin, time.Minute)
+			},
+			buildStubs: func(store *mocks.MockUserRepository, apiKeyRepo *mocks.MockApiKeyRepository) {
+				updated := user
+				updated.Role = entity.RoleModerator
+
+				store.EXPECT().
+					UpdateByID(gomock.Eq(user.ID), gomock.Eq(&entity.User{Role: entity.RoleModerator})).
+					Times(1).
+					Return(&updated, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[entity.UserDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusOK, response.StatusCode)
+				require.Equal(t, "moderator", response.Result.Role)
+			},
+		},
+		{
+			name:   "NotAdmin",
+			userID: user.ID.String(),
+			role:   "admin",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), entity.RoleModerator, time.Minute)
+			},
+			buildStubs: func(store *mocks.MockUserRepository, apiKeyRepo *mocks.MockApiKeyRepository) {
+				store.EXPECT().
+					UpdateByID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "OwnRole",
+			userID: admin.ID.String(),
+			role:   "user",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, admin.ID.String(), entity.RoleAdmin, time.Minute)
+			},
+			buildStubs: func(store *mocks.MockUserRepository, apiKeyRepo *mocks.MockApiKeyRepository) {
+				store.EXPECT().
+					UpdateByID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidRole",
+			userID: user.ID.String(),
+			role:   "owner",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, admin.ID.String(), entity.RoleAdmin, time.Minute)
+			},
+			buildStubs: func(store *mocks.MockUserRepository, apiKeyRepo *mocks.MockApiKeyRepository) {
+				store.EXPECT().
+					UpdateByID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "ApiKey",
+			userID: user.ID.String(),
+			role:   "admin",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				request.Header.Set(middleware.ApiKeyHeaderKey, apiKey)
+			},
+			buildStubs: func(store *mocks.MockUserRepository, apiKeyRepo *mocks.MockApiKeyRepository) {
+				apiKeyRepo.EXPECT().
+					FindByHashedKey(gomock.Any()).
+					Times(1).
+					Return(&entity.ApiKey{
+						Base:   entity.Base{ID: uuid.New(), CreatedAt: time.Now()},
+						UserID: admin.ID,
+						User:   admin,
+						Scopes: "read write",
+					}, nil)
+				apiKeyRepo.EXPECT().
+					UpdateLastUsedAt(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					UpdateByID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "NotFound",
+			userID: user.ID.String(),
+			role:   "creator",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(t, request, tokenMaker, middleware.AuthorizationTypeBearer, admin.ID.String(), entity.RoleAdmin, time.Minute)
+			},
+			buildStubs: func(store *mocks.MockUserRepository, apiKeyRepo *mocks.MockApiKeyRepository) {
+				store.EXPECT().
+					UpdateByID(gomock.Eq(user.ID), gomock.Any()).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.buildStubs(s.userRepository, s.apiKeyRepository)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.PATCH("/users/:id/role",
+				middleware.AuthMiddleware(s.tokenMaker, s.apiKeyUseCase),
+				middleware.RequireRole(entity.RoleAdmin),
+				middleware.RequireFullAccess(),
+				s.handler.UpdateUserRole,
+			)
+
+			requestBody, err := json.Marshal(entity.UserRoleUpdatePayload{Role: tc.role})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/users/%s/role", tc.userID)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			c.Request = request
+
+			tc.setupAuth(t, c.Request, s.tokenMaker)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *UserTestSuite) TestRevokeSessionAPI() {
+	user := randomUser(s.T())
+	sessionID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		sessionID     string
+		buildStubs    func(sessionRepo *mocks.MockSessionRepository)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			sessionID: sessionID.String(),
+			buildStubs: func(sessionRepo *mocks.MockSessionRepository) {
+				sessionRepo.EXPECT().
+					Block(gomock.Eq(sessionID), gomock.Eq(user.ID)).
+					Times(1).
+					Return(true, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "OtherUserSession",
+			sessionID: sessionID.String(),
+			buildStubs: func(sessionRepo *mocks.MockSessionRepository) {
+				sessionRepo.EXPECT().
+					Block(gomock.Eq(sessionID), gomock.Eq(user.ID)).
+					Times(1).
+					Return(false, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidSessionID",
+			sessionID: "invalid",
+			buildStubs: func(sessionRepo *mocks.MockSessionRepository) {
+				sessionRepo.EXPECT().
+					Block(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.buildStubs(s.sessionRepository)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.DELETE("/users/me/sessions/:id", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.RevokeSession)
+
+			url := fmt.Sprintf("/users/me/sessions/%s", tc.sessionID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			c.Request = request
+
+			addAuthorization(t, c.Request, s.tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), time.Minute)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *UserTestSuite) TestConfirmTOTPAPI() {
+	user := randomUser(s.T())
+	secret, err := totp.GenerateSecret()
+	require.NoError(s.T(), err)
+
+	step := totp.Step(time.Now())
+	code, err := totp.Code(secret, step)
+	require.NoError(s.T(), err)
+
+	confirmedAt := time.Now()
+
+	testCases := []struct {
+		name          string
+		code          string
+		buildStubs    func(mfaRepo *mocks.MockMfaRepository)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			code: code,
+			buildStubs: func(mfaRepo *mocks.MockMfaRepository) {
+				mfaRepo.EXPECT().
+					FindTOTPByUserID(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&entity.UserTOTP{UserID: user.ID, Secret: secret}, nil)
+				mfaRepo.EXPECT().
+					ConfirmTOTP(gomock.Eq(user.ID), gomock.Eq(step), gomock.Len(10)).
+					Times(1).
+					Return(true, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[entity.RecoveryCodesResponse]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusOK, response.StatusCode)
+				require.Len(t, response.Result.RecoveryCodes, 10)
+			},
+		},
+		{
+			name: "WrongCode",
+			code: "12345a",
+			buildStubs: func(mfaRepo *mocks.MockMfaRepository) {
+				mfaRepo.EXPECT().
+					FindTOTPByUserID(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&entity.UserTOTP{UserID: user.ID, Secret: secret}, nil)
+				mfaRepo.EXPECT().
+					ConfirmTOTP(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyEnabled",
+			code: code,
+			buildStubs: func(mfaRepo *mocks.MockMfaRepository) {
+				mfaRepo.EXPECT().
+					FindTOTPByUserID(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&entity.UserTOTP{UserID: user.ID, Secret: secret, ConfirmedAt: &confirmedAt}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotEnrolled",
+			code: code,
+			buildStubs: func(mfaRepo *mocks.MockMfaRepository) {
+				mfaRepo.EXPECT().
+					FindTOTPByUserID(gomock.Eq(user.ID)).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.buildStubs(s.mfaRepository)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.POST("/users/me/mfa/totp/confirm", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.ConfirmTOTP)
+
+			requestBody, err := json.Marshal(entity.TOTPCodePayload{Code: tc.code})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/users/me/mfa/totp/confirm", bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			c.Request = request
+
+			addAuthorization(t, c.Request, s.tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), time.Minute)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *UserTestSuite) TestChangeEmailAPI() {
+	user := randomUser(s.T())
+	walletUser := randomUser(s.T())
+	walletUser.HashedPassword = ""
+
+	testCases := []struct {
+		name          string
+		user          entity.User
+		requestBody   gin.H
+		buildStubs    func(user entity.User)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			user:        user,
+			requestBody: gin.H{"email": "new-email@gmail.com", "password": "@Password123"},
+			buildStubs: func(user entity.User) {
+				s.userRepository.EXPECT().
+					FindById(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&user, nil)
+				s.taskDistributor.EXPECT().
+					DistributeTaskSendEmailChangeEmail(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Do(func(_ any, payload *worker.PayloadSendEmailChangeEmail, _ ...asynq.Option) {
+						require.Equal(s.T(), user.ID.String(), payload.UserID)
+						require.Equal(s.T(), "new-email@gmail.com", payload.NewEmail)
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+			},
+		},
+		{
+			name:        "NoPasswordSet",
+			user:        walletUser,
+			requestBody: gin.H{"email": "new-email@gmail.com"},
+			buildStubs: func(user entity.User) {
+				s.userRepository.EXPECT().
+					FindById(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&user, nil)
+				s.taskDistributor.EXPECT().
+					DistributeTaskSendEmailChangeEmail(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+			},
+		},
+		{
+			name:        "IncorrectPassword",
+			user:        user,
+			requestBody: gin.H{"email": "new-email@gmail.com", "password": "wrong-password"},
+			buildStubs: func(user entity.User) {
+				s.userRepository.EXPECT().
+					FindById(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&user, nil)
+				s.taskDistributor.EXPECT().
+					DistributeTaskSendEmailChangeEmail(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "SameEmail",
+			user:        user,
+			requestBody: gin.H{"email": strings.ToUpper(user.Email), "password": "@Password123"},
+			buildStubs: func(user entity.User) {
+				s.userRepository.EXPECT().
+					FindById(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&user, nil)
+				s.taskDistributor.EXPECT().
+					DistributeTaskSendEmailChangeEmail(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "InvalidEmail",
+			user:        user,
+			requestBody: gin.H{"email": "not-an-email", "password": "@Password123"},
+			buildStubs: func(user entity.User) {
+				s.userRepository.EXPECT().
+					FindById(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.buildStubs(tc.user)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.POST("/users/me/email", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.ChangeEmail)
+
+			requestBody, err := json.Marshal(tc.requestBody)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/users/me/email", bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			c.Request = request
+
+			addAuthorization(t, c.Request, s.tokenMaker, middleware.AuthorizationTypeBearer, tc.user.ID.String(), time.Minute)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *UserTestSuite) TestExportDataAPI() {
+	user := randomUser(s.T())
+	walletUser := randomUser(s.T())
+	walletUser.Email = "0xabc@" + entity.WalletEmailDomain
+
+	testCases := []struct {
+		name          string
+		user          entity.User
+		buildStubs    func(user entity.User)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			user: user,
+			buildStubs: func(user entity.User) {
+				s.userRepository.EXPECT().
+					FindById(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&user, nil)
+				s.dataExportRepo.EXPECT().
+					FindLatestByUserID(gomock.Eq(user.ID)).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				s.dataExportRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(1).
+					DoAndReturn(func(export *entity.DataExport) (*entity.DataExport, error) {
+						require.Equal(s.T(), user.ID, export.UserID)
+						export.ID = uuid.New()
+						return export, nil
+					})
+				s.taskDistributor.EXPECT().
+					DistributeTaskGenerateDataExport(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+
+				var response ResultResponse[entity.DataExportDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, "pending", response.Result.Status)
+			},
+		},
+		{
+			name: "AlreadyPending",
+			user: user,
+			buildStubs: func(user entity.User) {
+				pending := entity.DataExport{
+					Base:   entity.Base{ID: uuid.New(), CreatedAt: time.Now().Add(-time.Minute)},
+					UserID: user.ID,
+					Status: entity.DataExportPending,
+				}
+
+				s.userRepository.EXPECT().
+					FindById(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&user, nil)
+				s.dataExportRepo.EXPECT().
+					FindLatestByUserID(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&pending, nil)
+				s.dataExportRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(0)
+				s.taskDistributor.EXPECT().
+					DistributeTaskGenerateDataExport(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+			},
+		},
+		{
+			name: "WalletOnly",
+			user: walletUser,
+			buildStubs: func(user entity.User) {
+				s.userRepository.EXPECT().
+					FindById(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&user, nil)
+				s.dataExportRepo.EXPECT().
+					FindLatestByUserID(gomock.Eq(user.ID)).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				s.dataExportRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(1).
+					DoAndReturn(func(export *entity.DataExport) (*entity.DataExport, error) {
+						export.ID = uuid.New()
+						return export, nil
+					})
+				s.taskDistributor.EXPECT().
+					DistributeTaskGenerateDataExport(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.buildStubs(tc.user)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.GET("/users/me/export", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.ExportData)
+
+			request, err := http.NewRequest(http.MethodGet, "/users/me/export", nil)
+			require.NoError(t, err)
+
+			c.Request = request
+
+			addAuthorization(t, c.Request, s.tokenMaker, middleware.AuthorizationTypeBearer, tc.user.ID.String(), time.Minute)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *UserTestSuite) TestGetDataExportAPI() {
+	user := randomUser(s.T())
+	walletUser := randomUser(s.T())
+	walletUser.Email = "0xabc@" + entity.WalletEmailDomain
+
+	newExport := func(user entity.User, status entity.DataExportStatus, expiredAt time.Time) *entity.DataExport {
+		return &entity.DataExport{
+			Base:           entity.Base{ID: uuid.New()},
+			UserID:         user.ID,
+			Status:         status,
+			SecretCodeHash: random.NewString(64),
+			ExpiredAt:      &expiredAt,
+		}
+	}
+
+	walletExport := newExport(walletUser, entity.DataExportReady, time.Now().Add(time.Hour))
+
+	testCases := []struct {
+		name          string
+		user          entity.User
+		export        *entity.DataExport
+		buildStubs    func(export *entity.DataExport)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			user:   user,
+			export: newExport(user, entity.DataExportReady, time.Now().Add(time.Hour)),
+			buildStubs: func(export *entity.DataExport) {
+				s.dataExportRepo.EXPECT().
+					UpdateSecretCodeHash(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response ResultResponse[entity.DataExportDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, "ready", response.Result.Status)
+				require.Empty(t, response.Result.DownloadURL)
+			},
+		},
+		{
+			name:   "WalletOnly",
+			user:   walletUser,
+			export: walletExport,
+			buildStubs: func(export *entity.DataExport) {
+				s.dataExportRepo.EXPECT().
+					UpdateSecretCodeHash(gomock.Eq(export.ID), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ uuid.UUID, secretCodeHash string) error {
+						export.SecretCodeHash = secretCodeHash
+						return nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+
+				var response ResultResponse[entity.DataExportDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				downloadURL, err := url.Parse(response.Result.DownloadURL)
+				require.NoError(t, err)
+				require.True(t, strings.HasSuffix(downloadURL.Path, "/"+response.Result.ID))
+
+				// The link carries the secret code whose hash was stored.
+				hash := sha256.Sum256([]byte(downloadURL.Query().Get("code")))
+				require.Equal(t, walletExport.SecretCodeHash, hex.EncodeToString(hash[:]))
+			},
+		},
+		{
+			name:   "WalletOnlyPending",
+			user:   walletUser,
+			export: newExport(walletUser, entity.DataExportPending, time.Now().Add(time.Hour)),
+			buildStubs: func(export *entity.DataExport) {
+				s.dataExportRepo.EXPECT().
+					UpdateSecretCodeHash(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response ResultResponse[entity.DataExportDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, "pending", response.Result.Status)
+				require.Empty(t, response.Result.DownloadURL)
+			},
+		},
+		{
+			name:   "WalletOnlyExpired",
+			user:   walletUser,
+			export: newExport(walletUser, entity.DataExportReady, time.Now().Add(-time.Minute)),
+			buildStubs: func(export *entity.DataExport) {
+				s.dataExportRepo.EXPECT().
+					UpdateSecretCodeHash(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response ResultResponse[entity.DataExportDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Empty(t, response.Result.DownloadURL)
+			},
+		},
+		{
+			name:   "OtherUser",
+			user:   walletUser,
+			export: newExport(user, entity.DataExportReady, time.Now().Add(time.Hour)),
+			buildStubs: func(export *entity.DataExport) {
+				s.dataExportRepo.EXPECT().
+					UpdateSecretCodeHash(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			s.userRepository.EXPECT().
+				FindById(gomock.Eq(tc.user.ID)).
+				Times(1).
+				Return(&tc.user, nil)
+			s.dataExportRepo.EXPECT().
+				FindByID(gomock.Eq(tc.export.ID)).
+				Times(1).
+				Return(tc.export, nil)
+			tc.buildStubs(tc.export)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.GET("/users/me/exports/:id", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.GetDataExport)
+
+			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/users/me/exports/%s", tc.export.ID), nil)
+			require.NoError(t, err)
+
+			c.Request = request
+
+			addAuthorization(t, c.Request, s.tokenMaker, middleware.AuthorizationTypeBearer, tc.user.ID.String(), time.Minute)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *UserTestSuite) TestDownloadDataExportAPI() {
+	secretCode := random.NewString(32)
+	hash := sha256.Sum256([]byte(secretCode))
+	archive := []byte(`{"profile":{}}`)
+
+	newExport := func(expiredAt time.Time) *entity.DataExport {
+		return &entity.DataExport{
+			Base:           entity.Base{ID: uuid.New()},
+			UserID:         uuid.New(),
+			Status:         entity.DataExportReady,
+			Archive:        archive,
+			SecretCodeHash: hex.EncodeToString(hash[:]),
+			ExpiredAt:      &expiredAt,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		export        *entity.DataExport
+		secretCode    string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			export:     newExport(time.Now().Add(time.Hour)),
+			secretCode: secretCode,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, archive, recorder.Body.Bytes())
+				require.Contains(t, recorder.Header().Get("Content-Disposition"), "attachment")
+			},
+		},
+		{
+			name:       "WrongSecretCode",
+			export:     newExport(time.Now().Add(time.Hour)),
+			secretCode: random.NewString(32),
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "Expired",
+			export:     newExport(time.Now().Add(-time.Minute)),
+			secretCode: secretCode,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			s.dataExportRepo.EXPECT().
+				FindByID(gomock.Eq(tc.export.ID)).
+				Times(1).
+				Return(tc.export, nil)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.GET("/exports/:id", s.handler.DownloadDataExport)
+
+			url := fmt.Sprintf("/exports/%s?code=%s", tc.export.ID, tc.secretCode)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			c.Request = request
+
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *UserTestSuite) TestDeleteMeAPI() {
+	user := randomUser(s.T())
+
+	testCases := []struct {
+		name          string
+		requestBody   gin.H
+		buildStubs    func()
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			requestBody: gin.H{"password": "@Password123"},
+			buildStubs: func() {
+				s.userRepository.EXPECT().
+					FindById(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&user, nil)
+				s.projectRepository.EXPECT().
+					FindAllByOwnerID(gomock.Eq(user.ID)).
+					Times(1).
+					Return([]entity.Project{{Status: entity.ProjectSucceeded}}, nil)
+				s.userRepository.EXPECT().
+					Anonymize(gomock.Eq(user.ID), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ uuid.UUID, anonymous *entity.User) error {
+						require.Equal(s.T(), user.ID.String()+"@"+entity.DeletedEmailDomain, anonymous.Email)
+						require.Empty(s.T(), anonymous.HashedPassword)
+						require.Empty(s.T(), anonymous.Firstname)
+						return nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "IncorrectPassword",
+			requestBody: gin.H{"password": "wrong-password"},
+			buildStubs: func() {
+				s.userRepository.EXPECT().
+					FindById(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&user, nil)
+				s.userRepository.EXPECT().
+					Anonymize(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:        "LiveProject",
+			requestBody: gin.H{"password": "@Password123"},
+			buildStubs: func() {
+				s.userRepository.EXPECT().
+					FindById(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&user, nil)
+				s.projectRepository.EXPECT().
+					FindAllByOwnerID(gomock.Eq(user.ID)).
+					Times(1).
+					Return([]entity.Project{{Status: entity.ProjectLive}}, nil)
+				s.userRepository.EXPECT().
+					Anonymize(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.buildStubs()
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.DELETE("/users/me", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.DeleteMe)
+
+			requestBody, err := json.Marshal(tc.requestBody)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodDelete, "/users/me", bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			c.Request = request
+
+			addAuthorization(t, c.Request, s.tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), time.Minute)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *UserTestSuite) TestCreateApiKeyAPI() {
+	user := randomUser(s.T())
+	past := time.Now().Add(-time.Hour)
+
+	testCases := []struct {
+		name          string
+		requestBody   gin.H
+		buildStubs    func(apiKeyRepo *mocks.MockApiKeyRepository)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			requestBody: gin.H{"name": "Deploy script", "scopes": []string{"write", "read", "read"}},
+			buildStubs: func(apiKeyRepo *mocks.MockApiKeyRepository) {
+				apiKeyRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(1).
+					DoAndReturn(func(apiKey *entity.ApiKey) (*entity.ApiKey, error) {
+						require.Equal(s.T(), user.ID, apiKey.UserID)
+						require.Equal(s.T(), "read write", apiKey.Scopes)
+						apiKey.ID = uuid.New()
+						return apiKey, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var response ResultResponse[entity.ApiKeyCreateResponse]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.True(t, strings.HasPrefix(response.Result.Key, "fundo_"))
+				require.True(t, strings.HasPrefix(response.Result.Key, response.Result.Prefix))
+				require.Equal(t, []string{"read", "write"}, response.Result.Scopes)
+			},
+		},
+		{
+			name:        "InvalidScope",
+			requestBody: gin.H{"name": "Deploy script", "scopes": []string{"admin"}},
+			buildStubs: func(apiKeyRepo *mocks.MockApiKeyRepository) {
+				apiKeyRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "ExpiryInThePast",
+			requestBody: gin.H{"name": "Deploy script", "scopes": []string{"read"}, "expired_at": past},
+			buildStubs: func(apiKeyRepo *mocks.MockApiKeyRepository) {
+				apiKeyRepo.EXPECT().
+					Create(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.buildStubs(s.apiKeyRepository)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.POST("/users/me/api-keys", middleware.AuthMiddleware(s.tokenMaker, s.apiKeyUseCase), middleware.RequireFullAccess(), s.handler.CreateApiKey)
+
+			requestBody, err := json.Marshal(tc.requestBody)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/users/me/api-keys", bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			c.Request = request
+
+			addAuthorization(t, c.Request, s.tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), time.Minute)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *UserTestSuite) TestApiKeyAuthentication() {
+	user := randomUser(s.T())
+	key := "fundo_" + strings.Repeat("ab", 32)
+	hash := sha256.Sum256([]byte(key))
+	hashedKey := hex.EncodeToString(hash[:])
+	expiredAt := time.Now().Add(-time.Minute)
+
+	apiKey := entity.ApiKey{
+		Base:      entity.Base{ID: uuid.New(), CreatedAt: time.Now()},
+		UserID:    user.ID,
+		User:      user,
+		HashedKey: hashedKey,
+		Scopes:    "read",
+	}
+
+	testCases := []struct {
+		name          string
+		key           string
+		buildStubs    func(apiKeyRepo *mocks.MockApiKeyRepository, userRepo *mocks.MockUserRepository)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			key:  key,
+			buildStubs: func(apiKeyRepo *mocks.MockApiKeyRepository, userRepo *mocks.MockUserRepository) {
+				apiKeyRepo.EXPECT().
+					FindByHashedKey(gomock.Eq(hashedKey)).
+					Times(1).
+					Return(&apiKey, nil)
+				apiKeyRepo.EXPECT().
+					UpdateLastUsedAt(gomock.Eq(apiKey.ID), gomock.Any()).
+					Times(1).
+					Return(nil)
+				userRepo.EXPECT().
+					FindById(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&user, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response ResultResponse[entity.UserDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, user.Email, response.Result.Email)
+			},
+		},
+		{
+			name: "UnknownKey",
+			key:  "fundo_" + strings.Repeat("cd", 32),
+			buildStubs: func(apiKeyRepo *mocks.MockApiKeyRepository, userRepo *mocks.MockUserRepository) {
+				apiKeyRepo.EXPECT().
+					FindByHashedKey(gomock.Any()).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "ExpiredKey",
+			key:  key,
+			buildStubs: func(apiKeyRepo *mocks.MockApiKeyRepository, userRepo *mocks.MockUserRepository) {
+				expired := apiKey
+				expired.ExpiredAt = &expiredAt
+				apiKeyRepo.EXPECT().
+					FindByHashedKey(gomock.Eq(hashedKey)).
+					Times(1).
+					Return(&expired, nil)
+				apiKeyRepo.EXPECT().
+					UpdateLastUsedAt(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.buildStubs(s.apiKeyRepository, s.userRepository)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.GET("/users/me", middleware.AuthMiddleware(s.tokenMaker, s.apiKeyUseCase), s.handler.GetMe)
+
+			request, err := http.NewRequest(http.MethodGet, "/users/me", nil)
+			require.NoError(t, err)
+
+			c.Request = request
+			c.Request.Header.Set(middleware.ApiKeyHeaderKey, tc.key)
+
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *UserTestSuite) TestCreateWalletChallengeAPI() {
+	user := randomUser(s.T())
+	key, err := secp256k1.GeneratePrivateKey()
+	require.NoError(s.T(), err)
+
+	address := walletAddress(key)
+
+	testCases := []struct {
+		name          string
+		address       string
+		buildStubs    func(nonceRepo *mocks.MockNonceRepository)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			address: strings.ToLower(address),
+			buildStubs: func(nonceRepo *mocks.MockNonceRepository) {
+				nonceRepo.EXPECT().
+					Create(gomock.Any(), gomock.Eq(fmt.Sprintf("link:%s:%s", user.ID, address)), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[entity.UserWalletChallengeResponse]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				message, err := siwe.ParseMessage(response.Result.Message)
+				require.NoError(t, err)
+				require.Equal(t, "fund-o.app", message.Domain)
+				require.Equal(t, address, message.Address)
+				require.Equal(t, response.Result.Nonce, message.Nonce)
+			},
+		},
+		{
+			name:    "InvalidAddress",
+			address: "0x1234",
+			buildStubs: func(nonceRepo *mocks.MockNonceRepository) {
+				nonceRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.buildStubs(s.nonceRepository)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.POST("/users/me/wallets/challenge", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.CreateWalletChallenge)
+
+			requestBody, err := json.Marshal(entity.UserWalletChallengePayload{Address: tc.address})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/users/me/wallets/challenge", bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			c.Request = request
+
+			addAuthorization(t, c.Request, s.tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), time.Minute)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *UserTestSuite) TestLinkWalletAPI() {
+	user := randomUser(s.T())
+	otherUser := randomUser(s.T())
+	key, err := secp256k1.GeneratePrivateKey()
+	require.NoError(s.T(), err)
+
+	address := walletAddress(key)
+	subject := fmt.Sprintf("link:%s:%s", user.ID, address)
+	nonce := "f0e1d2c3b4a59687"
+	message := walletMessage("fund-o.app", address, nonce, time.Now().Add(5*time.Minute))
+	linkedUser := user
+	linkedUser.Wallets = []entity.UserWallet{{UserID: user.ID, Address: address}}
+	otherUser.Wallets = []entity.UserWallet{{UserID: otherUser.ID, Address: address}}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(userRepo *mocks.MockUserRepository, nonceRepo *mocks.MockNonceRepository)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(userRepo *mocks.MockUserRepository, nonceRepo *mocks.MockNonceRepository) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Eq(nonce)).
+					Times(1).
+					Return(subject, nil)
+				userRepo.EXPECT().
+					FindByWalletAddress(gomock.Eq(address)).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				userRepo.EXPECT().
+					CreateWallet(gomock.Eq(&entity.UserWallet{UserID: user.ID, Address: address})).
+					Times(1).
+					DoAndReturn(func(wallet *entity.UserWallet) (*entity.UserWallet, error) {
+						return wallet, nil
+					})
+				userRepo.EXPECT().
+					FindById(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&linkedUser, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[entity.UserDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusCreated, response.StatusCode)
+				require.Len(t, response.Result.Wallets, 1)
+				require.Equal(t, address, response.Result.Wallets[0].Address)
+			},
+		},
+		{
+			name: "LinkedToAnotherUser",
+			buildStubs: func(userRepo *mocks.MockUserRepository, nonceRepo *mocks.MockNonceRepository) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Eq(nonce)).
+					Times(1).
+					Return(subject, nil)
+				userRepo.EXPECT().
+					FindByWalletAddress(gomock.Eq(address)).
+					Times(1).
+					Return(&otherUser, nil)
+				userRepo.EXPECT().
+					CreateWallet(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "LinkedConcurrently",
+			buildStubs: func(userRepo *mocks.MockUserRepository, nonceRepo *mocks.MockNonceRepository) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Eq(nonce)).
+					Times(1).
+					Return(subject, nil)
+				userRepo.EXPECT().
+					FindByWalletAddress(gomock.Eq(address)).
+					Times(1).
+					Return(nil, gorm.ErrRecordNotFound)
+				userRepo.EXPECT().
+					CreateWallet(gomock.Any()).
+					Times(1).
+					Return(nil, gorm.ErrDuplicatedKey)
+				userRepo.EXPECT().
+					FindById(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "ChallengeIssuedToAnotherUser",
+			buildStubs: func(userRepo *mocks.MockUserRepository, nonceRepo *mocks.MockNonceRepository) {
+				nonceRepo.EXPECT().
+					Consume(gomock.Eq(nonce)).
+					Times(1).
+					Return(fmt.Sprintf("link:%s:%s", otherUser.ID, address), nil)
+				userRepo.EXPECT().
+					FindByWalletAddress(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.buildStubs(s.userRepository, s.nonceRepository)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.POST("/users/me/wallets", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.LinkWallet)
+
+			requestBody, err := json.Marshal(entity.UserWalletLinkPayload{
+				Message:   message,
+				Signature: signWalletMessage(key, message),
+			})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/users/me/wallets", bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			c.Request = request
+
+			addAuthorization(t, c.Request, s.tokenMaker, middleware.AuthorizationTypeBearer, user.ID.String(), time.Minute)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *UserTestSuite) TestUnlinkWalletAPI() {
+	user := randomUser(s.T())
+	walletOnlyUser := randomUser(s.T())
+	address := "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	user.Wallets = []entity.UserWallet{{UserID: user.ID, Address: address}}
+	walletOnlyUser.HashedPassword = ""
+	walletOnlyUser.Wallets = []entity.UserWallet{{UserID: walletOnlyUser.ID, Address: address}}
+	unlinkedUser := user
+	unlinkedUser.Wallets = nil
+
+	testCases := []struct {
+		name          string
+		user          entity.User
+		address       string
+		buildStubs    func(repo *mocks.MockUserRepository)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			user:    user,
+			address: strings.ToLower(address),
+			buildStubs: func(repo *mocks.MockUserRepository) {
+				gomock.InOrder(
+					repo.EXPECT().
+						FindById(gomock.Eq(user.ID)).
+						Times(1).
+						Return(&user, nil),
+					repo.EXPECT().
+						DeleteWallet(gomock.Eq(user.ID), gomock.Eq(address)).
+						Times(1).
+						Return(true, nil),
+					repo.EXPECT().
+						FindById(gomock.Eq(user.ID)).
+						Times(1).
+						Return(&unlinkedUser, nil),
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				var response ResultResponse[entity.UserDto]
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				require.Equal(t, http.StatusOK, response.StatusCode)
+				require.Empty(t, response.Result.Wallets)
+			},
+		},
+		{
+			name:    "NotLinked",
+			user:    unlinkedUser,
+			address: address,
+			buildStubs: func(repo *mocks.MockUserRepository) {
+				repo.EXPECT().
+					FindById(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&unlinkedUser, nil)
+				repo.EXPECT().
+					DeleteWallet(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:    "LastSignInMethod",
+			user:    walletOnlyUser,
+			address: address,
+			buildStubs: func(repo *mocks.MockUserRepository) {
+				repo.EXPECT().
+					FindById(gomock.Eq(walletOnlyUser.ID)).
+					Times(1).
+					Return(&walletOnlyUser, nil)
+				repo.EXPECT().
+					DeleteWallet(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			tc.buildStubs(s.userRepository)
+
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.DELETE("/users/me/wallets/:address", middleware.AuthMiddleware(s.tokenMaker, nil), s.handler.UnlinkWallet)
+
+			request, err := http.NewRequest(http.MethodDelete, "/users/me/wallets/"+tc.address, nil)
+			require.NoError(t, err)
+
+			c.Request = request
+
+			addAuthorization(t, c.Request, s.tokenMaker, middleware.AuthorizationTypeBearer, tc.user.ID.String(), time.Minute)
+			r.ServeHTTP(recorder, c.Request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
 func TestUserHandlerSuite(t *testing.T) {
 	suite.Run(t, new(UserTestSuite))
 }
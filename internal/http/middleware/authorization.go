@@ -3,6 +3,7 @@ package middleware
 import (
 	"errors"
 	"fmt"
+	"fund-o/api-server/internal/entity"
 	"fund-o/api-server/pkg/token"
 	"net/http"
 	"strings"
@@ -13,43 +14,53 @@ import (
 const (
 	AuthorizationHeaderKey  = "authorization"
 	AuthorizationTypeBearer = "bearer"
+	AuthorizationTypeApiKey = "apikey"
 	AuthorizationPayloadKey = "authorization_payload"
+	ApiKeyHeaderKey         = "x-api-key"
 )
 
-func AuthMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+// ApiKeyAuthenticator resolves an API key to the authorization payload of its user.
+type ApiKeyAuthenticator interface {
+	AuthenticateApiKey(key string) (*token.Payload, error)
+}
+
+// AuthMiddleware accepts a bearer token or, when apiKeys is not nil, an API key given in the
+// X-Api-Key header or with the ApiKey authorization type.
+func AuthMiddleware(tokenMaker token.Maker, apiKeys ApiKeyAuthenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		payload, err := parseQueryToken(c, tokenMaker)
-		if err == nil {
-			c.Set(AuthorizationPayloadKey, payload)
-			c.Next()
+		payload, err := authorize(c, tokenMaker, apiKeys)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
 			return
 		}
 
-		authorizationHeader := c.GetHeader(AuthorizationHeaderKey)
-		if len(authorizationHeader) == 0 {
-			err := errors.New("authorization header is not provided")
-			c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+		if !payload.HasScope(requiredScope(c.Request.Method)) {
+			c.AbortWithStatusJSON(http.StatusForbidden, forbiddenResponse(errScopeNotAllowed))
 			return
 		}
 
-		fields := strings.Fields(authorizationHeader)
-		if len(fields) < 2 {
-			err := errors.New("invalid authorization header format")
-			c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+		c.Set(AuthorizationPayloadKey, payload)
+		c.Next()
+	}
+}
+
+// OptionalAuthMiddleware lets anonymous requests through without an authorization payload, but
+// still rejects requests that carry invalid credentials.
+func OptionalAuthMiddleware(tokenMaker token.Maker, apiKeys ApiKeyAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Query("token") == "" && c.GetHeader(AuthorizationHeaderKey) == "" && c.GetHeader(ApiKeyHeaderKey) == "" {
+			c.Next()
 			return
 		}
 
-		authorizationType := strings.ToLower(fields[0])
-		if authorizationType != AuthorizationTypeBearer {
-			err := fmt.Errorf("unsupported authorization type %s", authorizationType)
+		payload, err := authorize(c, tokenMaker, apiKeys)
+		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
 			return
 		}
 
-		accessToken := fields[1]
-		payload, err = tokenMaker.VerifyToken(accessToken)
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+		if !payload.HasScope(requiredScope(c.Request.Method)) {
+			c.AbortWithStatusJSON(http.StatusForbidden, forbiddenResponse(errScopeNotAllowed))
 			return
 		}
 
@@ -58,14 +69,128 @@ func AuthMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 	}
 }
 
+// RequireRole only lets through users holding one of the given roles. It has to run after
+// AuthMiddleware, which puts the authorization payload on the context.
+func RequireRole(roles ...entity.UserRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		payload, ok := c.Get(AuthorizationPayloadKey)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errors.New("authorization payload is not provided")))
+			return
+		}
+
+		role := payload.(*token.Payload).Role
+		for _, allowed := range roles {
+			if role == allowed.String() {
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, forbiddenResponse(fmt.Errorf("role %q is not allowed to access this resource", role)))
+	}
+}
+
+// RequireFullAccess rejects API keys and other scoped payloads, for managing the account itself.
+// It has to run after AuthMiddleware.
+func RequireFullAccess() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		payload, ok := c.Get(AuthorizationPayloadKey)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errors.New("authorization payload is not provided")))
+			return
+		}
+
+		if len(payload.(*token.Payload).Scopes) > 0 {
+			c.AbortWithStatusJSON(http.StatusForbidden, forbiddenResponse(errors.New("api keys are not allowed to access this resource, please sign in")))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+var errScopeNotAllowed = errors.New("api key does not have the scope to access this resource")
+
+func authorize(c *gin.Context, tokenMaker token.Maker, apiKeys ApiKeyAuthenticator) (*token.Payload, error) {
+	if apiKey := c.GetHeader(ApiKeyHeaderKey); apiKey != "" {
+		return authenticateApiKey(apiKeys, apiKey)
+	}
+
+	payload, err := parseQueryToken(c, tokenMaker)
+	if err == nil {
+		return payload, nil
+	}
+
+	authorizationHeader := c.GetHeader(AuthorizationHeaderKey)
+	if len(authorizationHeader) == 0 {
+		return nil, errors.New("authorization header is not provided")
+	}
+
+	fields := strings.Fields(authorizationHeader)
+	if len(fields) < 2 {
+		return nil, errors.New("invalid authorization header format")
+	}
+
+	authorizationType := strings.ToLower(fields[0])
+	switch authorizationType {
+	case AuthorizationTypeBearer:
+		return verifyAccessToken(tokenMaker, fields[1])
+	case AuthorizationTypeApiKey:
+		return authenticateApiKey(apiKeys, fields[1])
+	default:
+		return nil, fmt.Errorf("unsupported authorization type %s", authorizationType)
+	}
+}
+
+func authenticateApiKey(apiKeys ApiKeyAuthenticator, apiKey string) (*token.Payload, error) {
+	if apiKeys == nil {
+		return nil, errors.New("api keys are not accepted")
+	}
+
+	return apiKeys.AuthenticateApiKey(apiKey)
+}
+
+// requiredScope returns the scope an API key needs to make a request with the method.
+func requiredScope(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return entity.ApiKeyScopeRead
+	default:
+		return entity.ApiKeyScopeWrite
+	}
+}
+
 func parseQueryToken(c *gin.Context, tokenMaker token.Maker) (*token.Payload, error) {
 	accessToken := c.Query("token")
 	if len(accessToken) == 0 {
 		return nil, errors.New("access token is not provided")
 	}
 
+	return verifyAccessToken(tokenMaker, accessToken)
+}
+
+// verifyAccessToken rejects the refresh tokens issued alongside access tokens, which outlive
+// them and stay valid after their session is signed out.
+func verifyAccessToken(tokenMaker token.Maker, accessToken string) (*token.Payload, error) {
 	payload, err := tokenMaker.VerifyToken(accessToken)
-	return payload, err
+	if err != nil {
+		return nil, err
+	}
+
+	if err := payload.CheckType(token.TypeAccess); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+func forbiddenResponse(err error) gin.H {
+	return gin.H{
+		"status":      http.StatusText(http.StatusForbidden),
+		"status_code": http.StatusForbidden,
+		"error":       err.Error(),
+	}
 }
 
 func errorResponse(err error) gin.H {
@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"errors"
 	"fmt"
+	"fund-o/api-server/internal/entity"
 	"fund-o/api-server/pkg/token"
 	"net/http"
 	"net/http/httptest"
@@ -78,6 +80,17 @@ func (s *MiddlewareSuite) TestAuthorizationMiddleware() {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
+		{
+			name: "RefreshToken",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				refreshToken, _, err := tokenMaker.CreateToken(userID, entity.RoleUser.String(), token.TypeRefresh, time.Minute)
+				require.NoError(t, err)
+				request.Header.Set(AuthorizationHeaderKey, fmt.Sprintf("%s %s", AuthorizationTypeBearer, refreshToken))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -86,7 +99,7 @@ func (s *MiddlewareSuite) TestAuthorizationMiddleware() {
 			c, r := gin.CreateTestContext(recorder)
 
 			r.GET("/auth",
-				AuthMiddleware(s.tokenMaker),
+				AuthMiddleware(s.tokenMaker, nil),
 				func(c *gin.Context) {
 					c.JSON(http.StatusOK, gin.H{})
 				},
@@ -130,7 +143,155 @@ func (s *MiddlewareSuite) TestAuthorizationMiddlewareInQueryString() {
 			c, r := gin.CreateTestContext(recorder)
 
 			r.GET("/auth",
-				AuthMiddleware(s.tokenMaker),
+				AuthMiddleware(s.tokenMaker, nil),
+				func(c *gin.Context) {
+					c.JSON(http.StatusOK, gin.H{})
+				},
+			)
+
+			request, err := http.NewRequest(http.MethodGet, "/auth", nil)
+			require.NoError(t, err)
+
+			c.Request = request
+
+			tc.setupAuth(t, request, s.tokenMaker)
+			r.ServeHTTP(recorder, c.Request)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *MiddlewareSuite) TestOptionalAuthorizationMiddleware() {
+	userID := uuid.NewString()
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				s.addAuthorization(t, request, AuthorizationTypeBearer, userID, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), userID)
+			},
+		},
+		{
+			name:      "Anonymous",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.NotContains(t, recorder.Body.String(), userID)
+			},
+		},
+		{
+			name: "ExpiredToken",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				s.addAuthorization(t, request, AuthorizationTypeBearer, userID, -time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "RefreshToken",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				refreshToken, _, err := tokenMaker.CreateToken(userID, entity.RoleUser.String(), token.TypeRefresh, time.Minute)
+				require.NoError(t, err)
+				request.Header.Set(AuthorizationHeaderKey, fmt.Sprintf("%s %s", AuthorizationTypeBearer, refreshToken))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.GET("/auth",
+				OptionalAuthMiddleware(s.tokenMaker, nil),
+				func(c *gin.Context) {
+					var userID string
+					if payload, ok := c.Get(AuthorizationPayloadKey); ok {
+						userID = payload.(*token.Payload).UserID
+					}
+
+					c.JSON(http.StatusOK, gin.H{"user_id": userID})
+				},
+			)
+
+			request, err := http.NewRequest(http.MethodGet, "/auth", nil)
+			require.NoError(t, err)
+
+			c.Request = request
+
+			tc.setupAuth(t, request, s.tokenMaker)
+			r.ServeHTTP(recorder, c.Request)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *MiddlewareSuite) TestRequireRoleMiddleware() {
+	userID := uuid.NewString()
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Moderator",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				s.addRoleAuthorization(t, request, AuthorizationTypeBearer, userID, entity.RoleModerator, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Admin",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				s.addRoleAuthorization(t, request, AuthorizationTypeBearer, userID, entity.RoleAdmin, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				s.addRoleAuthorization(t, request, AuthorizationTypeBearer, userID, entity.RoleCreator, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "NoAuthorization",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.GET("/auth",
+				AuthMiddleware(s.tokenMaker, nil),
+				RequireRole(entity.RoleModerator, entity.RoleAdmin),
 				func(c *gin.Context) {
 					c.JSON(http.StatusOK, gin.H{})
 				},
@@ -149,6 +310,169 @@ func (s *MiddlewareSuite) TestAuthorizationMiddlewareInQueryString() {
 	}
 }
 
+func (s *MiddlewareSuite) TestApiKeyAuthorizationMiddleware() {
+	apiKeys := fakeApiKeyAuthenticator{
+		"fundo_read":  {ID: uuid.New(), UserID: uuid.NewString(), Role: entity.RoleUser.String(), Scopes: []string{entity.ApiKeyScopeRead}},
+		"fundo_write": {ID: uuid.New(), UserID: uuid.NewString(), Role: entity.RoleUser.String(), Scopes: []string{entity.ApiKeyScopeRead, entity.ApiKeyScopeWrite}},
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		apiKeys       ApiKeyAuthenticator
+		setupAuth     func(request *http.Request)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "Header",
+			method:  http.MethodGet,
+			apiKeys: apiKeys,
+			setupAuth: func(request *http.Request) {
+				request.Header.Set(ApiKeyHeaderKey, "fundo_read")
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:    "AuthorizationType",
+			method:  http.MethodPost,
+			apiKeys: apiKeys,
+			setupAuth: func(request *http.Request) {
+				request.Header.Set(AuthorizationHeaderKey, "ApiKey fundo_write")
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:    "MissingScope",
+			method:  http.MethodPost,
+			apiKeys: apiKeys,
+			setupAuth: func(request *http.Request) {
+				request.Header.Set(ApiKeyHeaderKey, "fundo_read")
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:    "InvalidKey",
+			method:  http.MethodGet,
+			apiKeys: apiKeys,
+			setupAuth: func(request *http.Request) {
+				request.Header.Set(ApiKeyHeaderKey, "fundo_unknown")
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:    "ApiKeysNotAccepted",
+			method:  http.MethodGet,
+			apiKeys: nil,
+			setupAuth: func(request *http.Request) {
+				request.Header.Set(ApiKeyHeaderKey, "fundo_read")
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.Handle(tc.method, "/auth",
+				AuthMiddleware(s.tokenMaker, tc.apiKeys),
+				func(c *gin.Context) {
+					c.JSON(http.StatusOK, gin.H{})
+				},
+			)
+
+			request, err := http.NewRequest(tc.method, "/auth", nil)
+			require.NoError(t, err)
+
+			c.Request = request
+
+			tc.setupAuth(request)
+			r.ServeHTTP(recorder, c.Request)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func (s *MiddlewareSuite) TestRequireFullAccessMiddleware() {
+	userID := uuid.NewString()
+	apiKeys := fakeApiKeyAuthenticator{
+		"fundo_write": {ID: uuid.New(), UserID: userID, Role: entity.RoleUser.String(), Scopes: []string{entity.ApiKeyScopeRead, entity.ApiKeyScopeWrite}},
+	}
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "AccessToken",
+			setupAuth: func(t *testing.T, request *http.Request) {
+				s.addAuthorization(t, request, AuthorizationTypeBearer, userID, time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ApiKey",
+			setupAuth: func(t *testing.T, request *http.Request) {
+				request.Header.Set(ApiKeyHeaderKey, "fundo_write")
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, r := gin.CreateTestContext(recorder)
+
+			r.POST("/auth",
+				AuthMiddleware(s.tokenMaker, apiKeys),
+				RequireFullAccess(),
+				func(c *gin.Context) {
+					c.JSON(http.StatusOK, gin.H{})
+				},
+			)
+
+			request, err := http.NewRequest(http.MethodPost, "/auth", nil)
+			require.NoError(t, err)
+
+			c.Request = request
+
+			tc.setupAuth(t, request)
+			r.ServeHTTP(recorder, c.Request)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+type fakeApiKeyAuthenticator map[string]*token.Payload
+
+func (f fakeApiKeyAuthenticator) AuthenticateApiKey(key string) (*token.Payload, error) {
+	payload, ok := f[key]
+	if !ok {
+		return nil, errors.New("api key is invalid or has expired")
+	}
+
+	return payload, nil
+}
+
 func (s *MiddlewareSuite) addAuthorization(
 	t *testing.T,
 	request *http.Request,
@@ -156,11 +480,22 @@ func (s *MiddlewareSuite) addAuthorization(
 	userID string,
 	duration time.Duration,
 ) {
-	token, payload, err := s.tokenMaker.CreateToken(userID, duration)
+	s.addRoleAuthorization(t, request, authorizationType, userID, entity.RoleUser, duration)
+}
+
+func (s *MiddlewareSuite) addRoleAuthorization(
+	t *testing.T,
+	request *http.Request,
+	authorizationType string,
+	userID string,
+	role entity.UserRole,
+	duration time.Duration,
+) {
+	accessToken, payload, err := s.tokenMaker.CreateToken(userID, role.String(), token.TypeAccess, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 
-	authorizationHeader := fmt.Sprintf("%s %s", authorizationType, token)
+	authorizationHeader := fmt.Sprintf("%s %s", authorizationType, accessToken)
 	request.Header.Set(AuthorizationHeaderKey, authorizationHeader)
 }
 
@@ -170,12 +505,12 @@ func (s *MiddlewareSuite) addAuthorizationInQueryParams(
 	userID string,
 	duration time.Duration,
 ) {
-	token, payload, err := s.tokenMaker.CreateToken(userID, duration)
+	accessToken, payload, err := s.tokenMaker.CreateToken(userID, entity.RoleUser.String(), token.TypeAccess, duration)
 	require.NoError(t, err)
 	require.NotEmpty(t, payload)
 
 	query := request.URL.Query()
-	query.Add("token", token)
+	query.Add("token", accessToken)
 	request.URL.RawQuery = query.Encode()
 }
 
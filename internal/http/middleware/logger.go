@@ -14,6 +14,7 @@ func RequestLogger() gin.HandlerFunc {
 		remoteArress := strings.Split(c.Request.RemoteAddr, ":")
 
 		log.Info().
+			Ctx(c.Request.Context()).
 			Str("method", c.Request.Method).
 			Str("url", c.Request.URL.Path).
 			Str("hostname", c.Request.Host).
@@ -32,6 +33,7 @@ func ResponseLogger() gin.HandlerFunc {
 		c.Next()
 
 		log.Info().
+			Ctx(c.Request.Context()).
 			Int("status_code", c.Writer.Status()).
 			Dur("response_time", time.Since(now)).
 			Msg("request completed")
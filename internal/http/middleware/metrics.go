@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"fund-o/api-server/pkg/metrics"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics records the count and latency of every request. Requests are labelled with the route
+// template rather than the path, so IDs in URLs don't create a series per resource.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		now := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		metrics.HttpRequests.WithLabelValues(c.Request.Method, route, status).Inc()
+		metrics.HttpRequestDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(now).Seconds())
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"fund-o/api-server/pkg/metrics"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	router := gin.New()
+	router.Use(Metrics())
+	router.GET("/projects/:id", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	t.Run("Test labels with route template", func(t *testing.T) {
+		before := testutil.ToFloat64(metrics.HttpRequests.WithLabelValues(http.MethodGet, "/projects/:id", "204"))
+
+		for _, id := range []string{"a", "b"} {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/projects/"+id, nil))
+			require.Equal(t, http.StatusNoContent, recorder.Code)
+		}
+
+		after := testutil.ToFloat64(metrics.HttpRequests.WithLabelValues(http.MethodGet, "/projects/:id", "204"))
+		require.Equal(t, before+2, after)
+	})
+
+	t.Run("Test unmatched route", func(t *testing.T) {
+		before := testutil.ToFloat64(metrics.HttpRequests.WithLabelValues(http.MethodGet, "unmatched", "404"))
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/missing/123", nil))
+		require.Equal(t, http.StatusNotFound, recorder.Code)
+
+		after := testutil.ToFloat64(metrics.HttpRequests.WithLabelValues(http.MethodGet, "unmatched", "404"))
+		require.Equal(t, before+1, after)
+	})
+}
@@ -0,0 +1,294 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fund-o/api-server/internal/datasource/repository"
+	"fund-o/api-server/internal/entity"
+	"fund-o/api-server/pkg/apperrors"
+	"fund-o/api-server/pkg/password"
+	"fund-o/api-server/pkg/random"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	dataExportTTL          = 24 * time.Hour
+	dataExportPendingTTL   = time.Hour
+	dataExportSecretSize   = 32
+	defaultDataExportURL   = "http://localhost:3000/api/v1/exports"
+	deletedUserDisplayName = "Deleted user"
+)
+
+type AccountUseCase interface {
+	RequestDataExport(userID string) (*entity.DataExportDto, bool, error)
+	GetDataExport(userID string, exportID string) (*entity.DataExportDto, error)
+	GenerateDataExport(ctx context.Context, exportID string) (*entity.DataExportLink, error)
+	DownloadDataExport(exportID string, secretCode string) ([]byte, error)
+	DeleteAccount(userID string, payload *entity.AccountDeletePayload) error
+}
+
+type accountUseCase struct {
+	dataExportRepository repository.DataExportRepository
+	userRepository       repository.UserRepository
+	projectRepository    repository.ProjectRepository
+	downloadURL          string
+}
+
+type AccountUseCaseOptions struct {
+	repository.DataExportRepository
+	repository.UserRepository
+	repository.ProjectRepository
+	DownloadURL string
+}
+
+func NewAccountUseCase(options *AccountUseCaseOptions) AccountUseCase {
+	downloadURL := options.DownloadURL
+	if downloadURL == "" {
+		downloadURL = defaultDataExportURL
+	}
+
+	return &accountUseCase{
+		dataExportRepository: options.DataExportRepository,
+		userRepository:       options.UserRepository,
+		projectRepository:    options.ProjectRepository,
+		downloadURL:          strings.TrimSuffix(downloadURL, "/"),
+	}
+}
+
+// RequestDataExport records a new export of the user's data for the worker to generate. It
+// reports false when an export is already being generated, in which case that one is returned.
+func (uc *accountUseCase) RequestDataExport(userID string) (*entity.DataExportDto, bool, error) {
+	user, err := uc.findUser(userID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	latest, err := uc.dataExportRepository.FindLatestByUserID(user.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, err
+	}
+
+	// An export still pending after a while was lost by the worker and may be requested again.
+	if err == nil && latest.Status == entity.DataExportPending && time.Since(latest.CreatedAt) < dataExportPendingTTL {
+		return latest.ToDataExportDto(), false, nil
+	}
+
+	export, err := uc.dataExportRepository.Create(&entity.DataExport{
+		UserID: user.ID,
+		Status: entity.DataExportPending,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return export.ToDataExportDto(), true, nil
+}
+
+// GenerateDataExport builds the archive of an export and returns the download link to send to the
+// user. Generating an export again replaces its secret code, so a retried task sends a working link.
+func (uc *accountUseCase) GenerateDataExport(ctx context.Context, exportID string) (*entity.DataExportLink, error) {
+	uc = uc.withContext(ctx)
+
+	id, err := uuid.Parse(exportID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidDataExport
+	}
+
+	export, err := uc.dataExportRepository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrInvalidDataExport
+		}
+
+		return nil, err
+	}
+
+	data, err := uc.dataExportRepository.FindUserData(export.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	archive, err := json.MarshalIndent(data.ToUserDataArchive(time.Now().UTC()), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	secretCode, err := random.NewSecret(dataExportSecretSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.dataExportRepository.MarkReady(export.ID, archive, hashSecretCode(secretCode), time.Now().Add(dataExportTTL)); err != nil {
+		return nil, err
+	}
+
+	return &entity.DataExportLink{
+		Email: data.User.Email,
+		URL:   uc.dataExportURL(export.ID, secretCode),
+	}, nil
+}
+
+// GetDataExport returns an export of the user. A user without an email address cannot be sent the
+// download link, so a ready export comes with a link of its own; each call replaces the secret
+// code, leaving only the latest link working.
+func (uc *accountUseCase) GetDataExport(userID string, exportID string) (*entity.DataExportDto, error) {
+	user, err := uc.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(exportID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidDataExport
+	}
+
+	export, err := uc.dataExportRepository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrInvalidDataExport
+		}
+
+		return nil, err
+	}
+
+	if export.UserID != user.ID {
+		return nil, apperrors.ErrInvalidDataExport
+	}
+
+	exportDto := export.ToDataExportDto()
+	if export.Status != entity.DataExportReady || !entity.IsPlaceholderEmail(user.Email) ||
+		export.ExpiredAt == nil || export.ExpiredAt.Before(time.Now()) {
+		return exportDto, nil
+	}
+
+	secretCode, err := random.NewSecret(dataExportSecretSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.dataExportRepository.UpdateSecretCodeHash(export.ID, hashSecretCode(secretCode)); err != nil {
+		return nil, err
+	}
+
+	exportDto.DownloadURL = uc.dataExportURL(export.ID, secretCode)
+	return exportDto, nil
+}
+
+// DownloadDataExport returns the archive of a ready export. The secret code of the link is enough
+// to download it, so that the link works straight from the email.
+func (uc *accountUseCase) DownloadDataExport(exportID string, secretCode string) ([]byte, error) {
+	id, err := uuid.Parse(exportID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidDataExport
+	}
+
+	export, err := uc.dataExportRepository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrInvalidDataExport
+		}
+
+		return nil, err
+	}
+
+	hash := hashSecretCode(secretCode)
+	if export.Status != entity.DataExportReady || subtle.ConstantTimeCompare([]byte(hash), []byte(export.SecretCodeHash)) != 1 {
+		return nil, apperrors.ErrInvalidDataExport
+	}
+
+	if export.ExpiredAt == nil || export.ExpiredAt.Before(time.Now()) {
+		return nil, apperrors.ErrDataExportExpired
+	}
+
+	return export.Archive, nil
+}
+
+// DeleteAccount anonymises the user. Accounts with a password have to enter it again. Funding
+// records are kept for accounting, so a user owning a project that may still raise funds has to
+// wait until it ends.
+func (uc *accountUseCase) DeleteAccount(userID string, payload *entity.AccountDeletePayload) error {
+	user, err := uc.findUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.HashedPassword != "" {
+		if err := password.CheckPassword(payload.Password, user.HashedPassword); err != nil {
+			return apperrors.ErrIncorrectPassword
+		}
+	}
+
+	projects, err := uc.projectRepository.FindAllByOwnerID(user.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, project := range projects {
+		if project.Status == entity.ProjectPendingReview || project.Status == entity.ProjectLive {
+			return apperrors.ErrActiveProjects
+		}
+	}
+
+	err = uc.userRepository.Anonymize(user.ID, &entity.User{
+		Email:       fmt.Sprintf("%s@%s", user.ID, entity.DeletedEmailDomain),
+		DisplayName: deletedUserDisplayName,
+		Gender:      entity.NotSay,
+		Role:        entity.RoleUser,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrUserNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (uc *accountUseCase) findUser(userID string) (*entity.User, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidUserID
+	}
+
+	user, err := uc.userRepository.FindById(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// dataExportURL is the link to download an export with its secret code.
+func (uc *accountUseCase) dataExportURL(exportID uuid.UUID, secretCode string) string {
+	query := url.Values{}
+	query.Set("code", secretCode)
+
+	return fmt.Sprintf("%s/%s?%s", uc.downloadURL, exportID, query.Encode())
+}
+
+// withContext returns a copy of the use case whose repositories run their queries with ctx.
+func (uc *accountUseCase) withContext(ctx context.Context) *accountUseCase {
+	scoped := *uc
+	scoped.dataExportRepository = repository.WithContext(ctx, uc.dataExportRepository)
+	scoped.userRepository = repository.WithContext(ctx, uc.userRepository)
+	scoped.projectRepository = repository.WithContext(ctx, uc.projectRepository)
+	return &scoped
+}
@@ -0,0 +1,480 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fund-o/api-server/internal/datasource/repository"
+	"fund-o/api-server/internal/entity"
+	"fund-o/api-server/pkg/chain"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+const (
+	projectContractsCursor  = "project_contracts"
+	defaultIndexerBatchSize = 500
+)
+
+var chainEventTopics = map[string]entity.ChainEventType{
+	chain.EventProjectCreated: entity.ChainEventProjectCreated,
+	chain.EventContributed:    entity.ChainEventContributed,
+	chain.EventRefunded:       entity.ChainEventRefunded,
+	chain.EventFundsWithdrawn: entity.ChainEventFundsWithdrawn,
+}
+
+type ChainIndexerUseCase interface {
+	IndexEvents(ctx context.Context) (*entity.ChainIndexResult, error)
+}
+
+type chainIndexerUseCase struct {
+	chainEventRepository repository.ChainEventRepository
+	projectRepository    repository.ProjectRepository
+	userRepository       repository.UserRepository
+	chainClient          chain.Client
+	factoryAddress       string
+	startBlock           uint64
+	confirmations        uint64
+	batchSize            uint64
+}
+
+type ChainIndexerUseCaseOptions struct {
+	repository.ChainEventRepository
+	repository.ProjectRepository
+	repository.UserRepository
+	ChainClient chain.Client
+	// FactoryAddress is the contract emitting ProjectCreated. ProjectCreated events are not
+	// indexed when it is empty.
+	FactoryAddress string
+	StartBlock     uint64
+	Confirmations  uint64
+	BatchSize      uint64
+}
+
+func NewChainIndexerUseCase(options *ChainIndexerUseCaseOptions) ChainIndexerUseCase {
+	batchSize := options.BatchSize
+	if batchSize == 0 {
+		batchSize = defaultIndexerBatchSize
+	}
+
+	return &chainIndexerUseCase{
+		chainEventRepository: options.ChainEventRepository,
+		projectRepository:    options.ProjectRepository,
+		userRepository:       options.UserRepository,
+		chainClient:          options.ChainClient,
+		factoryAddress:       options.FactoryAddress,
+		startBlock:           options.StartBlock,
+		confirmations:        options.Confirmations,
+		batchSize:            batchSize,
+	}
+}
+
+// IndexEvents runs one indexing round: it rolls back unconfirmed events if the chain reorganized
+// since the last round, stores the events of the next block range and reconciles the events
+// that are now deep enough to be considered final.
+func (uc *chainIndexerUseCase) IndexEvents(ctx context.Context) (*entity.ChainIndexResult, error) {
+	uc = uc.withContext(ctx)
+
+	head, err := uc.chainClient.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block number: %w", err)
+	}
+
+	cursor, err := uc.findCursor()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.ChainIndexResult{}
+
+	reorged, err := uc.isReorged(ctx, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if reorged {
+		cursor, result.RolledBack, err = uc.rollback(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cursor.BlockNumber < head {
+		result.FromBlock = cursor.BlockNumber + 1
+		result.ToBlock = min(head, cursor.BlockNumber+uc.batchSize)
+
+		result.Indexed, err = uc.indexRange(ctx, result.FromBlock, result.ToBlock)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if head >= uc.confirmations {
+		result.Confirmed, err = uc.confirmEvents(head - uc.confirmations)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// withContext returns a copy of the use case whose repositories run their queries with ctx.
+func (uc *chainIndexerUseCase) withContext(ctx context.Context) *chainIndexerUseCase {
+	scoped := *uc
+	scoped.chainEventRepository = repository.WithContext(ctx, uc.chainEventRepository)
+	scoped.projectRepository = repository.WithContext(ctx, uc.projectRepository)
+	scoped.userRepository = repository.WithContext(ctx, uc.userRepository)
+	return &scoped
+}
+
+func (uc *chainIndexerUseCase) findCursor() (*entity.ChainCursor, error) {
+	cursor, err := uc.chainEventRepository.FindCursor(projectContractsCursor)
+	if err == nil {
+		return cursor, nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// Nothing has been indexed yet, so start right before the configured block.
+	cursor = &entity.ChainCursor{Name: projectContractsCursor}
+	if uc.startBlock > 0 {
+		cursor.BlockNumber = uc.startBlock - 1
+	}
+
+	return cursor, nil
+}
+
+// isReorged reports whether the block the cursor points at is no longer part of the chain.
+func (uc *chainIndexerUseCase) isReorged(ctx context.Context, cursor *entity.ChainCursor) (bool, error) {
+	if cursor.BlockHash == "" {
+		return false, nil
+	}
+
+	hash, err := uc.chainClient.BlockHash(ctx, cursor.BlockNumber)
+	if err != nil {
+		if errors.Is(err, chain.ErrBlockNotFound) {
+			return true, nil
+		}
+
+		return false, fmt.Errorf("failed to get block hash: %w", err)
+	}
+
+	return hash != cursor.BlockHash, nil
+}
+
+// rollback rewinds the cursor by the confirmation depth and drops the unconfirmed events above it.
+// Reorganizations deeper than the confirmation depth are not supported.
+func (uc *chainIndexerUseCase) rollback(ctx context.Context, cursor *entity.ChainCursor) (*entity.ChainCursor, int64, error) {
+	safe := &entity.ChainCursor{Name: cursor.Name}
+	if cursor.BlockNumber > uc.confirmations {
+		safe.BlockNumber = cursor.BlockNumber - uc.confirmations
+	}
+
+	hash, err := uc.chainClient.BlockHash(ctx, safe.BlockNumber)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get block hash: %w", err)
+	}
+	safe.BlockHash = hash
+
+	deleted, err := uc.chainEventRepository.Rollback(safe)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return safe, deleted, nil
+}
+
+func (uc *chainIndexerUseCase) indexRange(ctx context.Context, from, to uint64) (int, error) {
+	hash, err := uc.chainClient.BlockHash(ctx, to)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block hash: %w", err)
+	}
+
+	addresses, err := uc.watchedAddresses()
+	if err != nil {
+		return 0, err
+	}
+
+	var logs []chain.Log
+	// An empty address list would match every contract on the chain.
+	if len(addresses) > 0 {
+		topics := make([]string, 0, len(chainEventTopics))
+		for topic := range chainEventTopics {
+			topics = append(topics, topic)
+		}
+
+		logs, err = uc.chainClient.FilterLogs(ctx, chain.LogQuery{
+			FromBlock: from,
+			ToBlock:   to,
+			Addresses: addresses,
+			Topics:    topics,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to filter logs: %w", err)
+		}
+	}
+
+	events := make([]entity.ChainEvent, 0, len(logs))
+	for _, log := range logs {
+		// The last block changed while the logs were read; retry the range on the next round.
+		if log.BlockNumber == to && log.BlockHash != hash {
+			return 0, fmt.Errorf("block %d changed while indexing", to)
+		}
+
+		event, err := parseChainEvent(log)
+		if err != nil {
+			continue
+		}
+
+		events = append(events, *event)
+	}
+
+	err = uc.chainEventRepository.SaveEvents(&entity.ChainCursor{
+		Name:        projectContractsCursor,
+		BlockNumber: to,
+		BlockHash:   hash,
+	}, events)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(events), nil
+}
+
+// watchedAddresses returns the contracts the indexer reads events from: the factory and the
+// contract of every known project.
+func (uc *chainIndexerUseCase) watchedAddresses() ([]string, error) {
+	addresses, err := uc.projectRepository.FindContractAddresses()
+	if err != nil {
+		return nil, err
+	}
+
+	if uc.factoryAddress != "" {
+		addresses = append(addresses, uc.factoryAddress)
+	}
+
+	return addresses, nil
+}
+
+func (uc *chainIndexerUseCase) confirmEvents(maxBlockNumber uint64) (int, error) {
+	events, err := uc.chainEventRepository.FindUnconfirmed(maxBlockNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, event := range events {
+		if err := uc.reconcile(&event); err != nil {
+			return i, err
+		}
+
+		if err := uc.chainEventRepository.MarkConfirmed(event.ID); err != nil {
+			return i, err
+		}
+	}
+
+	return len(events), nil
+}
+
+// reconcile brings the records an event is about in line with the chain.
+func (uc *chainIndexerUseCase) reconcile(event *entity.ChainEvent) error {
+	switch event.Type {
+	case entity.ChainEventContributed:
+		return uc.reconcileContribution(event)
+	case entity.ChainEventRefunded:
+		return uc.reconcileRefund(event)
+	case entity.ChainEventFundsWithdrawn:
+		return uc.reconcileWithdrawal(event)
+	default:
+		return nil
+	}
+}
+
+// reconcileContribution settles the backer record of a final Contributed event. A pending
+// backer is confirmed or rejected, and a contribution made outside of the API is recorded
+// when its sender is a known user.
+func (uc *chainIndexerUseCase) reconcileContribution(event *entity.ChainEvent) error {
+	project, err := uc.projectRepository.FindByContractID(event.ContractAddress)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	backer, err := uc.projectRepository.FindProjectBackerByTxHash(event.TxHash)
+	if err == nil {
+		if backer.Status != entity.BackerPending || backer.ProjectID != project.ID {
+			return nil
+		}
+
+		user, err := uc.userRepository.FindById(backer.UserID)
+		if err != nil {
+			return err
+		}
+
+		// The contribution must come from one of the backer's wallets, otherwise anyone could
+		// claim the transaction of another user.
+		status := entity.BackerConfirmed
+		if !backer.Amount.Equal(event.Amount) || !user.HasWallet(event.Account) {
+			status = entity.BackerRejected
+		}
+
+		updated, err := uc.projectRepository.UpdateProjectBackerStatus(backer.ID, entity.BackerPending, status)
+		if err != nil {
+			return err
+		}
+
+		if updated && status == entity.BackerConfirmed {
+			recordContribution(project.Currency, backer.Amount)
+		}
+
+		return nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	account, err := chain.ChecksumAddress(event.Account)
+	if err != nil {
+		return nil
+	}
+
+	user, err := uc.userRepository.FindByWalletAddress(account)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	txHash := event.TxHash
+	_, err = uc.projectRepository.CreateProjectBacker(&entity.ProjectBacker{
+		ProjectID: project.ID,
+		UserID:    user.ID,
+		Amount:    event.Amount,
+		TxHash:    &txHash,
+		Status:    entity.BackerConfirmed,
+	})
+	if err != nil {
+		return err
+	}
+
+	recordContribution(project.Currency, event.Amount)
+	return nil
+}
+
+// reconcileRefund settles the open refund a final Refunded event pays back. The refund is
+// matched by project, amount and the user owning the receiving wallet; refunds the event
+// cannot be matched with are left to the refund task.
+func (uc *chainIndexerUseCase) reconcileRefund(event *entity.ChainEvent) error {
+	project, err := uc.projectRepository.FindByContractID(event.ContractAddress)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	account, err := chain.ChecksumAddress(event.Account)
+	if err != nil {
+		return nil
+	}
+
+	user, err := uc.userRepository.FindByWalletAddress(account)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	refund, err := uc.projectRepository.FindOpenRefund(project.ID, user.ID, event.Amount)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	if refund.Status == entity.RefundRequested {
+		if _, err := uc.projectRepository.UpdateRefundStatus(refund.ID, entity.RefundRequested, entity.RefundProcessing); err != nil {
+			return err
+		}
+	}
+
+	_, err = uc.projectRepository.CompleteRefund(refund.ID, event)
+	return err
+}
+
+// reconcileWithdrawal marks a live project as succeeded once its owner withdrew the funds. The
+// contract only releases the funds of a project that reached its goal.
+func (uc *chainIndexerUseCase) reconcileWithdrawal(event *entity.ChainEvent) error {
+	project, err := uc.projectRepository.FindByContractID(event.ContractAddress)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	if project.Status != entity.ProjectLive {
+		return nil
+	}
+
+	_, err = uc.projectRepository.UpdateStatus(project.ID, entity.ProjectLive, entity.ProjectSucceeded)
+	return err
+}
+
+func parseChainEvent(log chain.Log) (*entity.ChainEvent, error) {
+	if len(log.Topics) == 0 {
+		return nil, chain.ErrMalformedLog
+	}
+
+	eventType, ok := chainEventTopics[strings.ToLower(log.Topics[0])]
+	if !ok {
+		return nil, chain.ErrMalformedLog
+	}
+
+	contractAddress := log.Address
+	account, err := log.TopicAddress(1)
+	if err != nil {
+		return nil, err
+	}
+
+	// ProjectCreated is emitted by the factory: the project contract is the first indexed
+	// parameter and the owner the second.
+	if eventType == entity.ChainEventProjectCreated {
+		contractAddress = account
+		if account, err = log.TopicAddress(2); err != nil {
+			return nil, err
+		}
+	}
+
+	amount, err := log.DataUint(0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ChainEvent{
+		Type:            eventType,
+		ContractAddress: contractAddress,
+		Account:         account,
+		Amount:          decimal.NewFromBigInt(amount, -18),
+		TxHash:          log.TxHash,
+		LogIndex:        log.LogIndex,
+		BlockNumber:     log.BlockNumber,
+		BlockHash:       log.BlockHash,
+	}, nil
+}
@@ -0,0 +1,316 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"fund-o/api-server/internal/entity"
+	"fund-o/api-server/internal/usecase"
+	"fund-o/api-server/mocks"
+	"fund-o/api-server/pkg/chain"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestChainIndexerIndexEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chainEventRepository := mocks.NewMockChainEventRepository(ctrl)
+	projectRepository := mocks.NewMockProjectRepository(ctrl)
+	userRepository := mocks.NewMockUserRepository(ctrl)
+	client := chain.NewMemoryClient()
+
+	indexer := usecase.NewChainIndexerUseCase(&usecase.ChainIndexerUseCaseOptions{
+		ChainEventRepository: chainEventRepository,
+		ProjectRepository:    projectRepository,
+		UserRepository:       userRepository,
+		ChainClient:          client,
+		Confirmations:        2,
+	})
+
+	project := entity.Project{Base: entity.Base{ID: uuid.New()}, ProjectContractID: "0x" + strings.Repeat("22", 20)}
+	wallet := "0x" + strings.Repeat("11", 20)
+	txHash := "0x" + strings.Repeat("ab", 32)
+	user := entity.User{Base: entity.Base{ID: uuid.New()}, Wallets: []entity.UserWallet{{Address: wallet}}}
+	backer := entity.ProjectBacker{
+		Base:      entity.Base{ID: uuid.New()},
+		ProjectID: project.ID,
+		UserID:    user.ID,
+		Amount:    decimal.NewFromFloat(1.5),
+		TxHash:    &txHash,
+		Status:    entity.BackerPending,
+	}
+
+	// The second log comes from a contract the indexer does not know about.
+	client.AddBlock("a",
+		contributedLog(project.ProjectContractID, wallet, txHash, decimal.NewFromFloat(1.5)),
+		contributedLog("0x"+strings.Repeat("99", 20), wallet, "0x"+strings.Repeat("cd", 32), decimal.NewFromFloat(3)),
+	)
+	client.AddBlock("a")
+	client.AddBlock("a")
+
+	var saved []entity.ChainEvent
+	chainEventRepository.EXPECT().
+		FindCursor(gomock.Eq("project_contracts")).
+		Times(1).
+		Return(nil, gorm.ErrRecordNotFound)
+	projectRepository.EXPECT().
+		FindContractAddresses().
+		Times(1).
+		Return([]string{project.ProjectContractID}, nil)
+	chainEventRepository.EXPECT().
+		SaveEvents(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(cursor *entity.ChainCursor, events []entity.ChainEvent) error {
+			require.Equal(t, uint64(3), cursor.BlockNumber)
+			saved = events
+			return nil
+		})
+	chainEventRepository.EXPECT().
+		FindUnconfirmed(gomock.Eq(uint64(1))).
+		Times(1).
+		DoAndReturn(func(uint64) ([]entity.ChainEvent, error) {
+			return saved, nil
+		})
+	projectRepository.EXPECT().
+		FindByContractID(gomock.Eq(project.ProjectContractID)).
+		Times(1).
+		Return(&project, nil)
+	projectRepository.EXPECT().
+		FindProjectBackerByTxHash(gomock.Eq(txHash)).
+		Times(1).
+		Return(&backer, nil)
+	userRepository.EXPECT().
+		FindById(gomock.Eq(user.ID)).
+		Times(1).
+		Return(&user, nil)
+	projectRepository.EXPECT().
+		UpdateProjectBackerStatus(gomock.Eq(backer.ID), gomock.Eq(entity.BackerPending), gomock.Eq(entity.BackerConfirmed)).
+		Times(1).
+		Return(true, nil)
+	chainEventRepository.EXPECT().
+		MarkConfirmed(gomock.Any()).
+		Times(1).
+		Return(nil)
+
+	result, err := indexer.IndexEvents(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Indexed)
+	require.Equal(t, 1, result.Confirmed)
+	require.Len(t, saved, 1)
+	require.Equal(t, entity.ChainEventContributed, saved[0].Type)
+	require.Equal(t, wallet, saved[0].Account)
+	require.True(t, decimal.NewFromFloat(1.5).Equal(saved[0].Amount))
+
+	// Replace the last block with a fork: the indexer must rewind by the confirmation depth.
+	staleHash, err := client.BlockHash(context.Background(), 3)
+	require.NoError(t, err)
+	client.Reorg(3)
+	client.AddBlock("b")
+	client.AddBlock("b")
+
+	chainEventRepository.EXPECT().
+		FindCursor(gomock.Eq("project_contracts")).
+		Times(1).
+		Return(&entity.ChainCursor{Name: "project_contracts", BlockNumber: 3, BlockHash: staleHash}, nil)
+	chainEventRepository.EXPECT().
+		Rollback(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(cursor *entity.ChainCursor) (int64, error) {
+			require.Equal(t, uint64(1), cursor.BlockNumber)
+			return 2, nil
+		})
+	projectRepository.EXPECT().
+		FindContractAddresses().
+		Times(1).
+		Return([]string{project.ProjectContractID}, nil)
+	chainEventRepository.EXPECT().
+		SaveEvents(gomock.Any(), gomock.Len(0)).
+		Times(1).
+		DoAndReturn(func(cursor *entity.ChainCursor, _ []entity.ChainEvent) error {
+			require.Equal(t, uint64(4), cursor.BlockNumber)
+			return nil
+		})
+	chainEventRepository.EXPECT().
+		FindUnconfirmed(gomock.Eq(uint64(2))).
+		Times(1).
+		Return(nil, nil)
+
+	result, err = indexer.IndexEvents(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.RolledBack)
+	require.Equal(t, uint64(2), result.FromBlock)
+	require.Equal(t, uint64(4), result.ToBlock)
+}
+
+func TestChainIndexerReconcileEvents(t *testing.T) {
+	project := entity.Project{
+		Base:              entity.Base{ID: uuid.New()},
+		ProjectContractID: "0x" + strings.Repeat("22", 20),
+		Status:            entity.ProjectLive,
+	}
+	wallet := "0x" + strings.Repeat("11", 20)
+	user := entity.User{Base: entity.Base{ID: uuid.New()}, Wallets: []entity.UserWallet{{Address: wallet}}}
+	amount := decimal.NewFromFloat(1.5)
+
+	testCases := []struct {
+		name       string
+		event      entity.ChainEvent
+		buildStubs func(projectRepo *mocks.MockProjectRepository, userRepo *mocks.MockUserRepository, event *entity.ChainEvent)
+	}{
+		{
+			name: "ContributionFromAnotherWallet",
+			event: entity.ChainEvent{
+				Type:            entity.ChainEventContributed,
+				ContractAddress: project.ProjectContractID,
+				Account:         "0x" + strings.Repeat("33", 20),
+				Amount:          amount,
+				TxHash:          "0x" + strings.Repeat("ab", 32),
+			},
+			buildStubs: func(projectRepo *mocks.MockProjectRepository, userRepo *mocks.MockUserRepository, event *entity.ChainEvent) {
+				backer := entity.ProjectBacker{
+					Base:      entity.Base{ID: uuid.New()},
+					ProjectID: project.ID,
+					UserID:    user.ID,
+					Amount:    amount,
+					TxHash:    &event.TxHash,
+					Status:    entity.BackerPending,
+				}
+				projectRepo.EXPECT().
+					FindByContractID(gomock.Eq(project.ProjectContractID)).
+					Times(1).
+					Return(&project, nil)
+				projectRepo.EXPECT().
+					FindProjectBackerByTxHash(gomock.Eq(event.TxHash)).
+					Times(1).
+					Return(&backer, nil)
+				userRepo.EXPECT().
+					FindById(gomock.Eq(user.ID)).
+					Times(1).
+					Return(&user, nil)
+				projectRepo.EXPECT().
+					UpdateProjectBackerStatus(gomock.Eq(backer.ID), gomock.Eq(entity.BackerPending), gomock.Eq(entity.BackerRejected)).
+					Times(1).
+					Return(true, nil)
+			},
+		},
+		{
+			name: "Refunded",
+			event: entity.ChainEvent{
+				Base:            entity.Base{ID: uuid.New()},
+				Type:            entity.ChainEventRefunded,
+				ContractAddress: project.ProjectContractID,
+				Account:         wallet,
+				Amount:          amount,
+				TxHash:          "0x" + strings.Repeat("ef", 32),
+			},
+			buildStubs: func(projectRepo *mocks.MockProjectRepository, userRepo *mocks.MockUserRepository, event *entity.ChainEvent) {
+				refund := entity.ProjectRefund{
+					Base:      entity.Base{ID: uuid.New()},
+					ProjectID: project.ID,
+					UserID:    user.ID,
+					Amount:    amount,
+					Status:    entity.RefundRequested,
+				}
+				projectRepo.EXPECT().
+					FindByContractID(gomock.Eq(project.ProjectContractID)).
+					Times(1).
+					Return(&project, nil)
+				userRepo.EXPECT().
+					FindByWalletAddress(gomock.Any()).
+					Times(1).
+					Return(&user, nil)
+				projectRepo.EXPECT().
+					FindOpenRefund(gomock.Eq(project.ID), gomock.Eq(user.ID), gomock.Eq(amount)).
+					Times(1).
+					Return(&refund, nil)
+				projectRepo.EXPECT().
+					UpdateRefundStatus(gomock.Eq(refund.ID), gomock.Eq(entity.RefundRequested), gomock.Eq(entity.RefundProcessing)).
+					Times(1).
+					Return(true, nil)
+				projectRepo.EXPECT().
+					CompleteRefund(gomock.Eq(refund.ID), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ uuid.UUID, completedBy *entity.ChainEvent) (bool, error) {
+						require.Equal(t, event.ID, completedBy.ID)
+						return true, nil
+					})
+			},
+		},
+		{
+			name: "FundsWithdrawn",
+			event: entity.ChainEvent{
+				Type:            entity.ChainEventFundsWithdrawn,
+				ContractAddress: project.ProjectContractID,
+				Account:         wallet,
+				Amount:          amount,
+				TxHash:          "0x" + strings.Repeat("fe", 32),
+			},
+			buildStubs: func(projectRepo *mocks.MockProjectRepository, userRepo *mocks.MockUserRepository, event *entity.ChainEvent) {
+				projectRepo.EXPECT().
+					FindByContractID(gomock.Eq(project.ProjectContractID)).
+					Times(1).
+					Return(&project, nil)
+				projectRepo.EXPECT().
+					UpdateStatus(gomock.Eq(project.ID), gomock.Eq(entity.ProjectLive), gomock.Eq(entity.ProjectSucceeded)).
+					Times(1).
+					Return(true, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			chainEventRepository := mocks.NewMockChainEventRepository(ctrl)
+			projectRepository := mocks.NewMockProjectRepository(ctrl)
+			userRepository := mocks.NewMockUserRepository(ctrl)
+			client := chain.NewMemoryClient()
+			client.AddBlock("a")
+
+			indexer := usecase.NewChainIndexerUseCase(&usecase.ChainIndexerUseCaseOptions{
+				ChainEventRepository: chainEventRepository,
+				ProjectRepository:    projectRepository,
+				UserRepository:       userRepository,
+				ChainClient:          client,
+			})
+
+			event := tc.event
+			chainEventRepository.EXPECT().
+				FindCursor(gomock.Any()).
+				Times(1).
+				Return(&entity.ChainCursor{Name: "project_contracts", BlockNumber: 1}, nil)
+			chainEventRepository.EXPECT().
+				FindUnconfirmed(gomock.Eq(uint64(1))).
+				Times(1).
+				Return([]entity.ChainEvent{event}, nil)
+			tc.buildStubs(projectRepository, userRepository, &event)
+			chainEventRepository.EXPECT().
+				MarkConfirmed(gomock.Eq(event.ID)).
+				Times(1).
+				Return(nil)
+
+			result, err := indexer.IndexEvents(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, 1, result.Confirmed)
+		})
+	}
+}
+
+func contributedLog(contract, backer, txHash string, amount decimal.Decimal) chain.Log {
+	wei := amount.Shift(18).BigInt()
+	return chain.Log{
+		Address: contract,
+		Topics:  []string{chain.EventContributed, "0x" + strings.Repeat("0", 24) + backer[2:]},
+		Data:    fmt.Sprintf("0x%064s", wei.Text(16)),
+		TxHash:  txHash,
+	}
+}
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fund-o/api-server/internal/datasource/repository"
+	"fund-o/api-server/internal/entity"
+	"fund-o/api-server/pkg/apperrors"
+	"fund-o/api-server/pkg/password"
+	"fund-o/api-server/pkg/random"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	emailChangeTTL        = 30 * time.Minute
+	emailChangeSecretSize = 32
+	defaultEmailChangeURL = "http://localhost:5173/confirm-email"
+)
+
+type EmailChangeUseCase interface {
+	RequestEmailChange(userID string, payload *entity.EmailChangePayload) error
+	CreateEmailChange(ctx context.Context, userID string, newEmail string) (*entity.EmailChangeLink, error)
+	ConfirmEmailChange(payload *entity.EmailChangeConfirmPayload) (*entity.UserDto, error)
+}
+
+type emailChangeUseCase struct {
+	emailChangeRepository repository.EmailChangeRepository
+	userRepository        repository.UserRepository
+	confirmURL            string
+}
+
+type EmailChangeUseCaseOptions struct {
+	repository.EmailChangeRepository
+	repository.UserRepository
+	ConfirmURL string
+}
+
+func NewEmailChangeUseCase(options *EmailChangeUseCaseOptions) EmailChangeUseCase {
+	confirmURL := options.ConfirmURL
+	if confirmURL == "" {
+		confirmURL = defaultEmailChangeURL
+	}
+
+	return &emailChangeUseCase{
+		emailChangeRepository: options.EmailChangeRepository,
+		userRepository:        options.UserRepository,
+		confirmURL:            confirmURL,
+	}
+}
+
+// RequestEmailChange checks that the user may move their account to the new email. Accounts with a
+// password have to enter it again, so a stolen session alone cannot take the account over.
+func (uc *emailChangeUseCase) RequestEmailChange(userID string, payload *entity.EmailChangePayload) error {
+	user, err := uc.findUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(user.Email, payload.Email) {
+		return apperrors.ErrSameEmail
+	}
+
+	if user.HashedPassword != "" {
+		if err := password.CheckPassword(payload.Password, user.HashedPassword); err != nil {
+			return apperrors.ErrIncorrectPassword
+		}
+	}
+
+	return nil
+}
+
+// CreateEmailChange issues a confirmation link for moving the user to the new email. The link is
+// only sent to the new address; the current email is kept until it is followed.
+func (uc *emailChangeUseCase) CreateEmailChange(ctx context.Context, userID string, newEmail string) (*entity.EmailChangeLink, error) {
+	uc = uc.withContext(ctx)
+
+	user, err := uc.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.checkEmailAvailable(newEmail); err != nil {
+		return nil, err
+	}
+
+	secretCode, err := random.NewSecret(emailChangeSecretSize)
+	if err != nil {
+		return nil, err
+	}
+
+	emailChange, err := uc.emailChangeRepository.Create(&entity.EmailChange{
+		UserID:         user.ID,
+		NewEmail:       newEmail,
+		SecretCodeHash: hashSecretCode(secretCode),
+		ExpiredAt:      time.Now().Add(emailChangeTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("id", emailChange.ID.String())
+	query.Set("code", secretCode)
+
+	return &entity.EmailChangeLink{
+		OldEmail: user.Email,
+		NewEmail: newEmail,
+		URL:      uc.confirmURL + "?" + query.Encode(),
+	}, nil
+}
+
+// ConfirmEmailChange moves the user to the new email of a confirmation link. Following the link
+// proves the user owns the address, so it is marked as verified.
+func (uc *emailChangeUseCase) ConfirmEmailChange(payload *entity.EmailChangeConfirmPayload) (*entity.UserDto, error) {
+	id, err := uuid.Parse(payload.ID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidEmailChange
+	}
+
+	emailChange, err := uc.emailChangeRepository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrInvalidEmailChange
+		}
+
+		return nil, err
+	}
+
+	hash := hashSecretCode(payload.SecretCode)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(emailChange.SecretCodeHash)) != 1 || emailChange.IsUsed {
+		return nil, apperrors.ErrInvalidEmailChange
+	}
+
+	if emailChange.ExpiredAt.Before(time.Now()) {
+		return nil, apperrors.ErrEmailChangeExpired
+	}
+
+	// Another account may have taken the email since the link was sent.
+	if err := uc.checkEmailAvailable(emailChange.NewEmail); err != nil {
+		return nil, err
+	}
+
+	used, err := uc.emailChangeRepository.MarkUsed(emailChange.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !used {
+		return nil, apperrors.ErrInvalidEmailChange
+	}
+
+	user, err := uc.userRepository.UpdateByID(emailChange.UserID, &entity.User{
+		Email:           emailChange.NewEmail,
+		IsEmailVerified: true,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	return user.ToUserDto(), nil
+}
+
+func (uc *emailChangeUseCase) findUser(userID string) (*entity.User, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidUserID
+	}
+
+	user, err := uc.userRepository.FindById(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (uc *emailChangeUseCase) checkEmailAvailable(email string) error {
+	_, err := uc.userRepository.FindByEmail(email)
+	if err == nil {
+		return apperrors.ErrEmailAlreadyUsed
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// withContext returns a copy of the use case whose repositories run their queries with ctx.
+func (uc *emailChangeUseCase) withContext(ctx context.Context) *emailChangeUseCase {
+	scoped := *uc
+	scoped.emailChangeRepository = repository.WithContext(ctx, uc.emailChangeRepository)
+	scoped.userRepository = repository.WithContext(ctx, uc.userRepository)
+	return &scoped
+}
@@ -15,6 +15,7 @@ type ForumUseCase interface {
 	ListForums(paginateOptions pagination.PaginateOptions) pagination.PaginateResult[entity.PostDto]
 	CreatePost(payload *entity.PostCreatePayload) (*entity.PostDto, error)
 	GetPostByID(id string) (*entity.PostDto, error)
+	DeletePost(id string) apperrors.Error
 	CreateCommentByForumID(forumID string, comment *entity.CommentCreatePayload) (*entity.CommentDto, error)
 	CreateReplyByCommentID(commentID string, payload *entity.ReplyCreatePayload) (*entity.ReplyDto, error)
 	UploadPostImage(file *multipart.FileHeader) (string, apperrors.Error)
@@ -82,6 +83,25 @@ func (uc *forumUseCase) GetPostByID(id string) (*entity.PostDto, error) {
 	return forum.ToPostDto(), nil
 }
 
+// DeletePost removes a post on behalf of a moderator.
+func (uc *forumUseCase) DeletePost(id string) apperrors.Error {
+	postID, err := uuid.Parse(id)
+	if err != nil {
+		return apperrors.New(http.StatusBadRequest, apperrors.ErrInvalidPostID.Error())
+	}
+
+	deleted, err := uc.forumRepository.DeletePost(postID)
+	if err != nil {
+		return apperrors.New(http.StatusInternalServerError, "Failed to delete post")
+	}
+
+	if !deleted {
+		return apperrors.New(http.StatusNotFound, apperrors.ErrPostNotFound.Error())
+	}
+
+	return nil
+}
+
 func (uc *forumUseCase) CreateCommentByForumID(postID string, payload *entity.CommentCreatePayload) (*entity.CommentDto, error) {
 	comment, err := uc.forumRepository.CreateComment(&entity.Comment{
 		Content:  payload.Content,
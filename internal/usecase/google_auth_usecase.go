@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fund-o/api-server/internal/datasource/repository"
+	"fund-o/api-server/internal/entity"
+	"fund-o/api-server/pkg/apperrors"
+	"fund-o/api-server/pkg/oauth"
+	"fund-o/api-server/pkg/random"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	googleStateTTL           = 10 * time.Minute
+	googleStateSubjectPrefix = "oauth:google:"
+)
+
+type GoogleAuthUseCase interface {
+	CreateAuthCodeURL(ctx context.Context) (*entity.OAuthAuthorization, error)
+	AuthenticateGoogle(ctx context.Context, payload *entity.OAuthCallbackPayload) (*entity.UserDto, error)
+}
+
+type googleAuthUseCase struct {
+	userRepository  repository.UserRepository
+	nonceRepository repository.NonceRepository
+	provider        oauth.Provider
+}
+
+type GoogleAuthUseCaseOptions struct {
+	repository.UserRepository
+	repository.NonceRepository
+	Provider oauth.Provider
+}
+
+func NewGoogleAuthUseCase(options *GoogleAuthUseCaseOptions) GoogleAuthUseCase {
+	return &googleAuthUseCase{
+		userRepository:  options.UserRepository,
+		nonceRepository: options.NonceRepository,
+		provider:        options.Provider,
+	}
+}
+
+// CreateAuthCodeURL starts a Google sign in. The state is stored together with the PKCE verifier,
+// so the callback can only be completed once and only for a sign in this server started.
+func (uc *googleAuthUseCase) CreateAuthCodeURL(ctx context.Context) (*entity.OAuthAuthorization, error) {
+	uc = uc.withContext(ctx)
+
+	state, err := random.NewSecret(16)
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := oauth.NewCodeVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.nonceRepository.Create(state, googleStateSubjectPrefix+verifier, googleStateTTL); err != nil {
+		return nil, err
+	}
+
+	return &entity.OAuthAuthorization{
+		URL:       uc.provider.AuthCodeURL(state, oauth.CodeChallenge(verifier)),
+		State:     state,
+		ExpiredAt: time.Now().Add(googleStateTTL),
+	}, nil
+}
+
+// AuthenticateGoogle completes a Google sign in and returns the user the Google account belongs
+// to. An unknown Google account is linked to the user with the same verified email, or gets a
+// new user.
+func (uc *googleAuthUseCase) AuthenticateGoogle(ctx context.Context, payload *entity.OAuthCallbackPayload) (*entity.UserDto, error) {
+	uc = uc.withContext(ctx)
+
+	subject, err := uc.nonceRepository.Consume(payload.State)
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, ok := strings.CutPrefix(subject, googleStateSubjectPrefix)
+	if !ok || verifier == "" {
+		return nil, apperrors.ErrInvalidOAuthState
+	}
+
+	accessToken, err := uc.provider.Exchange(ctx, payload.Code, verifier)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := uc.provider.UserInfo(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepository.FindByIdentity(entity.IdentityProviderGoogle, info.Subject)
+	if err == nil {
+		return user.ToUserDto(), nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if !info.EmailVerified || info.Email == "" {
+		return nil, apperrors.ErrOAuthEmailNotVerified
+	}
+
+	email := info.Email
+	user, err = uc.userRepository.FindByEmail(email)
+	if err == nil {
+		return uc.linkIdentity(user, info)
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	user, err = uc.userRepository.Create(&entity.User{
+		Email:           email,
+		Firstname:       info.GivenName,
+		Lastname:        info.FamilyName,
+		DisplayName:     googleDisplayName(info),
+		ProfileImage:    info.Picture,
+		Gender:          entity.NotSay,
+		Role:            entity.RoleUser,
+		IsEmailVerified: true,
+		Identities: []entity.UserIdentity{{
+			Provider: entity.IdentityProviderGoogle,
+			Subject:  info.Subject,
+			Email:    email,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user.ToUserDto(), nil
+}
+
+// linkIdentity adds the Google account to an existing user. Users whose email is not verified are
+// refused: someone could have registered the address without owning it, and linking would hand
+// the real owner an account whose password the other person knows.
+func (uc *googleAuthUseCase) linkIdentity(user *entity.User, info *oauth.UserInfo) (*entity.UserDto, error) {
+	if !user.IsEmailVerified {
+		return nil, apperrors.ErrOAuthAccountNotVerified
+	}
+
+	_, err := uc.userRepository.CreateIdentity(&entity.UserIdentity{
+		UserID:   user.ID,
+		Provider: entity.IdentityProviderGoogle,
+		Subject:  info.Subject,
+		Email:    user.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user.ToUserDto(), nil
+}
+
+// withContext returns a copy of the use case whose repositories run their queries with ctx.
+func (uc *googleAuthUseCase) withContext(ctx context.Context) *googleAuthUseCase {
+	scoped := *uc
+	scoped.userRepository = repository.WithContext(ctx, uc.userRepository)
+	scoped.nonceRepository = repository.WithContext(ctx, uc.nonceRepository)
+	return &scoped
+}
+
+func googleDisplayName(info *oauth.UserInfo) string {
+	if info.Name != "" {
+		return info.Name
+	}
+
+	name, _, _ := strings.Cut(info.Email, "@")
+	return name
+}
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"fund-o/api-server/internal/datasource/repository"
+	"fund-o/api-server/pkg/apperrors"
+	"strings"
+	"time"
+)
+
+const (
+	defaultLoginMaxAttempts     = 5
+	defaultLoginIPMaxAttempts   = 50
+	defaultLoginLockoutDuration = 15 * time.Minute
+	loginBaseDelay              = time.Second
+)
+
+type LoginAttemptUseCase interface {
+	Check(ctx context.Context, email, ip string) (time.Duration, error)
+	RecordFailure(ctx context.Context, email, ip string) (bool, error)
+	RecordSuccess(ctx context.Context, email string) error
+	CheckMfa(ctx context.Context, userID, ip string) (time.Duration, error)
+	RecordMfaFailure(ctx context.Context, userID, ip string) (bool, error)
+	RecordMfaSuccess(ctx context.Context, userID, email string) error
+	LockoutDuration() time.Duration
+}
+
+type loginAttemptUseCase struct {
+	loginAttemptRepository repository.LoginAttemptRepository
+	maxAttempts            int64
+	ipMaxAttempts          int64
+	lockoutDuration        time.Duration
+}
+
+type LoginAttemptUseCaseOptions struct {
+	repository.LoginAttemptRepository
+	MaxAttempts     int
+	IPMaxAttempts   int
+	LockoutDuration time.Duration
+}
+
+func NewLoginAttemptUseCase(options *LoginAttemptUseCaseOptions) LoginAttemptUseCase {
+	maxAttempts := options.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultLoginMaxAttempts
+	}
+
+	ipMaxAttempts := options.IPMaxAttempts
+	if ipMaxAttempts <= 0 {
+		ipMaxAttempts = defaultLoginIPMaxAttempts
+	}
+
+	lockoutDuration := options.LockoutDuration
+	if lockoutDuration <= 0 {
+		lockoutDuration = defaultLoginLockoutDuration
+	}
+
+	return &loginAttemptUseCase{
+		loginAttemptRepository: options.LoginAttemptRepository,
+		maxAttempts:            int64(maxAttempts),
+		ipMaxAttempts:          int64(ipMaxAttempts),
+		lockoutDuration:        lockoutDuration,
+	}
+}
+
+// Check returns apperrors.ErrTooManyLoginAttempts with the time left to wait when logins for the
+// email or from the ip are blocked.
+func (uc *loginAttemptUseCase) Check(ctx context.Context, email, ip string) (time.Duration, error) {
+	return uc.withContext(ctx).check(emailAttemptKey(email), ip)
+}
+
+// RecordFailure counts a failed login. Every failure delays the next attempt for the email twice as
+// long as the one before, and the email is locked out once it reaches the maximum attempts. It
+// reports whether this failure locked the email out. The same happens for an ip trying many
+// emails, with a higher maximum and without the delays.
+func (uc *loginAttemptUseCase) RecordFailure(ctx context.Context, email, ip string) (bool, error) {
+	return uc.withContext(ctx).recordFailure(emailAttemptKey(email), ip)
+}
+
+// RecordSuccess clears the failures of the email. Failures of the ip are kept, so that signing in to
+// one account does not allow guessing the passwords of others.
+func (uc *loginAttemptUseCase) RecordSuccess(ctx context.Context, email string) error {
+	return uc.withContext(ctx).loginAttemptRepository.ResetFailures(emailAttemptKey(email))
+}
+
+// CheckMfa is Check for the two-factor codes of a user, whose challenge is only issued after the
+// first factor was proven.
+func (uc *loginAttemptUseCase) CheckMfa(ctx context.Context, userID, ip string) (time.Duration, error) {
+	return uc.withContext(ctx).check(mfaAttemptKey(userID), ip)
+}
+
+// RecordMfaFailure counts a wrong two-factor code like a failed login, so that knowing the password
+// does not allow guessing codes with a new challenge for every sign in.
+func (uc *loginAttemptUseCase) RecordMfaFailure(ctx context.Context, userID, ip string) (bool, error) {
+	return uc.withContext(ctx).recordFailure(mfaAttemptKey(userID), ip)
+}
+
+// RecordMfaSuccess clears the failures of a sign in that passed its two-factor challenge, both of
+// the codes and of the email, which are kept until then.
+func (uc *loginAttemptUseCase) RecordMfaSuccess(ctx context.Context, userID, email string) error {
+	uc = uc.withContext(ctx)
+
+	if err := uc.loginAttemptRepository.ResetFailures(mfaAttemptKey(userID)); err != nil {
+		return err
+	}
+
+	return uc.loginAttemptRepository.ResetFailures(emailAttemptKey(email))
+}
+
+func (uc *loginAttemptUseCase) LockoutDuration() time.Duration {
+	return uc.lockoutDuration
+}
+
+func (uc *loginAttemptUseCase) check(key, ip string) (time.Duration, error) {
+	retryAfter, err := uc.loginAttemptRepository.LockTTL(key)
+	if err != nil {
+		return 0, err
+	}
+
+	ipRetryAfter, err := uc.loginAttemptRepository.LockTTL(ipAttemptKey(ip))
+	if err != nil {
+		return 0, err
+	}
+
+	retryAfter = max(retryAfter, ipRetryAfter)
+	if retryAfter > 0 {
+		return retryAfter, apperrors.ErrTooManyLoginAttempts
+	}
+
+	return 0, nil
+}
+
+func (uc *loginAttemptUseCase) recordFailure(key, ip string) (bool, error) {
+	if err := uc.recordIPFailure(ip); err != nil {
+		return false, err
+	}
+
+	failures, err := uc.loginAttemptRepository.AddFailure(key, uc.lockoutDuration)
+	if err != nil {
+		return false, err
+	}
+
+	if failures < uc.maxAttempts {
+		return false, uc.loginAttemptRepository.Lock(key, loginBaseDelay<<(failures-1))
+	}
+
+	if err := uc.loginAttemptRepository.Lock(key, uc.lockoutDuration); err != nil {
+		return false, err
+	}
+
+	// The key starts over with a clean slate once the lockout has passed.
+	if err := uc.loginAttemptRepository.ResetFailures(key); err != nil {
+		return false, err
+	}
+
+	return failures == uc.maxAttempts, nil
+}
+
+func (uc *loginAttemptUseCase) recordIPFailure(ip string) error {
+	key := ipAttemptKey(ip)
+	failures, err := uc.loginAttemptRepository.AddFailure(key, uc.lockoutDuration)
+	if err != nil {
+		return err
+	}
+
+	if failures < uc.ipMaxAttempts {
+		return nil
+	}
+
+	if err := uc.loginAttemptRepository.Lock(key, uc.lockoutDuration); err != nil {
+		return err
+	}
+
+	return uc.loginAttemptRepository.ResetFailures(key)
+}
+
+// withContext returns a copy of the use case whose repository runs its commands with ctx.
+func (uc *loginAttemptUseCase) withContext(ctx context.Context) *loginAttemptUseCase {
+	scoped := *uc
+	scoped.loginAttemptRepository = repository.WithContext(ctx, uc.loginAttemptRepository)
+	return &scoped
+}
+
+func emailAttemptKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func mfaAttemptKey(userID string) string {
+	return "mfa:" + userID
+}
+
+func ipAttemptKey(ip string) string {
+	return "ip:" + ip
+}
@@ -0,0 +1,321 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fund-o/api-server/internal/datasource/repository"
+	"fund-o/api-server/internal/entity"
+	"fund-o/api-server/pkg/apperrors"
+	"fund-o/api-server/pkg/password"
+	"fund-o/api-server/pkg/random"
+	"fund-o/api-server/pkg/totp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	totpIssuer             = "FundO"
+	mfaChallengeTTL        = 5 * time.Minute
+	mfaChallengeSubject    = "mfa:"
+	recoveryCodeCount      = 10
+	recoveryCodeHalfLength = 5
+)
+
+type MfaUseCase interface {
+	EnrollTOTP(userID string) (*entity.TOTPEnrollmentResponse, error)
+	ConfirmTOTP(userID string, code string) (*entity.RecoveryCodesResponse, error)
+	DisableTOTP(userID string, code string) error
+	CreateChallenge(ctx context.Context, userID string) (*entity.MfaChallengeResponse, error)
+	ConsumeChallenge(ctx context.Context, mfaToken string) (string, error)
+	VerifyChallenge(userID string, code string) (*entity.UserDto, error)
+}
+
+type mfaUseCase struct {
+	mfaRepository   repository.MfaRepository
+	userRepository  repository.UserRepository
+	nonceRepository repository.NonceRepository
+}
+
+type MfaUseCaseOptions struct {
+	repository.MfaRepository
+	repository.UserRepository
+	repository.NonceRepository
+}
+
+func NewMfaUseCase(options *MfaUseCaseOptions) MfaUseCase {
+	return &mfaUseCase{
+		mfaRepository:   options.MfaRepository,
+		userRepository:  options.UserRepository,
+		nonceRepository: options.NonceRepository,
+	}
+}
+
+// EnrollTOTP generates the secret of a new authenticator. It has no effect on sign in until it is
+// confirmed with a first code.
+func (uc *mfaUseCase) EnrollTOTP(userID string) (*entity.TOTPEnrollmentResponse, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidUserID
+	}
+
+	user, err := uc.userRepository.FindById(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	current, err := uc.mfaRepository.FindTOTPByUserID(id)
+	if err == nil && current.IsConfirmed() {
+		return nil, apperrors.ErrTOTPAlreadyEnabled
+	}
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.mfaRepository.SaveTOTP(&entity.UserTOTP{UserID: id, Secret: secret}); err != nil {
+		return nil, err
+	}
+
+	return &entity.TOTPEnrollmentResponse{
+		Secret:     secret,
+		OtpauthURI: totp.URI(totpIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmTOTP turns on two-factor authentication once the user proved the authenticator works,
+// and returns the recovery codes. They are only ever shown here.
+func (uc *mfaUseCase) ConfirmTOTP(userID string, code string) (*entity.RecoveryCodesResponse, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidUserID
+	}
+
+	current, err := uc.findTOTP(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.IsConfirmed() {
+		return nil, apperrors.ErrTOTPAlreadyEnabled
+	}
+
+	step, ok := totp.Validate(current.Secret, code, time.Now())
+	if !ok {
+		return nil, apperrors.ErrInvalidTOTPCode
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	recoveryCodes := make([]entity.UserRecoveryCode, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+
+		hashedCode, err := password.HashCode(code)
+		if err != nil {
+			return nil, err
+		}
+
+		codes = append(codes, code)
+		recoveryCodes = append(recoveryCodes, entity.UserRecoveryCode{UserID: id, HashedCode: hashedCode})
+	}
+
+	confirmed, err := uc.mfaRepository.ConfirmTOTP(id, step, recoveryCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	if !confirmed {
+		return nil, apperrors.ErrTOTPAlreadyEnabled
+	}
+
+	return &entity.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableTOTP turns off two-factor authentication. A valid code is required so that a stolen
+// access token alone cannot remove the second factor.
+func (uc *mfaUseCase) DisableTOTP(userID string, code string) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return apperrors.ErrInvalidUserID
+	}
+
+	if err := uc.verifyCode(id, code); err != nil {
+		return err
+	}
+
+	_, err = uc.mfaRepository.DeleteTOTP(id)
+	return err
+}
+
+// CreateChallenge issues the token a user exchanges with a code to finish signing in. It returns
+// nil when the user has not turned on two-factor authentication.
+func (uc *mfaUseCase) CreateChallenge(ctx context.Context, userID string) (*entity.MfaChallengeResponse, error) {
+	uc = uc.withContext(ctx)
+
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidUserID
+	}
+
+	current, err := uc.mfaRepository.FindTOTPByUserID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if !current.IsConfirmed() {
+		return nil, nil
+	}
+
+	mfaToken, err := random.NewSecret(32)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.nonceRepository.Create(mfaToken, mfaChallengeSubject+id.String(), mfaChallengeTTL); err != nil {
+		return nil, err
+	}
+
+	return &entity.MfaChallengeResponse{
+		MfaRequired: true,
+		MfaToken:    mfaToken,
+		ExpiredAt:   time.Now().Add(mfaChallengeTTL),
+	}, nil
+}
+
+// ConsumeChallenge redeems the token of a sign in challenge and returns the ID of the user signing
+// in, whose code is checked with VerifyChallenge. The challenge can only be answered once; after a
+// wrong code the user has to sign in again.
+func (uc *mfaUseCase) ConsumeChallenge(ctx context.Context, mfaToken string) (string, error) {
+	subject, err := uc.withContext(ctx).nonceRepository.Consume(mfaToken)
+	if err != nil {
+		return "", err
+	}
+
+	userID, ok := strings.CutPrefix(subject, mfaChallengeSubject)
+	if !ok {
+		return "", apperrors.ErrInvalidMfaToken
+	}
+
+	return userID, nil
+}
+
+// VerifyChallenge checks the code answering the sign in challenge of the user and returns the user
+// signing in.
+func (uc *mfaUseCase) VerifyChallenge(userID string, code string) (*entity.UserDto, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidMfaToken
+	}
+
+	if err := uc.verifyCode(id, code); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepository.FindById(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	return user.ToUserDto(), nil
+}
+
+// verifyCode accepts a code of the confirmed authenticator, or one of the unused recovery codes.
+// Either can be used only once.
+func (uc *mfaUseCase) verifyCode(userID uuid.UUID, code string) error {
+	current, err := uc.findTOTP(userID)
+	if err != nil {
+		return err
+	}
+
+	if !current.IsConfirmed() {
+		return apperrors.ErrTOTPNotEnrolled
+	}
+
+	if step, ok := totp.Validate(current.Secret, code, time.Now()); ok {
+		used, err := uc.mfaRepository.UseTOTPStep(userID, step)
+		if err != nil {
+			return err
+		}
+
+		if !used {
+			return apperrors.ErrInvalidTOTPCode
+		}
+
+		return nil
+	}
+
+	recoveryCodes, err := uc.mfaRepository.FindUnusedRecoveryCodes(userID)
+	if err != nil {
+		return err
+	}
+
+	code = strings.ToLower(strings.TrimSpace(code))
+	for _, recoveryCode := range recoveryCodes {
+		if password.CheckPassword(code, recoveryCode.HashedCode) != nil {
+			continue
+		}
+
+		used, err := uc.mfaRepository.UseRecoveryCode(recoveryCode.ID)
+		if err != nil {
+			return err
+		}
+
+		if used {
+			return nil
+		}
+	}
+
+	return apperrors.ErrInvalidTOTPCode
+}
+
+func (uc *mfaUseCase) findTOTP(userID uuid.UUID) (*entity.UserTOTP, error) {
+	current, err := uc.mfaRepository.FindTOTPByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrTOTPNotEnrolled
+		}
+
+		return nil, err
+	}
+
+	return current, nil
+}
+
+func newRecoveryCode() (string, error) {
+	code, err := random.NewSecret(recoveryCodeHalfLength)
+	if err != nil {
+		return "", err
+	}
+
+	return code[:recoveryCodeHalfLength] + "-" + code[recoveryCodeHalfLength:], nil
+}
+
+// withContext returns a copy of the use case whose repositories run their queries with ctx.
+func (uc *mfaUseCase) withContext(ctx context.Context) *mfaUseCase {
+	scoped := *uc
+	scoped.userRepository = repository.WithContext(ctx, uc.userRepository)
+	scoped.nonceRepository = repository.WithContext(ctx, uc.nonceRepository)
+	return &scoped
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fund-o/api-server/internal/datasource/repository"
+	"fund-o/api-server/internal/entity"
+	"fund-o/api-server/pkg/apperrors"
+	"fund-o/api-server/pkg/password"
+	"fund-o/api-server/pkg/random"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	passwordResetTTL        = 30 * time.Minute
+	passwordResetSecretSize = 32
+	defaultPasswordResetURL = "http://localhost:5173/reset-password"
+)
+
+type PasswordResetUseCase interface {
+	CreatePasswordReset(ctx context.Context, email string) (*entity.PasswordResetLink, error)
+	ResetPassword(payload *entity.PasswordResetPayload) error
+}
+
+type passwordResetUseCase struct {
+	passwordResetRepository repository.PasswordResetRepository
+	userRepository          repository.UserRepository
+	sessionRepository       repository.SessionRepository
+	resetURL                string
+}
+
+type PasswordResetUseCaseOptions struct {
+	repository.PasswordResetRepository
+	repository.UserRepository
+	repository.SessionRepository
+	ResetURL string
+}
+
+func NewPasswordResetUseCase(options *PasswordResetUseCaseOptions) PasswordResetUseCase {
+	resetURL := options.ResetURL
+	if resetURL == "" {
+		resetURL = defaultPasswordResetURL
+	}
+
+	return &passwordResetUseCase{
+		passwordResetRepository: options.PasswordResetRepository,
+		userRepository:          options.UserRepository,
+		sessionRepository:       options.SessionRepository,
+		resetURL:                resetURL,
+	}
+}
+
+// CreatePasswordReset issues a reset link for the account with the given email. Wallet-only
+// accounts have no email to send it to and are reported as not found.
+func (uc *passwordResetUseCase) CreatePasswordReset(ctx context.Context, email string) (*entity.PasswordResetLink, error) {
+	uc = uc.withContext(ctx)
+
+	user, err := uc.userRepository.FindByEmail(email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	if strings.HasSuffix(user.Email, "@"+entity.WalletEmailDomain) {
+		return nil, apperrors.ErrUserNotFound
+	}
+
+	secretCode, err := random.NewSecret(passwordResetSecretSize)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordReset, err := uc.passwordResetRepository.Create(&entity.PasswordReset{
+		UserID:         user.ID,
+		Email:          user.Email,
+		SecretCodeHash: hashSecretCode(secretCode),
+		ExpiredAt:      time.Now().Add(passwordResetTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("id", passwordReset.ID.String())
+	query.Set("code", secretCode)
+
+	return &entity.PasswordResetLink{
+		Email: user.Email,
+		URL:   uc.resetURL + "?" + query.Encode(),
+	}, nil
+}
+
+// ResetPassword sets a new password using a reset link and blocks every session of the user, so
+// whoever knew the old password is signed out.
+func (uc *passwordResetUseCase) ResetPassword(payload *entity.PasswordResetPayload) error {
+	if payload.Password != payload.PasswordConfirmation {
+		return apperrors.ErrPasswordAndConfirmationNotMatch
+	}
+
+	id, err := uuid.Parse(payload.ID)
+	if err != nil {
+		return apperrors.ErrInvalidPasswordReset
+	}
+
+	passwordReset, err := uc.passwordResetRepository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrInvalidPasswordReset
+		}
+
+		return err
+	}
+
+	hash := hashSecretCode(payload.SecretCode)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(passwordReset.SecretCodeHash)) != 1 || passwordReset.IsUsed {
+		return apperrors.ErrInvalidPasswordReset
+	}
+
+	if passwordReset.ExpiredAt.Before(time.Now()) {
+		return apperrors.ErrPasswordResetExpired
+	}
+
+	hashedPassword, err := password.HashPassword(payload.Password)
+	if err != nil {
+		return apperrors.ErrHashPassword
+	}
+
+	used, err := uc.passwordResetRepository.MarkUsed(passwordReset.ID)
+	if err != nil {
+		return err
+	}
+
+	if !used {
+		return apperrors.ErrInvalidPasswordReset
+	}
+
+	if _, err := uc.userRepository.UpdateByID(passwordReset.UserID, &entity.User{HashedPassword: hashedPassword}); err != nil {
+		return err
+	}
+
+	_, err = uc.sessionRepository.BlockAllByUserID(passwordReset.UserID)
+	return err
+}
+
+func hashSecretCode(secretCode string) string {
+	sum := sha256.Sum256([]byte(secretCode))
+	return hex.EncodeToString(sum[:])
+}
+
+// withContext returns a copy of the use case whose repositories run their queries with ctx.
+func (uc *passwordResetUseCase) withContext(ctx context.Context) *passwordResetUseCase {
+	scoped := *uc
+	scoped.passwordResetRepository = repository.WithContext(ctx, uc.passwordResetRepository)
+	scoped.userRepository = repository.WithContext(ctx, uc.userRepository)
+	scoped.sessionRepository = repository.WithContext(ctx, uc.sessionRepository)
+	return &scoped
+}
@@ -7,6 +7,7 @@ import (
 
 type ProjectCategoryUseCase interface {
 	ListProjectCategories() ([]entity.ProjectCategoryDto, error)
+	CreateProjectCategory(payload *entity.ProjectCategoryCreatePayload) (*entity.ProjectCategoryDto, error)
 }
 
 type projectCategoryUseCase struct {
@@ -36,3 +37,20 @@ func (uc *projectCategoryUseCase) ListProjectCategories() ([]entity.ProjectCateg
 
 	return categoryDtos, nil
 }
+
+func (uc *projectCategoryUseCase) CreateProjectCategory(payload *entity.ProjectCategoryCreatePayload) (*entity.ProjectCategoryDto, error) {
+	subCategories := make([]entity.ProjectSubCategory, 0, len(payload.SubCategories))
+	for _, name := range payload.SubCategories {
+		subCategories = append(subCategories, entity.ProjectSubCategory{Name: name})
+	}
+
+	category, err := uc.projectCategoryRepository.Create(&entity.ProjectCategory{
+		Name:          payload.Name,
+		SubCategories: subCategories,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return category.ToProjectCategoryDto(), nil
+}
@@ -1,71 +1,123 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"fund-o/api-server/internal/datasource/repository"
 	"fund-o/api-server/internal/entity"
 	"fund-o/api-server/pkg/apperrors"
+	"fund-o/api-server/pkg/chain"
+	"fund-o/api-server/pkg/metrics"
 	"fund-o/api-server/pkg/pagination"
 	"fund-o/api-server/pkg/uploader"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type ProjectUseCase interface {
-	ListProjects(params entity.ProjectListParams) pagination.PaginateResult[entity.ProjectDto]
+	ListProjects(userID string, role entity.UserRole, params entity.ProjectListParams) (*pagination.PaginateResult[entity.ProjectDto], apperrors.Error)
 	CreateProject(project *entity.ProjectCreatePayload) (*entity.ProjectDto, error)
-	GetProjectByID(projectID string) (*entity.ProjectDto, apperrors.Error)
+	GetProjectByID(userID string, role entity.UserRole, projectID string) (*entity.ProjectDto, apperrors.Error)
 	GetProjectsByOwnerID(requestOwnerID string) ([]entity.ProjectDto, error)
 	GetRecommendationProjects() ([]entity.ProjectDto, error)
 	CreateProjectRating(rating *entity.ProjectRatingCreatePayload) error
 	IsRatedProject(userID string, projectID string) (bool, error)
-	CreateBackProject(userID string, payload *entity.ProjectBackerCreatePayload) error
+	CreateBackProject(ctx context.Context, userID string, payload *entity.ProjectBackerCreatePayload) (*entity.ProjectBackerDto, error)
+	VerifyContribution(ctx context.Context, backerID string) error
+	RequestRefund(userID string, projectID string) ([]entity.ProjectRefundDto, apperrors.Error)
+	InitiateRefunds(userID string, role entity.UserRole, projectID string) ([]entity.ProjectRefundDto, apperrors.Error)
+	ProcessRefund(ctx context.Context, refundID string) error
+	FailRefund(ctx context.Context, refundID string, reason string) error
 	GetBackedProjects(userID string) ([]entity.ListBackedProjectResponse, error)
+	SubmitProject(userID string, projectID string) (*entity.ProjectDto, apperrors.Error)
+	PublishProject(projectID string) (*entity.ProjectDto, apperrors.Error)
+	RejectProject(projectID string) (*entity.ProjectDto, apperrors.Error)
+	CancelProject(userID string, projectID string) (*entity.ProjectDto, apperrors.Error)
+	CloseExpiredProjects(ctx context.Context) (int64, error)
+	ListProjectRewards(projectID string) ([]entity.ProjectRewardDto, apperrors.Error)
+	CreateProjectReward(userID string, payload *entity.ProjectRewardCreatePayload) (*entity.ProjectRewardDto, apperrors.Error)
+	UpdateProjectReward(userID string, payload *entity.ProjectRewardUpdatePayload) (*entity.ProjectRewardDto, apperrors.Error)
+	DeleteProjectReward(userID string, projectID string, rewardID string) apperrors.Error
+	ListProjectUpdates(userID string, projectID string) ([]entity.ProjectUpdateDto, apperrors.Error)
+	GetProjectUpdate(userID string, projectID string, updateID string) (*entity.ProjectUpdateDto, apperrors.Error)
+	CreateProjectUpdate(userID string, payload *entity.ProjectUpdateCreatePayload) (*entity.ProjectUpdateDto, apperrors.Error)
+	UpdateProjectUpdate(userID string, payload *entity.ProjectUpdateUpdatePayload) (*entity.ProjectUpdateDto, apperrors.Error)
+	DeleteProjectUpdate(userID string, projectID string, updateID string) apperrors.Error
+	ListBackerEmails(projectID string) ([]string, error)
+	GetProjectUpdateNotification(ctx context.Context, updateID string) (*entity.ProjectUpdateNotification, error)
 }
 
 type projectUseCase struct {
-	projectRepository repository.ProjectRepository
-	imageUploader     uploader.ImageUploader
+	projectRepository       repository.ProjectRepository
+	projectUpdateRepository repository.ProjectUpdateRepository
+	userRepository          repository.UserRepository
+	imageUploader           uploader.ImageUploader
+	chainClient             chain.Client
 }
 
 type ProjectUseCaseOptions struct {
 	repository.ProjectRepository
+	repository.ProjectUpdateRepository
+	repository.UserRepository
 	uploader.ImageUploader
+	ChainClient chain.Client
 }
 
 func NewProjectUseCase(options *ProjectUseCaseOptions) ProjectUseCase {
 	return &projectUseCase{
-		projectRepository: options.ProjectRepository,
-		imageUploader:     options.ImageUploader,
+		projectRepository:       options.ProjectRepository,
+		projectUpdateRepository: options.ProjectUpdateRepository,
+		userRepository:          options.UserRepository,
+		imageUploader:           options.ImageUploader,
+		chainClient:             options.ChainClient,
 	}
 }
 
-func (uc *projectUseCase) ListProjects(params entity.ProjectListParams) pagination.PaginateResult[entity.ProjectDto] {
+// ListProjects lists the projects matching the params. Users other than moderators only see the
+// projects in a public status, besides their own; userID is empty for anonymous requests.
+func (uc *projectUseCase) ListProjects(userID string, role entity.UserRole, params entity.ProjectListParams) (*pagination.PaginateResult[entity.ProjectDto], apperrors.Error) {
+	parsedCategoryId, err := uuid.Parse(params.CategoryID)
+	if err != nil {
+		parsedCategoryId = uuid.Nil
+	}
+
+	parsedSubCategoryId, err := uuid.Parse(params.SubCategoryID)
+	if err != nil {
+		parsedSubCategoryId = uuid.Nil
+	}
+
+	listOptions := entity.ProjectListOptions{
+		Query:         params.Query,
+		CategoryID:    parsedCategoryId,
+		SubCategoryID: parsedSubCategoryId,
+	}
+
+	if params.Status != "" {
+		status, ok := entity.ParseProjectStatus(params.Status)
+		if !ok {
+			return nil, apperrors.New(http.StatusBadRequest, apperrors.ErrInvalidProjectStatus.Error())
+		}
+		listOptions.Status = status
+	}
+
+	if !canSeeAllProjects(role) {
+		listOptions.PublicOnly = true
+		// Anonymous requests keep uuid.Nil, which owns nothing.
+		listOptions.ViewerID, _ = uuid.Parse(userID)
+	}
+
 	result := pagination.MakePaginateResult(pagination.MakePaginateContextParameters[entity.ProjectDto]{
 		PaginateOptions: params.PaginateOptions,
 		CountDocuments: func() int64 {
-			return uc.projectRepository.Count()
+			return uc.projectRepository.Count(listOptions)
 		},
 		FindDocuments: func(findOptions pagination.PaginateFindOptions) []entity.ProjectDto {
-			parsedCategoryId, err := uuid.Parse(params.CategoryID)
-			if err != nil {
-				parsedCategoryId = uuid.Nil
-			}
-
-			parsedSubCategoryId, err := uuid.Parse(params.SubCategoryID)
-			if err != nil {
-				parsedSubCategoryId = uuid.Nil
-			}
-
-			documents := uc.projectRepository.FindAll(findOptions, entity.ProjectListOptions{
-				Query:         params.Query,
-				CategoryID:    parsedCategoryId,
-				SubCategoryID: parsedSubCategoryId,
-			})
+			documents := uc.projectRepository.FindAll(findOptions, listOptions)
 
 			projectDtos := make([]entity.ProjectDto, 0, len(documents))
 			for _, document := range documents {
@@ -76,7 +128,7 @@ func (uc *projectUseCase) ListProjects(params entity.ProjectListParams) paginati
 		},
 	})
 
-	return result
+	return &result, nil
 }
 
 func (uc *projectUseCase) CreateProject(project *entity.ProjectCreatePayload) (*entity.ProjectDto, error) {
@@ -87,6 +139,16 @@ func (uc *projectUseCase) CreateProject(project *entity.ProjectCreatePayload) (*
 		return nil, err
 	}
 
+	goal, err := decimal.NewFromString(project.Goal)
+	if err != nil || !goal.IsPositive() {
+		return nil, apperrors.ErrInvalidFundingGoal
+	}
+
+	currency := strings.ToUpper(project.Currency)
+	if currency == "" {
+		currency = entity.DefaultProjectCurrency
+	}
+
 	image, err := uc.imageUploader.Upload(uploader.ProjectImageFolder, project.Image)
 	if err != nil {
 		return nil, err
@@ -103,6 +165,9 @@ func (uc *projectUseCase) CreateProject(project *entity.ProjectCreatePayload) (*
 		Image:             image,
 		StartDate:         time.Now(),
 		EndDate:           endDate,
+		Status:            entity.ProjectDraft,
+		Goal:              goal,
+		Currency:          currency,
 		OwnerID:           ownerID,
 	}
 	newProject, err := uc.projectRepository.Create(payload)
@@ -113,7 +178,9 @@ func (uc *projectUseCase) CreateProject(project *entity.ProjectCreatePayload) (*
 	return newProject.ToProjectDto(), nil
 }
 
-func (uc *projectUseCase) GetProjectByID(projectID string) (*entity.ProjectDto, apperrors.Error) {
+// GetProjectByID gets a project. Projects that are not public yet, or anymore, are not found for
+// users other than their owner and moderators.
+func (uc *projectUseCase) GetProjectByID(userID string, role entity.UserRole, projectID string) (*entity.ProjectDto, apperrors.Error) {
 	projectUUID, err := uuid.Parse(projectID)
 	if err != nil {
 		return nil, apperrors.New(http.StatusBadRequest, "Invalid project ID")
@@ -128,9 +195,18 @@ func (uc *projectUseCase) GetProjectByID(projectID string) (*entity.ProjectDto,
 		return nil, apperrors.New(http.StatusInternalServerError, "Failed to get project")
 	}
 
+	if !project.Status.IsPublic() && project.OwnerID.String() != userID && !canSeeAllProjects(role) {
+		return nil, apperrors.New(http.StatusNotFound, "Project not found")
+	}
+
 	return project.ToProjectDto(), nil
 }
 
+// canSeeAllProjects reports whether the role may see projects in any status, to review them.
+func canSeeAllProjects(role entity.UserRole) bool {
+	return role == entity.RoleModerator || role == entity.RoleAdmin
+}
+
 func (uc *projectUseCase) GetProjectsByOwnerID(requestOwnerID string) ([]entity.ProjectDto, error) {
 	ownerID, err := uuid.Parse(requestOwnerID)
 	if err != nil {
@@ -206,29 +282,308 @@ func (uc *projectUseCase) IsRatedProject(userID string, projectID string) (bool,
 	return false, nil
 }
 
-func (uc *projectUseCase) CreateBackProject(userID string, payload *entity.ProjectBackerCreatePayload) error {
+func (uc *projectUseCase) CreateBackProject(ctx context.Context, userID string, payload *entity.ProjectBackerCreatePayload) (*entity.ProjectBackerDto, error) {
+	uc = uc.withContext(ctx)
+
 	parsedUserID, err := uuid.Parse(userID)
 	if err != nil {
-		return apperrors.ErrInvalidUserID
+		return nil, apperrors.ErrInvalidUserID
 	}
 
 	parsedProjectID, err := uuid.Parse(payload.ProjectID)
 	if err != nil {
-		return apperrors.ErrInvalidProjectID
+		return nil, apperrors.ErrInvalidProjectID
+	}
+
+	txHash, err := chain.NormalizeHash(payload.TxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Submitting the same transaction twice returns the contribution that was already recorded.
+	existing, err := uc.findBackerByTxHash(txHash, parsedUserID, parsedProjectID)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+
+	project, err := uc.projectRepository.FindByID(parsedProjectID)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err = uc.projectRepository.CreateProjectBacker(&entity.ProjectBacker{
+	if project.Status != entity.ProjectLive || time.Now().After(project.EndDate) {
+		return nil, apperrors.ErrProjectNotLive
+	}
+
+	amount := decimal.NewFromFloat(payload.Amount)
+
+	var rewardID *uuid.UUID
+	if payload.RewardID != "" {
+		parsedRewardID, err := uuid.Parse(payload.RewardID)
+		if err != nil {
+			return nil, apperrors.ErrInvalidRewardID
+		}
+
+		reward, err := uc.projectRepository.FindRewardByID(parsedRewardID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, apperrors.ErrRewardNotFound
+			}
+
+			return nil, err
+		}
+
+		if reward.ProjectID != project.ID {
+			return nil, apperrors.ErrRewardNotFound
+		}
+
+		if amount.LessThan(reward.MinimumAmount) {
+			return nil, apperrors.ErrRewardMinimumNotMet
+		}
+
+		rewardID = &reward.ID
+	}
+
+	backer, err := uc.projectRepository.CreateProjectBacker(&entity.ProjectBacker{
 		ProjectID: parsedProjectID,
 		UserID:    parsedUserID,
-		Amount:    decimal.NewFromFloat(payload.Amount),
+		Amount:    amount,
+		RewardID:  rewardID,
+		TxHash:    &txHash,
+		Status:    entity.BackerPending,
 	})
+	if err != nil {
+		// A concurrent request may have recorded the same transaction in the meantime.
+		if existing, findErr := uc.findBackerByTxHash(txHash, parsedUserID, parsedProjectID); findErr == nil && existing != nil {
+			return existing, nil
+		}
+
+		return nil, err
+	}
+
+	return backer.ToProjectBackerDto(), nil
+}
+
+// findBackerByTxHash returns the contribution recorded for the transaction, or nil when there is none.
+// A transaction already used by another user or project is rejected.
+func (uc *projectUseCase) findBackerByTxHash(txHash string, userID, projectID uuid.UUID) (*entity.ProjectBackerDto, error) {
+	backer, err := uc.projectRepository.FindProjectBackerByTxHash(txHash)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if backer.UserID != userID || backer.ProjectID != projectID {
+		return nil, apperrors.ErrTxHashAlreadyUsed
+	}
+
+	return backer.ToProjectBackerDto(), nil
+}
+
+// VerifyContribution checks a pending contribution against its on-chain transaction. It returns
+// apperrors.ErrContributionPending while the transaction is not mined so that the caller can retry.
+func (uc *projectUseCase) VerifyContribution(ctx context.Context, backerID string) error {
+	uc = uc.withContext(ctx)
+
+	parsedBackerID, err := uuid.Parse(backerID)
 	if err != nil {
 		return err
 	}
 
+	backer, err := uc.projectRepository.FindProjectBackerByID(parsedBackerID)
+	if err != nil {
+		return err
+	}
+
+	if backer.Status != entity.BackerPending || backer.TxHash == nil {
+		return nil
+	}
+
+	project, err := uc.projectRepository.FindByID(backer.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.userRepository.FindById(backer.UserID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := uc.chainClient.TransactionByHash(ctx, *backer.TxHash)
+	if err != nil {
+		if errors.Is(err, chain.ErrTransactionNotFound) {
+			return apperrors.ErrContributionPending
+		}
+
+		return err
+	}
+
+	if tx.Status == chain.TransactionPending {
+		return apperrors.ErrContributionPending
+	}
+
+	status := entity.BackerConfirmed
+	if tx.Status != chain.TransactionSucceeded ||
+		!chain.SameAddress(tx.To, project.ProjectContractID) ||
+		!user.HasWallet(tx.From) ||
+		tx.Value == nil || tx.Value.Cmp(backer.Amount.Shift(18).BigInt()) != 0 {
+		status = entity.BackerRejected
+	}
+
+	updated, err := uc.projectRepository.UpdateProjectBackerStatus(backer.ID, entity.BackerPending, status)
+	if err != nil {
+		return err
+	}
+
+	if updated && status == entity.BackerConfirmed {
+		recordContribution(project.Currency, backer.Amount)
+	}
+
 	return nil
 }
 
+// withContext returns a copy of the use case whose repositories run their queries with ctx.
+func (uc *projectUseCase) withContext(ctx context.Context) *projectUseCase {
+	scoped := *uc
+	scoped.projectRepository = repository.WithContext(ctx, uc.projectRepository)
+	scoped.projectUpdateRepository = repository.WithContext(ctx, uc.projectUpdateRepository)
+	scoped.userRepository = repository.WithContext(ctx, uc.userRepository)
+	return &scoped
+}
+
+// recordContribution counts a contribution confirmed on chain in the business metrics.
+func recordContribution(currency string, amount decimal.Decimal) {
+	metrics.Contributions.WithLabelValues(currency).Inc()
+	metrics.ContributedAmount.WithLabelValues(currency).Add(amount.InexactFloat64())
+}
+
+// RequestRefund asks for the user's confirmed contributions to a failed or cancelled project back.
+func (uc *projectUseCase) RequestRefund(userID string, projectID string) ([]entity.ProjectRefundDto, apperrors.Error) {
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, apperrors.New(http.StatusBadRequest, apperrors.ErrInvalidUserID.Error())
+	}
+
+	project, appErr := uc.findProject(projectID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return uc.requestRefunds(project, parsedUserID)
+}
+
+// InitiateRefunds requests a refund of every confirmed contribution to a failed or cancelled
+// project owned by userID. Admins may initiate the refunds of any project.
+func (uc *projectUseCase) InitiateRefunds(userID string, role entity.UserRole, projectID string) ([]entity.ProjectRefundDto, apperrors.Error) {
+	var project *entity.Project
+	var appErr apperrors.Error
+	if role == entity.RoleAdmin {
+		project, appErr = uc.findProject(projectID)
+	} else {
+		project, appErr = uc.findOwnedProject(userID, projectID)
+	}
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return uc.requestRefunds(project, uuid.Nil)
+}
+
+func (uc *projectUseCase) requestRefunds(project *entity.Project, userID uuid.UUID) ([]entity.ProjectRefundDto, apperrors.Error) {
+	if !project.Status.IsRefundable() {
+		return nil, apperrors.New(http.StatusConflict, apperrors.ErrProjectNotRefundable.Error())
+	}
+
+	backers, err := uc.projectRepository.FindRefundableBackers(project.ID, userID)
+	if err != nil {
+		return nil, apperrors.New(http.StatusInternalServerError, "Failed to find contributions to refund")
+	}
+
+	if len(backers) == 0 {
+		return nil, apperrors.New(http.StatusNotFound, apperrors.ErrNothingToRefund.Error())
+	}
+
+	refunds, err := uc.projectRepository.RequestRefunds(backers)
+	if err != nil {
+		return nil, apperrors.New(http.StatusInternalServerError, "Failed to request refunds")
+	}
+
+	refundDtos := make([]entity.ProjectRefundDto, 0, len(refunds))
+	for _, refund := range refunds {
+		refundDtos = append(refundDtos, *refund.ToProjectRefundDto())
+	}
+
+	return refundDtos, nil
+}
+
+// ProcessRefund settles a requested refund. The project contract pays refunds out itself, so the
+// refund is complete once a confirmed Refunded event paying the amount back to the wallet that
+// made the contribution has been indexed. It returns apperrors.ErrRefundPending until then so that
+// the caller can retry.
+func (uc *projectUseCase) ProcessRefund(ctx context.Context, refundID string) error {
+	uc = uc.withContext(ctx)
+
+	parsedRefundID, err := uuid.Parse(refundID)
+	if err != nil {
+		return err
+	}
+
+	refund, err := uc.projectRepository.FindRefundByID(parsedRefundID)
+	if err != nil {
+		return err
+	}
+
+	if refund.Status == entity.RefundRequested {
+		if _, err := uc.projectRepository.UpdateRefundStatus(refund.ID, entity.RefundRequested, entity.RefundProcessing); err != nil {
+			return err
+		}
+	} else if refund.Status != entity.RefundProcessing {
+		return nil
+	}
+
+	if refund.Backer.TxHash == nil {
+		return uc.FailRefund(ctx, refundID, "contribution has no transaction to refund")
+	}
+
+	project, err := uc.projectRepository.FindByID(refund.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := uc.chainClient.TransactionByHash(ctx, *refund.Backer.TxHash)
+	if err != nil {
+		return err
+	}
+
+	event, err := uc.projectRepository.FindRefundEvent(project.ProjectContractID, tx.From, refund.Amount)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrRefundPending
+		}
+
+		return err
+	}
+
+	_, err = uc.projectRepository.CompleteRefund(refund.ID, event)
+	return err
+}
+
+// FailRefund gives up on a refund that could not be settled.
+func (uc *projectUseCase) FailRefund(ctx context.Context, refundID string, reason string) error {
+	uc = uc.withContext(ctx)
+
+	parsedRefundID, err := uuid.Parse(refundID)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.projectRepository.FailRefund(parsedRefundID, reason)
+	return err
+}
+
 func (uc *projectUseCase) GetBackedProjects(userID string) ([]entity.ListBackedProjectResponse, error) {
 	projectFundings, err := uc.projectRepository.FindBackProjectsByUserID(userID)
 	if err != nil {
@@ -243,11 +598,455 @@ func (uc *projectUseCase) GetBackedProjects(userID string) ([]entity.ListBackedP
 		}
 
 		backedProjects = append(backedProjects, entity.ListBackedProjectResponse{
-			Project:    *project.ToProjectDto(),
-			FundAmount: projectFunding.TotalFunds,
+			Project:        *project.ToProjectDto(),
+			FundAmount:     projectFunding.TotalFunds,
+			RefundStatus:   projectFunding.RefundStatus.String(),
+			RefundedAmount: projectFunding.RefundedAmount,
 		})
 
 	}
 
 	return backedProjects, nil
 }
+
+func (uc *projectUseCase) SubmitProject(userID string, projectID string) (*entity.ProjectDto, apperrors.Error) {
+	return uc.transitionProject(userID, projectID, entity.ProjectPendingReview)
+}
+
+// PublishProject approves a project pending review. Only moderators and admins may call it, so
+// ownership is not checked.
+func (uc *projectUseCase) PublishProject(projectID string) (*entity.ProjectDto, apperrors.Error) {
+	project, appErr := uc.findProject(projectID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return uc.moveProject(project, entity.ProjectLive)
+}
+
+// RejectProject sends a project pending review back to its owner as a draft.
+func (uc *projectUseCase) RejectProject(projectID string) (*entity.ProjectDto, apperrors.Error) {
+	project, appErr := uc.findProject(projectID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if project.Status != entity.ProjectPendingReview {
+		return nil, apperrors.New(http.StatusConflict, apperrors.ErrInvalidProjectStatusTransition.Error())
+	}
+
+	return uc.moveProject(project, entity.ProjectDraft)
+}
+
+func (uc *projectUseCase) CancelProject(userID string, projectID string) (*entity.ProjectDto, apperrors.Error) {
+	return uc.transitionProject(userID, projectID, entity.ProjectCancelled)
+}
+
+func (uc *projectUseCase) CloseExpiredProjects(ctx context.Context) (int64, error) {
+	return repository.WithContext(ctx, uc.projectRepository).CloseExpired(time.Now())
+}
+
+// transitionProject moves a project owned by userID to the next status, enforcing the
+// lifecycle defined by entity.ProjectStatus.CanTransitionTo.
+func (uc *projectUseCase) transitionProject(userID string, projectID string, next entity.ProjectStatus) (*entity.ProjectDto, apperrors.Error) {
+	project, appErr := uc.findOwnedProject(userID, projectID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return uc.moveProject(project, next)
+}
+
+func (uc *projectUseCase) moveProject(project *entity.Project, next entity.ProjectStatus) (*entity.ProjectDto, apperrors.Error) {
+	if !project.Status.CanTransitionTo(next) {
+		return nil, apperrors.New(http.StatusConflict, apperrors.ErrInvalidProjectStatusTransition.Error())
+	}
+
+	updated, err := uc.projectRepository.UpdateStatus(project.ID, project.Status, next)
+	if err != nil {
+		return nil, apperrors.New(http.StatusInternalServerError, "Failed to update project status")
+	}
+
+	if !updated {
+		return nil, apperrors.New(http.StatusConflict, apperrors.ErrInvalidProjectStatusTransition.Error())
+	}
+
+	project.Status = next
+	return project.ToProjectDto(), nil
+}
+
+// findOwnedProject loads the project and makes sure it belongs to userID.
+func (uc *projectUseCase) findOwnedProject(userID string, projectID string) (*entity.Project, apperrors.Error) {
+	project, appErr := uc.findProject(projectID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if project.OwnerID.String() != userID {
+		return nil, apperrors.New(http.StatusForbidden, apperrors.ErrNotProjectOwner.Error())
+	}
+
+	return project, nil
+}
+
+func (uc *projectUseCase) findProject(projectID string) (*entity.Project, apperrors.Error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, apperrors.New(http.StatusBadRequest, apperrors.ErrInvalidProjectID.Error())
+	}
+
+	project, err := uc.projectRepository.FindByID(projectUUID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.New(http.StatusNotFound, "Project not found")
+		}
+
+		return nil, apperrors.New(http.StatusInternalServerError, "Failed to get project")
+	}
+
+	return project, nil
+}
+
+func (uc *projectUseCase) ListProjectRewards(projectID string) ([]entity.ProjectRewardDto, apperrors.Error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, apperrors.New(http.StatusBadRequest, apperrors.ErrInvalidProjectID.Error())
+	}
+
+	rewards, err := uc.projectRepository.FindRewardsByProjectID(projectUUID)
+	if err != nil {
+		return nil, apperrors.New(http.StatusInternalServerError, "Failed to list project rewards")
+	}
+
+	rewardDtos := make([]entity.ProjectRewardDto, 0, len(rewards))
+	for _, reward := range rewards {
+		rewardDtos = append(rewardDtos, *reward.ToProjectRewardDto())
+	}
+
+	return rewardDtos, nil
+}
+
+func (uc *projectUseCase) CreateProjectReward(userID string, payload *entity.ProjectRewardCreatePayload) (*entity.ProjectRewardDto, apperrors.Error) {
+	project, appErr := uc.findOwnedProject(userID, payload.ProjectID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	estimatedDelivery, err := time.Parse(time.RFC3339, payload.EstimatedDelivery)
+	if err != nil {
+		return nil, apperrors.New(http.StatusBadRequest, "Invalid estimated delivery date")
+	}
+
+	reward, err := uc.projectRepository.CreateReward(&entity.ProjectReward{
+		ProjectID:         project.ID,
+		Title:             payload.Title,
+		Description:       payload.Description,
+		MinimumAmount:     decimal.NewFromFloat(payload.MinimumAmount),
+		EstimatedDelivery: estimatedDelivery,
+		QuantityLimit:     payload.QuantityLimit,
+		RequiresShipping:  payload.RequiresShipping,
+	})
+	if err != nil {
+		return nil, apperrors.New(http.StatusInternalServerError, "Failed to create project reward")
+	}
+
+	return reward.ToProjectRewardDto(), nil
+}
+
+func (uc *projectUseCase) UpdateProjectReward(userID string, payload *entity.ProjectRewardUpdatePayload) (*entity.ProjectRewardDto, apperrors.Error) {
+	reward, appErr := uc.findOwnedReward(userID, payload.ProjectID, payload.RewardID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if payload.Title != nil {
+		reward.Title = *payload.Title
+	}
+
+	if payload.Description != nil {
+		reward.Description = *payload.Description
+	}
+
+	if payload.MinimumAmount != nil {
+		reward.MinimumAmount = decimal.NewFromFloat(*payload.MinimumAmount)
+	}
+
+	if payload.EstimatedDelivery != nil {
+		estimatedDelivery, err := time.Parse(time.RFC3339, *payload.EstimatedDelivery)
+		if err != nil {
+			return nil, apperrors.New(http.StatusBadRequest, "Invalid estimated delivery date")
+		}
+		reward.EstimatedDelivery = estimatedDelivery
+	}
+
+	if payload.QuantityLimit != nil {
+		reward.QuantityLimit = payload.QuantityLimit
+	}
+
+	if payload.ClearQuantityLimit {
+		reward.QuantityLimit = nil
+	}
+
+	if payload.RequiresShipping != nil {
+		reward.RequiresShipping = *payload.RequiresShipping
+	}
+
+	updatedReward, err := uc.projectRepository.UpdateReward(reward)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrRewardLimitBelowClaimed) {
+			return nil, apperrors.New(http.StatusConflict, err.Error())
+		}
+
+		return nil, apperrors.New(http.StatusInternalServerError, "Failed to update project reward")
+	}
+
+	return updatedReward.ToProjectRewardDto(), nil
+}
+
+func (uc *projectUseCase) DeleteProjectReward(userID string, projectID string, rewardID string) apperrors.Error {
+	reward, appErr := uc.findOwnedReward(userID, projectID, rewardID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if reward.QuantityClaimed > 0 {
+		return apperrors.New(http.StatusConflict, apperrors.ErrRewardAlreadyClaimed.Error())
+	}
+
+	if err := uc.projectRepository.DeleteReward(reward.ID); err != nil {
+		return apperrors.New(http.StatusInternalServerError, "Failed to delete project reward")
+	}
+
+	return nil
+}
+
+// findOwnedReward loads a reward of a project owned by userID.
+func (uc *projectUseCase) findOwnedReward(userID string, projectID string, rewardID string) (*entity.ProjectReward, apperrors.Error) {
+	project, appErr := uc.findOwnedProject(userID, projectID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	rewardUUID, err := uuid.Parse(rewardID)
+	if err != nil {
+		return nil, apperrors.New(http.StatusBadRequest, apperrors.ErrInvalidRewardID.Error())
+	}
+
+	reward, err := uc.projectRepository.FindRewardByID(rewardUUID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.New(http.StatusNotFound, apperrors.ErrRewardNotFound.Error())
+		}
+
+		return nil, apperrors.New(http.StatusInternalServerError, "Failed to get project reward")
+	}
+
+	if reward.ProjectID != project.ID {
+		return nil, apperrors.New(http.StatusNotFound, apperrors.ErrRewardNotFound.Error())
+	}
+
+	return reward, nil
+}
+
+// ListProjectUpdates lists the updates of a project. Backers-only updates are included for the
+// project owner and its backers; userID is empty for anonymous requests.
+func (uc *projectUseCase) ListProjectUpdates(userID string, projectID string) ([]entity.ProjectUpdateDto, apperrors.Error) {
+	project, appErr := uc.findProject(projectID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	canSeeBackersOnly, err := uc.canSeeBackersOnlyUpdates(userID, project)
+	if err != nil {
+		return nil, apperrors.New(http.StatusInternalServerError, "Failed to check project backer")
+	}
+
+	updates, err := uc.projectUpdateRepository.FindAllByProjectID(project.ID, canSeeBackersOnly)
+	if err != nil {
+		return nil, apperrors.New(http.StatusInternalServerError, "Failed to list project updates")
+	}
+
+	updateDtos := make([]entity.ProjectUpdateDto, 0, len(updates))
+	for _, update := range updates {
+		updateDtos = append(updateDtos, *update.ToProjectUpdateDto())
+	}
+
+	return updateDtos, nil
+}
+
+func (uc *projectUseCase) GetProjectUpdate(userID string, projectID string, updateID string) (*entity.ProjectUpdateDto, apperrors.Error) {
+	project, appErr := uc.findProject(projectID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	update, appErr := uc.findProjectUpdate(project, updateID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if update.Visibility == entity.UpdateVisibilityBackersOnly {
+		canSeeBackersOnly, err := uc.canSeeBackersOnlyUpdates(userID, project)
+		if err != nil {
+			return nil, apperrors.New(http.StatusInternalServerError, "Failed to check project backer")
+		}
+
+		if !canSeeBackersOnly {
+			return nil, apperrors.New(http.StatusForbidden, apperrors.ErrBackersOnlyUpdate.Error())
+		}
+	}
+
+	return update.ToProjectUpdateDto(), nil
+}
+
+func (uc *projectUseCase) CreateProjectUpdate(userID string, payload *entity.ProjectUpdateCreatePayload) (*entity.ProjectUpdateDto, apperrors.Error) {
+	project, appErr := uc.findOwnedProject(userID, payload.ProjectID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	visibility := entity.UpdateVisibilityPublic
+	if payload.Visibility != "" {
+		var ok bool
+		if visibility, ok = entity.ParseProjectUpdateVisibility(payload.Visibility); !ok {
+			return nil, apperrors.New(http.StatusBadRequest, "Invalid project update visibility")
+		}
+	}
+
+	update, err := uc.projectUpdateRepository.Create(&entity.ProjectUpdate{
+		ProjectID:  project.ID,
+		AuthorID:   project.OwnerID,
+		Title:      payload.Title,
+		Body:       payload.Body,
+		Visibility: visibility,
+	})
+	if err != nil {
+		return nil, apperrors.New(http.StatusInternalServerError, "Failed to create project update")
+	}
+
+	return update.ToProjectUpdateDto(), nil
+}
+
+func (uc *projectUseCase) UpdateProjectUpdate(userID string, payload *entity.ProjectUpdateUpdatePayload) (*entity.ProjectUpdateDto, apperrors.Error) {
+	project, appErr := uc.findOwnedProject(userID, payload.ProjectID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	update, appErr := uc.findProjectUpdate(project, payload.UpdateID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if payload.Title != nil {
+		update.Title = *payload.Title
+	}
+
+	if payload.Body != nil {
+		update.Body = *payload.Body
+	}
+
+	if payload.Visibility != nil {
+		visibility, ok := entity.ParseProjectUpdateVisibility(*payload.Visibility)
+		if !ok {
+			return nil, apperrors.New(http.StatusBadRequest, "Invalid project update visibility")
+		}
+		update.Visibility = visibility
+	}
+
+	updatedUpdate, err := uc.projectUpdateRepository.Update(update)
+	if err != nil {
+		return nil, apperrors.New(http.StatusInternalServerError, "Failed to update project update")
+	}
+
+	return updatedUpdate.ToProjectUpdateDto(), nil
+}
+
+func (uc *projectUseCase) DeleteProjectUpdate(userID string, projectID string, updateID string) apperrors.Error {
+	project, appErr := uc.findOwnedProject(userID, projectID)
+	if appErr != nil {
+		return appErr
+	}
+
+	update, appErr := uc.findProjectUpdate(project, updateID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if err := uc.projectUpdateRepository.Delete(update.ID); err != nil {
+		return apperrors.New(http.StatusInternalServerError, "Failed to delete project update")
+	}
+
+	return nil
+}
+
+// ListBackerEmails lists where to notify the backers of a project about a new update.
+func (uc *projectUseCase) ListBackerEmails(projectID string) ([]string, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidProjectID
+	}
+
+	return uc.projectRepository.FindBackerEmails(projectUUID)
+}
+
+func (uc *projectUseCase) GetProjectUpdateNotification(ctx context.Context, updateID string) (*entity.ProjectUpdateNotification, error) {
+	uc = uc.withContext(ctx)
+
+	updateUUID, err := uuid.Parse(updateID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidProjectUpdateID
+	}
+
+	update, err := uc.projectUpdateRepository.FindByID(updateUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := uc.projectRepository.FindByID(update.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ProjectUpdateNotification{
+		ProjectTitle: project.Title,
+		UpdateTitle:  update.Title,
+	}, nil
+}
+
+// canSeeBackersOnlyUpdates reports whether the user owns or backed the project.
+func (uc *projectUseCase) canSeeBackersOnlyUpdates(userID string, project *entity.Project) (bool, error) {
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return false, nil
+	}
+
+	if project.OwnerID == parsedUserID {
+		return true, nil
+	}
+
+	return uc.projectRepository.IsProjectBacker(project.ID, parsedUserID)
+}
+
+// findProjectUpdate loads an update of the project.
+func (uc *projectUseCase) findProjectUpdate(project *entity.Project, updateID string) (*entity.ProjectUpdate, apperrors.Error) {
+	updateUUID, err := uuid.Parse(updateID)
+	if err != nil {
+		return nil, apperrors.New(http.StatusBadRequest, apperrors.ErrInvalidProjectUpdateID.Error())
+	}
+
+	update, err := uc.projectUpdateRepository.FindByID(updateUUID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.New(http.StatusNotFound, apperrors.ErrProjectUpdateNotFound.Error())
+		}
+
+		return nil, apperrors.New(http.StatusInternalServerError, "Failed to get project update")
+	}
+
+	if update.ProjectID != project.ID {
+		return nil, apperrors.New(http.StatusNotFound, apperrors.ErrProjectUpdateNotFound.Error())
+	}
+
+	return update, nil
+}
@@ -0,0 +1,216 @@
+package usecase_test
+
+import (
+	"context"
+	"fund-o/api-server/internal/entity"
+	"fund-o/api-server/internal/usecase"
+	"fund-o/api-server/mocks"
+	"fund-o/api-server/pkg/apperrors"
+	"fund-o/api-server/pkg/chain"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectVerifyContribution(t *testing.T) {
+	contract := "0x" + strings.Repeat("22", 20)
+	wallet := "0x" + strings.Repeat("11", 20)
+	txHash := "0x" + strings.Repeat("ab", 32)
+	amount := decimal.NewFromFloat(1.5)
+	wei := amount.Shift(18).BigInt()
+
+	testCases := []struct {
+		name      string
+		buildTx   func() *chain.Transaction
+		checkErr  func(t *testing.T, err error)
+		expected  entity.ProjectBackerStatus
+		noConfirm bool
+	}{
+		{
+			name: "Confirmed",
+			buildTx: func() *chain.Transaction {
+				return &chain.Transaction{Hash: txHash, From: "0x" + strings.ToUpper(wallet[2:]), To: contract, Value: wei, Status: chain.TransactionSucceeded}
+			},
+			expected: entity.BackerConfirmed,
+		},
+		{
+			name: "WrongRecipient",
+			buildTx: func() *chain.Transaction {
+				return &chain.Transaction{Hash: txHash, From: wallet, To: "0x" + strings.Repeat("99", 20), Value: wei, Status: chain.TransactionSucceeded}
+			},
+			expected: entity.BackerRejected,
+		},
+		{
+			name: "SenderNotBackerWallet",
+			buildTx: func() *chain.Transaction {
+				return &chain.Transaction{Hash: txHash, From: "0x" + strings.Repeat("33", 20), To: contract, Value: wei, Status: chain.TransactionSucceeded}
+			},
+			expected: entity.BackerRejected,
+		},
+		{
+			name: "WrongValue",
+			buildTx: func() *chain.Transaction {
+				return &chain.Transaction{Hash: txHash, From: wallet, To: contract, Value: new(big.Int).Sub(wei, big.NewInt(1)), Status: chain.TransactionSucceeded}
+			},
+			expected: entity.BackerRejected,
+		},
+		{
+			name: "Reverted",
+			buildTx: func() *chain.Transaction {
+				return &chain.Transaction{Hash: txHash, From: wallet, To: contract, Value: wei, Status: chain.TransactionFailed}
+			},
+			expected: entity.BackerRejected,
+		},
+		{
+			name: "NotMined",
+			buildTx: func() *chain.Transaction {
+				return &chain.Transaction{Hash: txHash, From: wallet, To: contract, Value: wei, Status: chain.TransactionPending}
+			},
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, apperrors.ErrContributionPending)
+			},
+			noConfirm: true,
+		},
+		{
+			name: "NotBroadcast",
+			buildTx: func() *chain.Transaction {
+				return nil
+			},
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, apperrors.ErrContributionPending)
+			},
+			noConfirm: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			projectRepository := mocks.NewMockProjectRepository(ctrl)
+			userRepository := mocks.NewMockUserRepository(ctrl)
+			client := chain.NewMemoryClient()
+			if tx := tc.buildTx(); tx != nil {
+				client.AddTransaction(*tx)
+			}
+
+			uc := usecase.NewProjectUseCase(&usecase.ProjectUseCaseOptions{
+				ProjectRepository: projectRepository,
+				UserRepository:    userRepository,
+				ChainClient:       client,
+			})
+
+			project := entity.Project{Base: entity.Base{ID: uuid.New()}, ProjectContractID: contract, Currency: entity.DefaultProjectCurrency}
+			user := entity.User{Base: entity.Base{ID: uuid.New()}, Wallets: []entity.UserWallet{{Address: wallet}}}
+			backer := entity.ProjectBacker{
+				Base:      entity.Base{ID: uuid.New()},
+				ProjectID: project.ID,
+				UserID:    user.ID,
+				Amount:    amount,
+				TxHash:    &txHash,
+				Status:    entity.BackerPending,
+			}
+
+			projectRepository.EXPECT().
+				FindProjectBackerByID(gomock.Eq(backer.ID)).
+				Times(1).
+				Return(&backer, nil)
+			projectRepository.EXPECT().
+				FindByID(gomock.Eq(project.ID)).
+				Times(1).
+				Return(&project, nil)
+			userRepository.EXPECT().
+				FindById(gomock.Eq(user.ID)).
+				Times(1).
+				Return(&user, nil)
+
+			if tc.noConfirm {
+				projectRepository.EXPECT().
+					UpdateProjectBackerStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			} else {
+				projectRepository.EXPECT().
+					UpdateProjectBackerStatus(gomock.Eq(backer.ID), gomock.Eq(entity.BackerPending), gomock.Eq(tc.expected)).
+					Times(1).
+					Return(true, nil)
+			}
+
+			err := uc.VerifyContribution(context.Background(), backer.ID.String())
+			if tc.checkErr != nil {
+				tc.checkErr(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestProjectVerifyContributionTwice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectRepository := mocks.NewMockProjectRepository(ctrl)
+	userRepository := mocks.NewMockUserRepository(ctrl)
+	client := chain.NewMemoryClient()
+
+	uc := usecase.NewProjectUseCase(&usecase.ProjectUseCaseOptions{
+		ProjectRepository: projectRepository,
+		UserRepository:    userRepository,
+		ChainClient:       client,
+	})
+
+	contract := "0x" + strings.Repeat("22", 20)
+	wallet := "0x" + strings.Repeat("11", 20)
+	txHash := "0x" + strings.Repeat("ab", 32)
+	project := entity.Project{Base: entity.Base{ID: uuid.New()}, ProjectContractID: contract, Currency: entity.DefaultProjectCurrency}
+	user := entity.User{Base: entity.Base{ID: uuid.New()}, Wallets: []entity.UserWallet{{Address: wallet}}}
+	backer := entity.ProjectBacker{
+		Base:      entity.Base{ID: uuid.New()},
+		ProjectID: project.ID,
+		UserID:    user.ID,
+		Amount:    decimal.NewFromInt(2),
+		TxHash:    &txHash,
+		Status:    entity.BackerPending,
+	}
+	client.AddTransaction(chain.Transaction{
+		Hash:   txHash,
+		From:   wallet,
+		To:     contract,
+		Value:  backer.Amount.Shift(18).BigInt(),
+		Status: chain.TransactionSucceeded,
+	})
+
+	projectRepository.EXPECT().
+		FindProjectBackerByID(gomock.Eq(backer.ID)).
+		Times(2).
+		DoAndReturn(func(uuid.UUID) (*entity.ProjectBacker, error) {
+			found := backer
+			return &found, nil
+		})
+	projectRepository.EXPECT().
+		FindByID(gomock.Eq(project.ID)).
+		Times(1).
+		Return(&project, nil)
+	userRepository.EXPECT().
+		FindById(gomock.Eq(user.ID)).
+		Times(1).
+		Return(&user, nil)
+	projectRepository.EXPECT().
+		UpdateProjectBackerStatus(gomock.Eq(backer.ID), gomock.Eq(entity.BackerPending), gomock.Eq(entity.BackerConfirmed)).
+		Times(1).
+		DoAndReturn(func(uuid.UUID, entity.ProjectBackerStatus, entity.ProjectBackerStatus) (bool, error) {
+			backer.Status = entity.BackerConfirmed
+			return true, nil
+		})
+
+	// The second run finds the contribution confirmed and leaves it alone.
+	require.NoError(t, uc.VerifyContribution(context.Background(), backer.ID.String()))
+	require.NoError(t, uc.VerifyContribution(context.Background(), backer.ID.String()))
+	require.Equal(t, entity.BackerConfirmed, backer.Status)
+}
@@ -1,15 +1,24 @@
 package usecase
 
 import (
+	"errors"
 	"fund-o/api-server/internal/datasource/repository"
 	"fund-o/api-server/internal/entity"
+	"fund-o/api-server/pkg/apperrors"
+	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type SessionUseCase interface {
 	CreateSession(payload *entity.SessionCreatePayload) (*entity.SessionDto, error)
 	GetSessionByID(sessionID uuid.UUID) (*entity.SessionDto, error)
+	ListActiveSessions(userID string) ([]entity.UserSessionDto, error)
+	RevokeSession(userID string, sessionID string) error
+	Logout(userID string, sessionID string) error
+	RevokeAllSessions(userID string) (int64, error)
+	RotateSession(previousID uuid.UUID, payload *entity.SessionCreatePayload) (*entity.SessionDto, error)
 }
 
 type sessionUseCase struct {
@@ -30,6 +39,7 @@ func (uc *sessionUseCase) CreateSession(payload *entity.SessionCreatePayload) (*
 	session := entity.Session{
 		ID:           payload.ID,
 		UserID:       uuid.MustParse(payload.UserID),
+		FamilyID:     payload.ID,
 		RefreshToken: payload.RefreshToken,
 		UserAgent:    payload.UserAgent,
 		ClientIP:     payload.ClientIP,
@@ -52,3 +62,136 @@ func (uc *sessionUseCase) GetSessionByID(sessionID uuid.UUID) (*entity.SessionDt
 
 	return session.ToSessionDto(), nil
 }
+
+// ListActiveSessions lists the sessions of the user that can still renew access tokens.
+func (uc *sessionUseCase) ListActiveSessions(userID string) ([]entity.UserSessionDto, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidUserID
+	}
+
+	sessions, err := uc.sessionRepository.FindActiveByUserID(id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	sessionDtos := make([]entity.UserSessionDto, 0, len(sessions))
+	for _, session := range sessions {
+		sessionDtos = append(sessionDtos, *session.ToUserSessionDto())
+	}
+
+	return sessionDtos, nil
+}
+
+// RevokeSession blocks one session of the user so its refresh token stops working.
+func (uc *sessionUseCase) RevokeSession(userID string, sessionID string) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return apperrors.ErrInvalidUserID
+	}
+
+	parsedSessionID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return apperrors.ErrInvalidSessionID
+	}
+
+	blocked, err := uc.sessionRepository.Block(parsedSessionID, id)
+	if err != nil {
+		return err
+	}
+
+	if !blocked {
+		return apperrors.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// Logout blocks the session of a refresh token together with the rest of its token family, so the
+// tokens it was rotated from or into stop working as well. Logging out with a token that was
+// already rotated blocks the family too, but fails with ErrRefreshTokenReused as a refresh would.
+func (uc *sessionUseCase) Logout(userID string, sessionID string) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return apperrors.ErrInvalidUserID
+	}
+
+	parsedSessionID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return apperrors.ErrInvalidSessionID
+	}
+
+	session, err := uc.sessionRepository.FindByID(parsedSessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrSessionNotFound
+		}
+
+		return err
+	}
+
+	if session.UserID != id {
+		return apperrors.ErrSessionNotFound
+	}
+
+	if session.RotatedAt != nil {
+		return uc.blockFamily(session)
+	}
+
+	_, err = uc.sessionRepository.BlockFamily(session.Family())
+	return err
+}
+
+// RevokeAllSessions blocks every session of the user and returns how many were still active.
+func (uc *sessionUseCase) RevokeAllSessions(userID string) (int64, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return 0, apperrors.ErrInvalidUserID
+	}
+
+	return uc.sessionRepository.BlockAllByUserID(id)
+}
+
+// RotateSession replaces a session with a new one of the same token family. Presenting a refresh
+// token that was already rotated means it leaked, so the whole family is blocked and the user has
+// to sign in again.
+func (uc *sessionUseCase) RotateSession(previousID uuid.UUID, payload *entity.SessionCreatePayload) (*entity.SessionDto, error) {
+	previous, err := uc.sessionRepository.FindByID(previousID)
+	if err != nil {
+		return nil, err
+	}
+
+	if previous.RotatedAt != nil {
+		return nil, uc.blockFamily(previous)
+	}
+
+	session := entity.Session{
+		ID:           payload.ID,
+		UserID:       previous.UserID,
+		FamilyID:     previous.Family(),
+		ParentID:     &previous.ID,
+		RefreshToken: payload.RefreshToken,
+		UserAgent:    payload.UserAgent,
+		ClientIP:     payload.ClientIP,
+		ExpiredAt:    payload.ExpiredAt,
+	}
+
+	rotated, err := uc.sessionRepository.Rotate(previous.ID, &session)
+	if err != nil {
+		return nil, err
+	}
+
+	if !rotated {
+		return nil, uc.blockFamily(previous)
+	}
+
+	return session.ToSessionDto(), nil
+}
+
+func (uc *sessionUseCase) blockFamily(session *entity.Session) error {
+	if _, err := uc.sessionRepository.BlockFamily(session.Family()); err != nil {
+		return err
+	}
+
+	return apperrors.ErrRefreshTokenReused
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"fund-o/api-server/internal/datasource/repository"
 	"fund-o/api-server/internal/entity"
@@ -11,12 +12,17 @@ import (
 	"gorm.io/gorm"
 )
 
+// dummyPasswordHash is a bcrypt hash with the default cost that no user has.
+const dummyPasswordHash = "$2a$10$uxm5ZdugG5xkk2QKHurNB.olbzvchugit4g7wiOk/Baf3ViSIRx2G"
+
 type UserUseCase interface {
 	CreateUser(user *entity.User) (*entity.UserDto, error)
 	AuthenticateUser(payload *entity.UserLoginPayload) (*entity.UserDto, error)
 	GetUserById(id string) (*entity.UserDto, error)
-	GetUserByEmail(email string) (*entity.UserDto, error)
+	GetUserByEmail(ctx context.Context, email string) (*entity.UserDto, error)
 	UpdateUserByID(id string, user *entity.UserUpdatePayload) (*entity.UserDto, error)
+	UpdateUserRole(id string, role entity.UserRole) (*entity.UserDto, error)
+	MarkEmailVerified(id string) (*entity.UserDto, error)
 }
 
 type userUseCase struct {
@@ -45,14 +51,22 @@ func (uc *userUseCase) CreateUser(user *entity.User) (*entity.UserDto, error) {
 	return newUser.ToUserDto(), nil
 }
 
+// AuthenticateUser checks the email and password of a user. It returns apperrors.ErrInvalidCredentials
+// whether the email is unknown or the password is wrong, so that callers cannot tell which one it was.
 func (uc *userUseCase) AuthenticateUser(payload *entity.UserLoginPayload) (*entity.UserDto, error) {
 	user, err := uc.userRepository.FindByEmail(payload.Email)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Compare against a dummy hash so an unknown email takes as long as a wrong password.
+			_ = password.CheckPassword(payload.Password, dummyPasswordHash)
+			return nil, apperrors.ErrInvalidCredentials
+		}
+
 		return nil, err
 	}
 
 	if err := password.CheckPassword(payload.Password, user.HashedPassword); err != nil {
-		return nil, err
+		return nil, apperrors.ErrInvalidCredentials
 	}
 
 	return user.ToUserDto(), nil
@@ -76,7 +90,9 @@ func (uc *userUseCase) GetUserById(id string) (*entity.UserDto, error) {
 	return user.ToUserDto(), nil
 }
 
-func (uc *userUseCase) GetUserByEmail(email string) (*entity.UserDto, error) {
+func (uc *userUseCase) GetUserByEmail(ctx context.Context, email string) (*entity.UserDto, error) {
+	uc = uc.withContext(ctx)
+
 	user, err := uc.userRepository.FindByEmail(email)
 	if err != nil {
 		return nil, err
@@ -99,11 +115,8 @@ func (uc *userUseCase) UpdateUserByID(id string, user *entity.UserUpdatePayload)
 	}
 
 	payload := entity.User{
-		Email:             user.Email,
-		DisplayName:       user.DisplayName,
-		ProfileImage:      profileImage,
-		MetaMaskAccountID: user.MetamaskAccountID,
-		IsEmailVerified:   user.IsEmailVerified,
+		DisplayName:  user.DisplayName,
+		ProfileImage: profileImage,
 	}
 
 	updatedUser, err := uc.userRepository.UpdateByID(userID, &payload)
@@ -113,3 +126,47 @@ func (uc *userUseCase) UpdateUserByID(id string, user *entity.UserUpdatePayload)
 
 	return updatedUser.ToUserDto(), nil
 }
+
+func (uc *userUseCase) UpdateUserRole(id string, role entity.UserRole) (*entity.UserDto, error) {
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.ErrInvalidUserID
+	}
+
+	updatedUser, err := uc.userRepository.UpdateByID(userID, &entity.User{Role: role})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	return updatedUser.ToUserDto(), nil
+}
+
+// MarkEmailVerified records that the user proved they own their current email.
+func (uc *userUseCase) MarkEmailVerified(id string) (*entity.UserDto, error) {
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.ErrInvalidUserID
+	}
+
+	updatedUser, err := uc.userRepository.UpdateByID(userID, &entity.User{IsEmailVerified: true})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	return updatedUser.ToUserDto(), nil
+}
+
+// withContext returns a copy of the use case whose repositories run their queries with ctx.
+func (uc *userUseCase) withContext(ctx context.Context) *userUseCase {
+	scoped := *uc
+	scoped.userRepository = repository.WithContext(ctx, uc.userRepository)
+	return &scoped
+}
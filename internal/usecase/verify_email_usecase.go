@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"fund-o/api-server/internal/datasource/repository"
 	"fund-o/api-server/internal/entity"
@@ -8,7 +9,7 @@ import (
 )
 
 type VerifyEmailUseCase interface {
-	CreateVerifyEmail(verifyEmail *entity.VerifyEmailCreatePayload) (*entity.VerifyEmailDto, error)
+	CreateVerifyEmail(ctx context.Context, verifyEmail *entity.VerifyEmailCreatePayload) (*entity.VerifyEmailDto, error)
 	VerifyEmail(payload *entity.VerifyEmailUpdatePayload) (*entity.VerifyEmailDto, error)
 }
 
@@ -26,7 +27,9 @@ func NewVerifyEmailUseCase(options *VerifyEmailUseCaseOptions) VerifyEmailUseCas
 	}
 }
 
-func (uc *verifyEmailUseCase) CreateVerifyEmail(verifyEmail *entity.VerifyEmailCreatePayload) (*entity.VerifyEmailDto, error) {
+func (uc *verifyEmailUseCase) CreateVerifyEmail(ctx context.Context, verifyEmail *entity.VerifyEmailCreatePayload) (*entity.VerifyEmailDto, error) {
+	uc = uc.withContext(ctx)
+
 	ve, err := uc.verifyEmailRepository.Create(&entity.VerifyEmail{
 		Email:      verifyEmail.Email,
 		SecretCode: verifyEmail.SecretCode,
@@ -65,3 +68,10 @@ func (uc *verifyEmailUseCase) VerifyEmail(payload *entity.VerifyEmailUpdatePaylo
 
 	return ve.ToVerifyEmailDto(), nil
 }
+
+// withContext returns a copy of the use case whose repositories run their queries with ctx.
+func (uc *verifyEmailUseCase) withContext(ctx context.Context) *verifyEmailUseCase {
+	scoped := *uc
+	scoped.verifyEmailRepository = repository.WithContext(ctx, uc.verifyEmailRepository)
+	return &scoped
+}
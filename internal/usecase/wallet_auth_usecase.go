@@ -0,0 +1,321 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fund-o/api-server/internal/datasource/repository"
+	"fund-o/api-server/internal/entity"
+	"fund-o/api-server/pkg/apperrors"
+	"fund-o/api-server/pkg/chain"
+	"fund-o/api-server/pkg/siwe"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	walletNonceTTL        = 5 * time.Minute
+	walletLoginSubject    = "login"
+	walletLinkStatement   = "Link this wallet to your Fund-O account."
+	defaultWalletChainID  = 1
+	walletMessageVersion  = "1"
+	walletLinkSubjectForm = "link:%s:%s"
+)
+
+type WalletAuthUseCase interface {
+	CreateNonce(ctx context.Context) (*entity.WalletNonceResponse, error)
+	AuthenticateWallet(ctx context.Context, payload *entity.UserWalletLoginPayload) (*entity.UserDto, error)
+	CreateLinkChallenge(ctx context.Context, userID string, payload *entity.UserWalletChallengePayload) (*entity.UserWalletChallengeResponse, error)
+	LinkWallet(ctx context.Context, userID string, payload *entity.UserWalletLinkPayload) (*entity.UserDto, error)
+	UnlinkWallet(userID string, address string) (*entity.UserDto, error)
+}
+
+type walletAuthUseCase struct {
+	userRepository  repository.UserRepository
+	nonceRepository repository.NonceRepository
+	domain          string
+	chainID         int64
+}
+
+type WalletAuthUseCaseOptions struct {
+	repository.UserRepository
+	repository.NonceRepository
+	Domain  string
+	ChainID int64
+}
+
+func NewWalletAuthUseCase(options *WalletAuthUseCaseOptions) WalletAuthUseCase {
+	chainID := options.ChainID
+	if chainID == 0 {
+		chainID = defaultWalletChainID
+	}
+
+	return &walletAuthUseCase{
+		userRepository:  options.UserRepository,
+		nonceRepository: options.NonceRepository,
+		domain:          options.Domain,
+		chainID:         chainID,
+	}
+}
+
+func (uc *walletAuthUseCase) CreateNonce(ctx context.Context) (*entity.WalletNonceResponse, error) {
+	uc = uc.withContext(ctx)
+
+	nonce, err := siwe.NewNonce()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.nonceRepository.Create(nonce, walletLoginSubject, walletNonceTTL); err != nil {
+		return nil, err
+	}
+
+	return &entity.WalletNonceResponse{
+		Nonce:     nonce,
+		ExpiredAt: time.Now().Add(walletNonceTTL),
+	}, nil
+}
+
+// AuthenticateWallet verifies a signed Sign-In with Ethereum message and returns the user owning
+// the wallet, creating one on the first sign in.
+func (uc *walletAuthUseCase) AuthenticateWallet(ctx context.Context, payload *entity.UserWalletLoginPayload) (*entity.UserDto, error) {
+	uc = uc.withContext(ctx)
+
+	message, address, err := uc.verifyMessage(payload.Message, payload.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.consumeNonce(message.Nonce, walletLoginSubject); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepository.FindByWalletAddress(address)
+	if err == nil {
+		return user.ToUserDto(), nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// Wallet-only accounts have no email or password yet; the placeholder email keeps the column
+	// unique and uses a reserved domain that can never receive mail.
+	lower := strings.ToLower(address)
+	user, err = uc.userRepository.Create(&entity.User{
+		Email:       fmt.Sprintf("%s@%s", lower, entity.WalletEmailDomain),
+		DisplayName: fmt.Sprintf("%s...%s", lower[:6], lower[len(lower)-4:]),
+		Gender:      entity.NotSay,
+		Role:        entity.RoleUser,
+		Wallets:     []entity.UserWallet{{Address: address}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user.ToUserDto(), nil
+}
+
+// CreateLinkChallenge issues the message the user has to sign with the wallet to prove they control it.
+func (uc *walletAuthUseCase) CreateLinkChallenge(ctx context.Context, userID string, payload *entity.UserWalletChallengePayload) (*entity.UserWalletChallengeResponse, error) {
+	uc = uc.withContext(ctx)
+
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidUserID
+	}
+
+	address, err := chain.ChecksumAddress(payload.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := siwe.NewNonce()
+	if err != nil {
+		return nil, err
+	}
+
+	issuedAt := time.Now().UTC().Truncate(time.Second)
+	expiredAt := issuedAt.Add(walletNonceTTL)
+	message := &siwe.Message{
+		Domain:         uc.domain,
+		Address:        address,
+		Statement:      walletLinkStatement,
+		URI:            "https://" + uc.domain,
+		Version:        walletMessageVersion,
+		ChainID:        uc.chainID,
+		Nonce:          nonce,
+		IssuedAt:       issuedAt,
+		ExpirationTime: &expiredAt,
+	}
+
+	if err := uc.nonceRepository.Create(nonce, linkSubject(id, address), walletNonceTTL); err != nil {
+		return nil, err
+	}
+
+	return &entity.UserWalletChallengeResponse{
+		Message:   message.String(),
+		Nonce:     nonce,
+		ExpiredAt: expiredAt,
+	}, nil
+}
+
+// LinkWallet verifies the signed link challenge and adds the wallet to the user's account.
+func (uc *walletAuthUseCase) LinkWallet(ctx context.Context, userID string, payload *entity.UserWalletLinkPayload) (*entity.UserDto, error) {
+	uc = uc.withContext(ctx)
+
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidUserID
+	}
+
+	message, address, err := uc.verifyMessage(payload.Message, payload.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	// The nonce is bound to the user and the address it was issued for, so a challenge cannot be
+	// reused to link the wallet to someone else's account.
+	if err := uc.consumeNonce(message.Nonce, linkSubject(id, address)); err != nil {
+		return nil, err
+	}
+
+	owner, err := uc.userRepository.FindByWalletAddress(address)
+	if err == nil {
+		if owner.ID != id {
+			return nil, apperrors.ErrWalletAlreadyLinked
+		}
+
+		return owner.ToUserDto(), nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if _, err := uc.userRepository.CreateWallet(&entity.UserWallet{UserID: id, Address: address}); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, apperrors.ErrWalletAlreadyLinked
+		}
+
+		return nil, err
+	}
+
+	return uc.findUser(id)
+}
+
+// UnlinkWallet removes a wallet from the user's account, unless it is the only way left to sign in.
+func (uc *walletAuthUseCase) UnlinkWallet(userID string, address string) (*entity.UserDto, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidUserID
+	}
+
+	address, err = chain.ChecksumAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepository.FindById(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	if !user.HasWallet(address) {
+		return nil, apperrors.ErrWalletNotFound
+	}
+
+	if user.HashedPassword == "" && len(user.Identities) == 0 && len(user.Wallets) == 1 {
+		return nil, apperrors.ErrLastSignInMethod
+	}
+
+	deleted, err := uc.userRepository.DeleteWallet(id, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if !deleted {
+		return nil, apperrors.ErrWalletNotFound
+	}
+
+	return uc.findUser(id)
+}
+
+// verifyMessage checks a signed Sign-In with Ethereum message and returns it with the checksummed
+// address of the signer.
+func (uc *walletAuthUseCase) verifyMessage(raw, signature string) (*siwe.Message, string, error) {
+	message, err := siwe.ParseMessage(raw)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := message.Validate(uc.domain, time.Now()); err != nil {
+		return nil, "", err
+	}
+
+	// A message signed for another chain must not be replayed here.
+	if message.ChainID != uc.chainID {
+		return nil, "", siwe.ErrChainIDMismatch
+	}
+
+	signer, err := chain.RecoverPersonalSigner(raw, signature)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !chain.SameAddress(signer, message.Address) {
+		return nil, "", apperrors.ErrWalletSignatureMismatch
+	}
+
+	address, err := chain.ChecksumAddress(signer)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return message, address, nil
+}
+
+func (uc *walletAuthUseCase) consumeNonce(nonce, subject string) error {
+	issuedFor, err := uc.nonceRepository.Consume(nonce)
+	if err != nil {
+		return err
+	}
+
+	if issuedFor != subject {
+		return apperrors.ErrInvalidNonce
+	}
+
+	return nil
+}
+
+func (uc *walletAuthUseCase) findUser(id uuid.UUID) (*entity.UserDto, error) {
+	user, err := uc.userRepository.FindById(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	return user.ToUserDto(), nil
+}
+
+// withContext returns a copy of the use case whose repositories run their queries with ctx.
+func (uc *walletAuthUseCase) withContext(ctx context.Context) *walletAuthUseCase {
+	scoped := *uc
+	scoped.userRepository = repository.WithContext(ctx, uc.userRepository)
+	scoped.nonceRepository = repository.WithContext(ctx, uc.nonceRepository)
+	return &scoped
+}
+
+func linkSubject(userID uuid.UUID, address string) string {
+	return fmt.Sprintf(walletLinkSubjectForm, userID, address)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/datasource/repository/chain_event_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entity "fund-o/api-server/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockChainEventRepository is a mock of ChainEventRepository interface.
+type MockChainEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChainEventRepositoryMockRecorder
+}
+
+// MockChainEventRepositoryMockRecorder is the mock recorder for MockChainEventRepository.
+type MockChainEventRepositoryMockRecorder struct {
+	mock *MockChainEventRepository
+}
+
+// NewMockChainEventRepository creates a new mock instance.
+func NewMockChainEventRepository(ctrl *gomock.Controller) *MockChainEventRepository {
+	mock := &MockChainEventRepository{ctrl: ctrl}
+	mock.recorder = &MockChainEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChainEventRepository) EXPECT() *MockChainEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindCursor mocks base method.
+func (m *MockChainEventRepository) FindCursor(name string) (*entity.ChainCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCursor", name)
+	ret0, _ := ret[0].(*entity.ChainCursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCursor indicates an expected call of FindCursor.
+func (mr *MockChainEventRepositoryMockRecorder) FindCursor(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCursor", reflect.TypeOf((*MockChainEventRepository)(nil).FindCursor), name)
+}
+
+// FindUnconfirmed mocks base method.
+func (m *MockChainEventRepository) FindUnconfirmed(maxBlockNumber uint64) ([]entity.ChainEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUnconfirmed", maxBlockNumber)
+	ret0, _ := ret[0].([]entity.ChainEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUnconfirmed indicates an expected call of FindUnconfirmed.
+func (mr *MockChainEventRepositoryMockRecorder) FindUnconfirmed(maxBlockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUnconfirmed", reflect.TypeOf((*MockChainEventRepository)(nil).FindUnconfirmed), maxBlockNumber)
+}
+
+// MarkConfirmed mocks base method.
+func (m *MockChainEventRepository) MarkConfirmed(eventID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkConfirmed", eventID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkConfirmed indicates an expected call of MarkConfirmed.
+func (mr *MockChainEventRepositoryMockRecorder) MarkConfirmed(eventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkConfirmed", reflect.TypeOf((*MockChainEventRepository)(nil).MarkConfirmed), eventID)
+}
+
+// Rollback mocks base method.
+func (m *MockChainEventRepository) Rollback(cursor *entity.ChainCursor) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", cursor)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockChainEventRepositoryMockRecorder) Rollback(cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockChainEventRepository)(nil).Rollback), cursor)
+}
+
+// SaveEvents mocks base method.
+func (m *MockChainEventRepository) SaveEvents(cursor *entity.ChainCursor, events []entity.ChainEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveEvents", cursor, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveEvents indicates an expected call of SaveEvents.
+func (mr *MockChainEventRepositoryMockRecorder) SaveEvents(cursor, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEvents", reflect.TypeOf((*MockChainEventRepository)(nil).SaveEvents), cursor, events)
+}
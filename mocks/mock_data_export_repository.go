@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/datasource/repository/data_export_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entity "fund-o/api-server/internal/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockDataExportRepository is a mock of DataExportRepository interface.
+type MockDataExportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataExportRepositoryMockRecorder
+}
+
+// MockDataExportRepositoryMockRecorder is the mock recorder for MockDataExportRepository.
+type MockDataExportRepositoryMockRecorder struct {
+	mock *MockDataExportRepository
+}
+
+// NewMockDataExportRepository creates a new mock instance.
+func NewMockDataExportRepository(ctrl *gomock.Controller) *MockDataExportRepository {
+	mock := &MockDataExportRepository{ctrl: ctrl}
+	mock.recorder = &MockDataExportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataExportRepository) EXPECT() *MockDataExportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDataExportRepository) Create(export *entity.DataExport) (*entity.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", export)
+	ret0, _ := ret[0].(*entity.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDataExportRepositoryMockRecorder) Create(export interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDataExportRepository)(nil).Create), export)
+}
+
+// FindByID mocks base method.
+func (m *MockDataExportRepository) FindByID(id uuid.UUID) (*entity.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*entity.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockDataExportRepositoryMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockDataExportRepository)(nil).FindByID), id)
+}
+
+// FindLatestByUserID mocks base method.
+func (m *MockDataExportRepository) FindLatestByUserID(userID uuid.UUID) (*entity.DataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestByUserID", userID)
+	ret0, _ := ret[0].(*entity.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestByUserID indicates an expected call of FindLatestByUserID.
+func (mr *MockDataExportRepositoryMockRecorder) FindLatestByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestByUserID", reflect.TypeOf((*MockDataExportRepository)(nil).FindLatestByUserID), userID)
+}
+
+// FindUserData mocks base method.
+func (m *MockDataExportRepository) FindUserData(userID uuid.UUID) (*entity.UserData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserData", userID)
+	ret0, _ := ret[0].(*entity.UserData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserData indicates an expected call of FindUserData.
+func (mr *MockDataExportRepositoryMockRecorder) FindUserData(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserData", reflect.TypeOf((*MockDataExportRepository)(nil).FindUserData), userID)
+}
+
+// MarkReady mocks base method.
+func (m *MockDataExportRepository) MarkReady(id uuid.UUID, archive []byte, secretCodeHash string, expiredAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReady", id, archive, secretCodeHash, expiredAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkReady indicates an expected call of MarkReady.
+func (mr *MockDataExportRepositoryMockRecorder) MarkReady(id, archive, secretCodeHash, expiredAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReady", reflect.TypeOf((*MockDataExportRepository)(nil).MarkReady), id, archive, secretCodeHash, expiredAt)
+}
+
+// UpdateSecretCodeHash mocks base method.
+func (m *MockDataExportRepository) UpdateSecretCodeHash(id uuid.UUID, secretCodeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecretCodeHash", id, secretCodeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSecretCodeHash indicates an expected call of UpdateSecretCodeHash.
+func (mr *MockDataExportRepositoryMockRecorder) UpdateSecretCodeHash(id, secretCodeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecretCodeHash", reflect.TypeOf((*MockDataExportRepository)(nil).UpdateSecretCodeHash), id, secretCodeHash)
+}
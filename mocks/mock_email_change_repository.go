@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/datasource/repository/email_change_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entity "fund-o/api-server/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockEmailChangeRepository is a mock of EmailChangeRepository interface.
+type MockEmailChangeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailChangeRepositoryMockRecorder
+}
+
+// MockEmailChangeRepositoryMockRecorder is the mock recorder for MockEmailChangeRepository.
+type MockEmailChangeRepositoryMockRecorder struct {
+	mock *MockEmailChangeRepository
+}
+
+// NewMockEmailChangeRepository creates a new mock instance.
+func NewMockEmailChangeRepository(ctrl *gomock.Controller) *MockEmailChangeRepository {
+	mock := &MockEmailChangeRepository{ctrl: ctrl}
+	mock.recorder = &MockEmailChangeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailChangeRepository) EXPECT() *MockEmailChangeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockEmailChangeRepository) Create(emailChange *entity.EmailChange) (*entity.EmailChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", emailChange)
+	ret0, _ := ret[0].(*entity.EmailChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEmailChangeRepositoryMockRecorder) Create(emailChange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEmailChangeRepository)(nil).Create), emailChange)
+}
+
+// FindByID mocks base method.
+func (m *MockEmailChangeRepository) FindByID(id uuid.UUID) (*entity.EmailChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*entity.EmailChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockEmailChangeRepositoryMockRecorder) FindByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockEmailChangeRepository)(nil).FindByID), id)
+}
+
+// MarkUsed mocks base method.
+func (m *MockEmailChangeRepository) MarkUsed(id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockEmailChangeRepositoryMockRecorder) MarkUsed(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockEmailChangeRepository)(nil).MarkUsed), id)
+}
@@ -95,6 +95,21 @@ func (mr *MockForumRepositoryMockRecorder) CreateReply(reply interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReply", reflect.TypeOf((*MockForumRepository)(nil).CreateReply), reply)
 }
 
+// DeletePost mocks base method.
+func (m *MockForumRepository) DeletePost(id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePost", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePost indicates an expected call of DeletePost.
+func (mr *MockForumRepositoryMockRecorder) DeletePost(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePost", reflect.TypeOf((*MockForumRepository)(nil).DeletePost), id)
+}
+
 // FindAllPostsByAuthorID mocks base method.
 func (m *MockForumRepository) FindAllPostsByAuthorID(authorID uuid.UUID) ([]entity.Post, error) {
 	m.ctrl.T.Helper()
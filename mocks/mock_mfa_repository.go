@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/datasource/repository/mfa_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entity "fund-o/api-server/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockMfaRepository is a mock of MfaRepository interface.
+type MockMfaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMfaRepositoryMockRecorder
+}
+
+// MockMfaRepositoryMockRecorder is the mock recorder for MockMfaRepository.
+type MockMfaRepositoryMockRecorder struct {
+	mock *MockMfaRepository
+}
+
+// NewMockMfaRepository creates a new mock instance.
+func NewMockMfaRepository(ctrl *gomock.Controller) *MockMfaRepository {
+	mock := &MockMfaRepository{ctrl: ctrl}
+	mock.recorder = &MockMfaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMfaRepository) EXPECT() *MockMfaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConfirmTOTP mocks base method.
+func (m *MockMfaRepository) ConfirmTOTP(userID uuid.UUID, step int64, recoveryCodes []entity.UserRecoveryCode) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTP", userID, step, recoveryCodes)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTOTP indicates an expected call of ConfirmTOTP.
+func (mr *MockMfaRepositoryMockRecorder) ConfirmTOTP(userID, step, recoveryCodes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTP", reflect.TypeOf((*MockMfaRepository)(nil).ConfirmTOTP), userID, step, recoveryCodes)
+}
+
+// DeleteTOTP mocks base method.
+func (m *MockMfaRepository) DeleteTOTP(userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTOTP", userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTOTP indicates an expected call of DeleteTOTP.
+func (mr *MockMfaRepositoryMockRecorder) DeleteTOTP(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTOTP", reflect.TypeOf((*MockMfaRepository)(nil).DeleteTOTP), userID)
+}
+
+// FindTOTPByUserID mocks base method.
+func (m *MockMfaRepository) FindTOTPByUserID(userID uuid.UUID) (*entity.UserTOTP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTOTPByUserID", userID)
+	ret0, _ := ret[0].(*entity.UserTOTP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTOTPByUserID indicates an expected call of FindTOTPByUserID.
+func (mr *MockMfaRepositoryMockRecorder) FindTOTPByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTOTPByUserID", reflect.TypeOf((*MockMfaRepository)(nil).FindTOTPByUserID), userID)
+}
+
+// FindUnusedRecoveryCodes mocks base method.
+func (m *MockMfaRepository) FindUnusedRecoveryCodes(userID uuid.UUID) ([]entity.UserRecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUnusedRecoveryCodes", userID)
+	ret0, _ := ret[0].([]entity.UserRecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUnusedRecoveryCodes indicates an expected call of FindUnusedRecoveryCodes.
+func (mr *MockMfaRepositoryMockRecorder) FindUnusedRecoveryCodes(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUnusedRecoveryCodes", reflect.TypeOf((*MockMfaRepository)(nil).FindUnusedRecoveryCodes), userID)
+}
+
+// SaveTOTP mocks base method.
+func (m *MockMfaRepository) SaveTOTP(totp *entity.UserTOTP) (*entity.UserTOTP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTOTP", totp)
+	ret0, _ := ret[0].(*entity.UserTOTP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveTOTP indicates an expected call of SaveTOTP.
+func (mr *MockMfaRepositoryMockRecorder) SaveTOTP(totp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTOTP", reflect.TypeOf((*MockMfaRepository)(nil).SaveTOTP), totp)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockMfaRepository) UseRecoveryCode(id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockMfaRepositoryMockRecorder) UseRecoveryCode(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockMfaRepository)(nil).UseRecoveryCode), id)
+}
+
+// UseTOTPStep mocks base method.
+func (m *MockMfaRepository) UseTOTPStep(userID uuid.UUID, step int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPStep", userID, step)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTOTPStep indicates an expected call of UseTOTPStep.
+func (mr *MockMfaRepositoryMockRecorder) UseTOTPStep(userID, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPStep", reflect.TypeOf((*MockMfaRepository)(nil).UseTOTPStep), userID, step)
+}
@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/datasource/repository/nonce_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNonceRepository is a mock of NonceRepository interface.
+type MockNonceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNonceRepositoryMockRecorder
+}
+
+// MockNonceRepositoryMockRecorder is the mock recorder for MockNonceRepository.
+type MockNonceRepositoryMockRecorder struct {
+	mock *MockNonceRepository
+}
+
+// NewMockNonceRepository creates a new mock instance.
+func NewMockNonceRepository(ctrl *gomock.Controller) *MockNonceRepository {
+	mock := &MockNonceRepository{ctrl: ctrl}
+	mock.recorder = &MockNonceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNonceRepository) EXPECT() *MockNonceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockNonceRepository) Consume(nonce string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", nonce)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockNonceRepositoryMockRecorder) Consume(nonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockNonceRepository)(nil).Consume), nonce)
+}
+
+// Create mocks base method.
+func (m *MockNonceRepository) Create(nonce, subject string, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", nonce, subject, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockNonceRepositoryMockRecorder) Create(nonce, subject, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNonceRepository)(nil).Create), nonce, subject, ttl)
+}
@@ -34,6 +34,21 @@ func (m *MockProjectCategoryRepository) EXPECT() *MockProjectCategoryRepositoryM
 	return m.recorder
 }
 
+// Create mocks base method.
+func (m *MockProjectCategoryRepository) Create(category *entity.ProjectCategory) (*entity.ProjectCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", category)
+	ret0, _ := ret[0].(*entity.ProjectCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProjectCategoryRepositoryMockRecorder) Create(category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProjectCategoryRepository)(nil).Create), category)
+}
+
 // FindAll mocks base method.
 func (m *MockProjectCategoryRepository) FindAll() ([]entity.ProjectCategory, error) {
 	m.ctrl.T.Helper()
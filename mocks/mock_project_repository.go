@@ -8,9 +8,11 @@ import (
 	entity "fund-o/api-server/internal/entity"
 	pagination "fund-o/api-server/pkg/pagination"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
+	decimal "github.com/shopspring/decimal"
 )
 
 // MockProjectRepository is a mock of ProjectRepository interface.
@@ -36,18 +38,48 @@ func (m *MockProjectRepository) EXPECT() *MockProjectRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CloseExpired mocks base method.
+func (m *MockProjectRepository) CloseExpired(now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseExpired", now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseExpired indicates an expected call of CloseExpired.
+func (mr *MockProjectRepositoryMockRecorder) CloseExpired(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseExpired", reflect.TypeOf((*MockProjectRepository)(nil).CloseExpired), now)
+}
+
+// CompleteRefund mocks base method.
+func (m *MockProjectRepository) CompleteRefund(refundID uuid.UUID, event *entity.ChainEvent) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteRefund", refundID, event)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteRefund indicates an expected call of CompleteRefund.
+func (mr *MockProjectRepositoryMockRecorder) CompleteRefund(refundID, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteRefund", reflect.TypeOf((*MockProjectRepository)(nil).CompleteRefund), refundID, event)
+}
+
 // Count mocks base method.
-func (m *MockProjectRepository) Count() int64 {
+func (m *MockProjectRepository) Count(findOptions entity.ProjectListOptions) int64 {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Count")
+	ret := m.ctrl.Call(m, "Count", findOptions)
 	ret0, _ := ret[0].(int64)
 	return ret0
 }
 
 // Count indicates an expected call of Count.
-func (mr *MockProjectRepositoryMockRecorder) Count() *gomock.Call {
+func (mr *MockProjectRepositoryMockRecorder) Count(findOptions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockProjectRepository)(nil).Count))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockProjectRepository)(nil).Count), findOptions)
 }
 
 // Create mocks base method.
@@ -95,6 +127,50 @@ func (mr *MockProjectRepositoryMockRecorder) CreateProjectRating(rating interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProjectRating", reflect.TypeOf((*MockProjectRepository)(nil).CreateProjectRating), rating)
 }
 
+// CreateReward mocks base method.
+func (m *MockProjectRepository) CreateReward(reward *entity.ProjectReward) (*entity.ProjectReward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReward", reward)
+	ret0, _ := ret[0].(*entity.ProjectReward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReward indicates an expected call of CreateReward.
+func (mr *MockProjectRepositoryMockRecorder) CreateReward(reward interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReward", reflect.TypeOf((*MockProjectRepository)(nil).CreateReward), reward)
+}
+
+// DeleteReward mocks base method.
+func (m *MockProjectRepository) DeleteReward(rewardID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReward", rewardID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReward indicates an expected call of DeleteReward.
+func (mr *MockProjectRepositoryMockRecorder) DeleteReward(rewardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReward", reflect.TypeOf((*MockProjectRepository)(nil).DeleteReward), rewardID)
+}
+
+// FailRefund mocks base method.
+func (m *MockProjectRepository) FailRefund(refundID uuid.UUID, reason string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailRefund", refundID, reason)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailRefund indicates an expected call of FailRefund.
+func (mr *MockProjectRepositoryMockRecorder) FailRefund(refundID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailRefund", reflect.TypeOf((*MockProjectRepository)(nil).FailRefund), refundID, reason)
+}
+
 // FindAll mocks base method.
 func (m *MockProjectRepository) FindAll(paginateOptions pagination.PaginateFindOptions, findOptions entity.ProjectListOptions) []entity.Project {
 	m.ctrl.T.Helper()
@@ -139,6 +215,36 @@ func (mr *MockProjectRepositoryMockRecorder) FindBackProjectsByUserID(userID int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBackProjectsByUserID", reflect.TypeOf((*MockProjectRepository)(nil).FindBackProjectsByUserID), userID)
 }
 
+// FindBackerEmails mocks base method.
+func (m *MockProjectRepository) FindBackerEmails(projectID uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBackerEmails", projectID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBackerEmails indicates an expected call of FindBackerEmails.
+func (mr *MockProjectRepositoryMockRecorder) FindBackerEmails(projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBackerEmails", reflect.TypeOf((*MockProjectRepository)(nil).FindBackerEmails), projectID)
+}
+
+// FindByContractID mocks base method.
+func (m *MockProjectRepository) FindByContractID(contractID string) (*entity.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByContractID", contractID)
+	ret0, _ := ret[0].(*entity.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByContractID indicates an expected call of FindByContractID.
+func (mr *MockProjectRepositoryMockRecorder) FindByContractID(contractID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByContractID", reflect.TypeOf((*MockProjectRepository)(nil).FindByContractID), contractID)
+}
+
 // FindByID mocks base method.
 func (m *MockProjectRepository) FindByID(projectID uuid.UUID) (*entity.Project, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +260,66 @@ func (mr *MockProjectRepositoryMockRecorder) FindByID(projectID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockProjectRepository)(nil).FindByID), projectID)
 }
 
+// FindContractAddresses mocks base method.
+func (m *MockProjectRepository) FindContractAddresses() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindContractAddresses")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindContractAddresses indicates an expected call of FindContractAddresses.
+func (mr *MockProjectRepositoryMockRecorder) FindContractAddresses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindContractAddresses", reflect.TypeOf((*MockProjectRepository)(nil).FindContractAddresses))
+}
+
+// FindOpenRefund mocks base method.
+func (m *MockProjectRepository) FindOpenRefund(projectID, userID uuid.UUID, amount decimal.Decimal) (*entity.ProjectRefund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOpenRefund", projectID, userID, amount)
+	ret0, _ := ret[0].(*entity.ProjectRefund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOpenRefund indicates an expected call of FindOpenRefund.
+func (mr *MockProjectRepositoryMockRecorder) FindOpenRefund(projectID, userID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpenRefund", reflect.TypeOf((*MockProjectRepository)(nil).FindOpenRefund), projectID, userID, amount)
+}
+
+// FindProjectBackerByID mocks base method.
+func (m *MockProjectRepository) FindProjectBackerByID(backerID uuid.UUID) (*entity.ProjectBacker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindProjectBackerByID", backerID)
+	ret0, _ := ret[0].(*entity.ProjectBacker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindProjectBackerByID indicates an expected call of FindProjectBackerByID.
+func (mr *MockProjectRepositoryMockRecorder) FindProjectBackerByID(backerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindProjectBackerByID", reflect.TypeOf((*MockProjectRepository)(nil).FindProjectBackerByID), backerID)
+}
+
+// FindProjectBackerByTxHash mocks base method.
+func (m *MockProjectRepository) FindProjectBackerByTxHash(txHash string) (*entity.ProjectBacker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindProjectBackerByTxHash", txHash)
+	ret0, _ := ret[0].(*entity.ProjectBacker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindProjectBackerByTxHash indicates an expected call of FindProjectBackerByTxHash.
+func (mr *MockProjectRepositoryMockRecorder) FindProjectBackerByTxHash(txHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindProjectBackerByTxHash", reflect.TypeOf((*MockProjectRepository)(nil).FindProjectBackerByTxHash), txHash)
+}
+
 // FindProjectRating mocks base method.
 func (m *MockProjectRepository) FindProjectRating(userID, projectID uuid.UUID) (*entity.ProjectRating, error) {
 	m.ctrl.T.Helper()
@@ -184,6 +350,81 @@ func (mr *MockProjectRepositoryMockRecorder) FindRecommendation(count interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRecommendation", reflect.TypeOf((*MockProjectRepository)(nil).FindRecommendation), count)
 }
 
+// FindRefundByID mocks base method.
+func (m *MockProjectRepository) FindRefundByID(refundID uuid.UUID) (*entity.ProjectRefund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRefundByID", refundID)
+	ret0, _ := ret[0].(*entity.ProjectRefund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRefundByID indicates an expected call of FindRefundByID.
+func (mr *MockProjectRepositoryMockRecorder) FindRefundByID(refundID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRefundByID", reflect.TypeOf((*MockProjectRepository)(nil).FindRefundByID), refundID)
+}
+
+// FindRefundEvent mocks base method.
+func (m *MockProjectRepository) FindRefundEvent(contractAddress, account string, amount decimal.Decimal) (*entity.ChainEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRefundEvent", contractAddress, account, amount)
+	ret0, _ := ret[0].(*entity.ChainEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRefundEvent indicates an expected call of FindRefundEvent.
+func (mr *MockProjectRepositoryMockRecorder) FindRefundEvent(contractAddress, account, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRefundEvent", reflect.TypeOf((*MockProjectRepository)(nil).FindRefundEvent), contractAddress, account, amount)
+}
+
+// FindRefundableBackers mocks base method.
+func (m *MockProjectRepository) FindRefundableBackers(projectID, userID uuid.UUID) ([]entity.ProjectBacker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRefundableBackers", projectID, userID)
+	ret0, _ := ret[0].([]entity.ProjectBacker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRefundableBackers indicates an expected call of FindRefundableBackers.
+func (mr *MockProjectRepositoryMockRecorder) FindRefundableBackers(projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRefundableBackers", reflect.TypeOf((*MockProjectRepository)(nil).FindRefundableBackers), projectID, userID)
+}
+
+// FindRewardByID mocks base method.
+func (m *MockProjectRepository) FindRewardByID(rewardID uuid.UUID) (*entity.ProjectReward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRewardByID", rewardID)
+	ret0, _ := ret[0].(*entity.ProjectReward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRewardByID indicates an expected call of FindRewardByID.
+func (mr *MockProjectRepositoryMockRecorder) FindRewardByID(rewardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRewardByID", reflect.TypeOf((*MockProjectRepository)(nil).FindRewardByID), rewardID)
+}
+
+// FindRewardsByProjectID mocks base method.
+func (m *MockProjectRepository) FindRewardsByProjectID(projectID uuid.UUID) ([]entity.ProjectReward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRewardsByProjectID", projectID)
+	ret0, _ := ret[0].([]entity.ProjectReward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRewardsByProjectID indicates an expected call of FindRewardsByProjectID.
+func (mr *MockProjectRepositoryMockRecorder) FindRewardsByProjectID(projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRewardsByProjectID", reflect.TypeOf((*MockProjectRepository)(nil).FindRewardsByProjectID), projectID)
+}
+
 // GetProjectBacker mocks base method.
 func (m *MockProjectRepository) GetProjectBacker(userID, projectID uuid.UUID) (entity.ProjectBacker, error) {
 	m.ctrl.T.Helper()
@@ -199,6 +440,36 @@ func (mr *MockProjectRepositoryMockRecorder) GetProjectBacker(userID, projectID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectBacker", reflect.TypeOf((*MockProjectRepository)(nil).GetProjectBacker), userID, projectID)
 }
 
+// IsProjectBacker mocks base method.
+func (m *MockProjectRepository) IsProjectBacker(projectID, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsProjectBacker", projectID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsProjectBacker indicates an expected call of IsProjectBacker.
+func (mr *MockProjectRepositoryMockRecorder) IsProjectBacker(projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsProjectBacker", reflect.TypeOf((*MockProjectRepository)(nil).IsProjectBacker), projectID, userID)
+}
+
+// RequestRefunds mocks base method.
+func (m *MockProjectRepository) RequestRefunds(backers []entity.ProjectBacker) ([]entity.ProjectRefund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestRefunds", backers)
+	ret0, _ := ret[0].([]entity.ProjectRefund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestRefunds indicates an expected call of RequestRefunds.
+func (mr *MockProjectRepositoryMockRecorder) RequestRefunds(backers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestRefunds", reflect.TypeOf((*MockProjectRepository)(nil).RequestRefunds), backers)
+}
+
 // UpdateProjectBacker mocks base method.
 func (m *MockProjectRepository) UpdateProjectBacker(backer *entity.ProjectBacker) (*entity.ProjectBacker, error) {
 	m.ctrl.T.Helper()
@@ -213,3 +484,63 @@ func (mr *MockProjectRepositoryMockRecorder) UpdateProjectBacker(backer interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProjectBacker", reflect.TypeOf((*MockProjectRepository)(nil).UpdateProjectBacker), backer)
 }
+
+// UpdateProjectBackerStatus mocks base method.
+func (m *MockProjectRepository) UpdateProjectBackerStatus(backerID uuid.UUID, from, to entity.ProjectBackerStatus) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProjectBackerStatus", backerID, from, to)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProjectBackerStatus indicates an expected call of UpdateProjectBackerStatus.
+func (mr *MockProjectRepositoryMockRecorder) UpdateProjectBackerStatus(backerID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProjectBackerStatus", reflect.TypeOf((*MockProjectRepository)(nil).UpdateProjectBackerStatus), backerID, from, to)
+}
+
+// UpdateRefundStatus mocks base method.
+func (m *MockProjectRepository) UpdateRefundStatus(refundID uuid.UUID, from, to entity.RefundStatus) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRefundStatus", refundID, from, to)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRefundStatus indicates an expected call of UpdateRefundStatus.
+func (mr *MockProjectRepositoryMockRecorder) UpdateRefundStatus(refundID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRefundStatus", reflect.TypeOf((*MockProjectRepository)(nil).UpdateRefundStatus), refundID, from, to)
+}
+
+// UpdateReward mocks base method.
+func (m *MockProjectRepository) UpdateReward(reward *entity.ProjectReward) (*entity.ProjectReward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReward", reward)
+	ret0, _ := ret[0].(*entity.ProjectReward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateReward indicates an expected call of UpdateReward.
+func (mr *MockProjectRepositoryMockRecorder) UpdateReward(reward interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReward", reflect.TypeOf((*MockProjectRepository)(nil).UpdateReward), reward)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockProjectRepository) UpdateStatus(projectID uuid.UUID, from, to entity.ProjectStatus) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", projectID, from, to)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockProjectRepositoryMockRecorder) UpdateStatus(projectID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockProjectRepository)(nil).UpdateStatus), projectID, from, to)
+}
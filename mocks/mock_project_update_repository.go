@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/datasource/repository/project_update_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	entity "fund-o/api-server/internal/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockProjectUpdateRepository is a mock of ProjectUpdateRepository interface.
+type MockProjectUpdateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectUpdateRepositoryMockRecorder
+}
+
+// MockProjectUpdateRepositoryMockRecorder is the mock recorder for MockProjectUpdateRepository.
+type MockProjectUpdateRepositoryMockRecorder struct {
+	mock *MockProjectUpdateRepository
+}
+
+// NewMockProjectUpdateRepository creates a new mock instance.
+func NewMockProjectUpdateRepository(ctrl *gomock.Controller) *MockProjectUpdateRepository {
+	mock := &MockProjectUpdateRepository{ctrl: ctrl}
+	mock.recorder = &MockProjectUpdateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProjectUpdateRepository) EXPECT() *MockProjectUpdateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockProjectUpdateRepository) Create(update *entity.ProjectUpdate) (*entity.ProjectUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", update)
+	ret0, _ := ret[0].(*entity.ProjectUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProjectUpdateRepositoryMockRecorder) Create(update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProjectUpdateRepository)(nil).Create), update)
+}
+
+// Delete mocks base method.
+func (m *MockProjectUpdateRepository) Delete(updateID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", updateID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProjectUpdateRepositoryMockRecorder) Delete(updateID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProjectUpdateRepository)(nil).Delete), updateID)
+}
+
+// FindAllByProjectID mocks base method.
+func (m *MockProjectUpdateRepository) FindAllByProjectID(projectID uuid.UUID, includeBackersOnly bool) ([]entity.ProjectUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByProjectID", projectID, includeBackersOnly)
+	ret0, _ := ret[0].([]entity.ProjectUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByProjectID indicates an expected call of FindAllByProjectID.
+func (mr *MockProjectUpdateRepositoryMockRecorder) FindAllByProjectID(projectID, includeBackersOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByProjectID", reflect.TypeOf((*MockProjectUpdateRepository)(nil).FindAllByProjectID), projectID, includeBackersOnly)
+}
+
+// FindByID mocks base method.
+func (m *MockProjectUpdateRepository) FindByID(updateID uuid.UUID) (*entity.ProjectUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", updateID)
+	ret0, _ := ret[0].(*entity.ProjectUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockProjectUpdateRepositoryMockRecorder) FindByID(updateID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockProjectUpdateRepository)(nil).FindByID), updateID)
+}
+
+// Update mocks base method.
+func (m *MockProjectUpdateRepository) Update(update *entity.ProjectUpdate) (*entity.ProjectUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", update)
+	ret0, _ := ret[0].(*entity.ProjectUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockProjectUpdateRepositoryMockRecorder) Update(update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProjectUpdateRepository)(nil).Update), update)
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	entity "fund-o/api-server/internal/entity"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -35,6 +36,51 @@ func (m *MockSessionRepository) EXPECT() *MockSessionRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Block mocks base method.
+func (m *MockSessionRepository) Block(id, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Block", id, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Block indicates an expected call of Block.
+func (mr *MockSessionRepositoryMockRecorder) Block(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Block", reflect.TypeOf((*MockSessionRepository)(nil).Block), id, userID)
+}
+
+// BlockAllByUserID mocks base method.
+func (m *MockSessionRepository) BlockAllByUserID(userID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockAllByUserID", userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockAllByUserID indicates an expected call of BlockAllByUserID.
+func (mr *MockSessionRepositoryMockRecorder) BlockAllByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockAllByUserID", reflect.TypeOf((*MockSessionRepository)(nil).BlockAllByUserID), userID)
+}
+
+// BlockFamily mocks base method.
+func (m *MockSessionRepository) BlockFamily(familyID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockFamily", familyID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockFamily indicates an expected call of BlockFamily.
+func (mr *MockSessionRepositoryMockRecorder) BlockFamily(familyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockFamily", reflect.TypeOf((*MockSessionRepository)(nil).BlockFamily), familyID)
+}
+
 // Create mocks base method.
 func (m *MockSessionRepository) Create(session *entity.Session) (*entity.Session, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +96,21 @@ func (mr *MockSessionRepositoryMockRecorder) Create(session interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSessionRepository)(nil).Create), session)
 }
 
+// FindActiveByUserID mocks base method.
+func (m *MockSessionRepository) FindActiveByUserID(userID uuid.UUID, now time.Time) ([]entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveByUserID", userID, now)
+	ret0, _ := ret[0].([]entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveByUserID indicates an expected call of FindActiveByUserID.
+func (mr *MockSessionRepositoryMockRecorder) FindActiveByUserID(userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveByUserID", reflect.TypeOf((*MockSessionRepository)(nil).FindActiveByUserID), userID, now)
+}
+
 // FindByID mocks base method.
 func (m *MockSessionRepository) FindByID(id uuid.UUID) (*entity.Session, error) {
 	m.ctrl.T.Helper()
@@ -64,3 +125,18 @@ func (mr *MockSessionRepositoryMockRecorder) FindByID(id interface{}) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockSessionRepository)(nil).FindByID), id)
 }
+
+// Rotate mocks base method.
+func (m *MockSessionRepository) Rotate(previousID uuid.UUID, next *entity.Session) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", previousID, next)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockSessionRepositoryMockRecorder) Rotate(previousID, next interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockSessionRepository)(nil).Rotate), previousID, next)
+}
@@ -36,6 +36,108 @@ func (m *MockTaskDistributor) EXPECT() *MockTaskDistributorMockRecorder {
 	return m.recorder
 }
 
+// DistributeTaskGenerateDataExport mocks base method.
+func (m *MockTaskDistributor) DistributeTaskGenerateDataExport(ctx context.Context, payload *worker.PayloadGenerateDataExport, opts ...asynq.Option) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "DistributeTaskGenerateDataExport", varargs...)
+}
+
+// DistributeTaskGenerateDataExport indicates an expected call of DistributeTaskGenerateDataExport.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskGenerateDataExport(ctx, payload interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskGenerateDataExport", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskGenerateDataExport), varargs...)
+}
+
+// DistributeTaskProcessRefund mocks base method.
+func (m *MockTaskDistributor) DistributeTaskProcessRefund(ctx context.Context, payload *worker.PayloadProcessRefund, opts ...asynq.Option) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "DistributeTaskProcessRefund", varargs...)
+}
+
+// DistributeTaskProcessRefund indicates an expected call of DistributeTaskProcessRefund.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskProcessRefund(ctx, payload interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskProcessRefund", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskProcessRefund), varargs...)
+}
+
+// DistributeTaskSendAccountLockedEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendAccountLockedEmail(ctx context.Context, payload *worker.PayloadSendAccountLockedEmail, opts ...asynq.Option) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "DistributeTaskSendAccountLockedEmail", varargs...)
+}
+
+// DistributeTaskSendAccountLockedEmail indicates an expected call of DistributeTaskSendAccountLockedEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendAccountLockedEmail(ctx, payload interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendAccountLockedEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendAccountLockedEmail), varargs...)
+}
+
+// DistributeTaskSendEmailChangeEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendEmailChangeEmail(ctx context.Context, payload *worker.PayloadSendEmailChangeEmail, opts ...asynq.Option) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "DistributeTaskSendEmailChangeEmail", varargs...)
+}
+
+// DistributeTaskSendEmailChangeEmail indicates an expected call of DistributeTaskSendEmailChangeEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendEmailChangeEmail(ctx, payload interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendEmailChangeEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendEmailChangeEmail), varargs...)
+}
+
+// DistributeTaskSendPasswordResetEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendPasswordResetEmail(ctx context.Context, payload *worker.PayloadSendPasswordResetEmail, opts ...asynq.Option) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "DistributeTaskSendPasswordResetEmail", varargs...)
+}
+
+// DistributeTaskSendPasswordResetEmail indicates an expected call of DistributeTaskSendPasswordResetEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendPasswordResetEmail(ctx, payload interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendPasswordResetEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendPasswordResetEmail), varargs...)
+}
+
+// DistributeTaskSendProjectUpdateEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendProjectUpdateEmail(ctx context.Context, payload *worker.PayloadSendProjectUpdateEmail, opts ...asynq.Option) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "DistributeTaskSendProjectUpdateEmail", varargs...)
+}
+
+// DistributeTaskSendProjectUpdateEmail indicates an expected call of DistributeTaskSendProjectUpdateEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendProjectUpdateEmail(ctx, payload interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendProjectUpdateEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendProjectUpdateEmail), varargs...)
+}
+
 // DistributeTaskSendVerifyEmail mocks base method.
 func (m *MockTaskDistributor) DistributeTaskSendVerifyEmail(ctx context.Context, payload *worker.PayloadSendVerifyEmail, opts ...asynq.Option) {
 	m.ctrl.T.Helper()
@@ -52,3 +154,20 @@ func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendVerifyEmail(ctx, pa
 	varargs := append([]interface{}{ctx, payload}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendVerifyEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendVerifyEmail), varargs...)
 }
+
+// DistributeTaskVerifyContribution mocks base method.
+func (m *MockTaskDistributor) DistributeTaskVerifyContribution(ctx context.Context, payload *worker.PayloadVerifyContribution, opts ...asynq.Option) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "DistributeTaskVerifyContribution", varargs...)
+}
+
+// DistributeTaskVerifyContribution indicates an expected call of DistributeTaskVerifyContribution.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskVerifyContribution(ctx, payload interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskVerifyContribution", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskVerifyContribution), varargs...)
+}
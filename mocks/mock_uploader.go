@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	multipart "mime/multipart"
 	reflect "reflect"
 
@@ -34,6 +35,20 @@ func (m *MockImageUploader) EXPECT() *MockImageUploaderMockRecorder {
 	return m.recorder
 }
 
+// Ping mocks base method.
+func (m *MockImageUploader) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockImageUploaderMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockImageUploader)(nil).Ping), ctx)
+}
+
 // Upload mocks base method.
 func (m *MockImageUploader) Upload(folder string, file *multipart.FileHeader) (string, error) {
 	m.ctrl.T.Helper()
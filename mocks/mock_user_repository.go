@@ -35,6 +35,20 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Anonymize mocks base method.
+func (m *MockUserRepository) Anonymize(id uuid.UUID, user *entity.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Anonymize", id, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Anonymize indicates an expected call of Anonymize.
+func (mr *MockUserRepositoryMockRecorder) Anonymize(id, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Anonymize", reflect.TypeOf((*MockUserRepository)(nil).Anonymize), id, user)
+}
+
 // Create mocks base method.
 func (m *MockUserRepository) Create(user *entity.User) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +64,51 @@ func (mr *MockUserRepositoryMockRecorder) Create(user interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepository)(nil).Create), user)
 }
 
+// CreateIdentity mocks base method.
+func (m *MockUserRepository) CreateIdentity(identity *entity.UserIdentity) (*entity.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdentity", identity)
+	ret0, _ := ret[0].(*entity.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIdentity indicates an expected call of CreateIdentity.
+func (mr *MockUserRepositoryMockRecorder) CreateIdentity(identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdentity", reflect.TypeOf((*MockUserRepository)(nil).CreateIdentity), identity)
+}
+
+// CreateWallet mocks base method.
+func (m *MockUserRepository) CreateWallet(wallet *entity.UserWallet) (*entity.UserWallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWallet", wallet)
+	ret0, _ := ret[0].(*entity.UserWallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWallet indicates an expected call of CreateWallet.
+func (mr *MockUserRepositoryMockRecorder) CreateWallet(wallet interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallet", reflect.TypeOf((*MockUserRepository)(nil).CreateWallet), wallet)
+}
+
+// DeleteWallet mocks base method.
+func (m *MockUserRepository) DeleteWallet(userID uuid.UUID, address string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWallet", userID, address)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWallet indicates an expected call of DeleteWallet.
+func (mr *MockUserRepositoryMockRecorder) DeleteWallet(userID, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWallet", reflect.TypeOf((*MockUserRepository)(nil).DeleteWallet), userID, address)
+}
+
 // FindByEmail mocks base method.
 func (m *MockUserRepository) FindByEmail(email string) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +139,36 @@ func (mr *MockUserRepositoryMockRecorder) FindById(id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockUserRepository)(nil).FindById), id)
 }
 
+// FindByIdentity mocks base method.
+func (m *MockUserRepository) FindByIdentity(provider, subject string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdentity", provider, subject)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdentity indicates an expected call of FindByIdentity.
+func (mr *MockUserRepositoryMockRecorder) FindByIdentity(provider, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdentity", reflect.TypeOf((*MockUserRepository)(nil).FindByIdentity), provider, subject)
+}
+
+// FindByWalletAddress mocks base method.
+func (m *MockUserRepository) FindByWalletAddress(address string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByWalletAddress", address)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByWalletAddress indicates an expected call of FindByWalletAddress.
+func (mr *MockUserRepositoryMockRecorder) FindByWalletAddress(address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByWalletAddress", reflect.TypeOf((*MockUserRepository)(nil).FindByWalletAddress), address)
+}
+
 // UpdateByID mocks base method.
 func (m *MockUserRepository) UpdateByID(id uuid.UUID, user *entity.User) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -6,4 +6,29 @@ var (
 	ErrPasswordAndConfirmationNotMatch = errors.New("password and password confirmation does not match")
 	ErrHashPassword                    = errors.New("failed to hash password")
 	ErrInvalidBirthDateFormat          = errors.New("invalid birth date format")
+	ErrInvalidNonce                    = errors.New("nonce is invalid or has already been used")
+	ErrWalletSignatureMismatch         = errors.New("signature was not made by the message address")
+	ErrWalletAlreadyLinked             = errors.New("wallet is already linked to an account")
+	ErrWalletNotFound                  = errors.New("wallet is not linked to this account")
+	ErrLastSignInMethod                = errors.New("cannot unlink the only way to sign in to this account")
+	ErrInvalidSessionID                = errors.New("invalid session id")
+	ErrSessionNotFound                 = errors.New("session not found")
+	ErrRefreshTokenReused              = errors.New("refresh token has already been used, please sign in again")
+	ErrInvalidPasswordReset            = errors.New("password reset link is invalid or has already been used")
+	ErrPasswordResetExpired            = errors.New("password reset link has expired")
+	ErrInvalidOAuthState               = errors.New("sign-in state is invalid or has expired, please try again")
+	ErrOAuthEmailNotVerified           = errors.New("the email of this account has not been verified by the provider")
+	ErrOAuthAccountNotVerified         = errors.New("an account with this email exists but its email is not verified, please sign in with your password and verify it first")
+	ErrTOTPAlreadyEnabled              = errors.New("two-factor authentication is already enabled")
+	ErrTOTPNotEnrolled                 = errors.New("two-factor authentication has not been set up")
+	ErrInvalidTOTPCode                 = errors.New("invalid two-factor authentication code")
+	ErrInvalidMfaToken                 = errors.New("two-factor challenge is invalid or has expired, please sign in again")
+	ErrInvalidCredentials              = errors.New("invalid email or password")
+	ErrTooManyLoginAttempts            = errors.New("too many failed login attempts, please try again later")
+	ErrInvalidApiKey                   = errors.New("api key is invalid or has expired")
+	ErrInvalidApiKeyID                 = errors.New("invalid api key id")
+	ErrInvalidApiKeyExpiry             = errors.New("api key expiry must be in the future")
+	ErrApiKeyNotFound                  = errors.New("api key not found")
+	ErrInvalidEmailChange              = errors.New("email change link is invalid or has already been used")
+	ErrEmailChangeExpired              = errors.New("email change link has expired")
 )
@@ -0,0 +1,7 @@
+package apperrors
+
+import "errors"
+
+var (
+	ErrPostNotFound = errors.New("post not found")
+)
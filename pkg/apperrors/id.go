@@ -5,4 +5,5 @@ import "errors"
 var (
 	ErrInvalidUserID    = errors.New("invalid user id")
 	ErrInvalidProjectID = errors.New("invalid project id")
+	ErrInvalidPostID    = errors.New("invalid post id")
 )
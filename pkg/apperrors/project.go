@@ -3,5 +3,24 @@ package apperrors
 import "errors"
 
 var (
-	ErrAlreadyRatedProject = errors.New("user already rated this project")
+	ErrAlreadyRatedProject            = errors.New("user already rated this project")
+	ErrProjectNotLive                 = errors.New("project is not accepting contributions")
+	ErrNotProjectOwner                = errors.New("user is not the owner of this project")
+	ErrInvalidProjectStatusTransition = errors.New("invalid project status transition")
+	ErrInvalidProjectStatus           = errors.New("invalid project status")
+	ErrInvalidFundingGoal             = errors.New("funding goal must be a positive number")
+	ErrInvalidRewardID                = errors.New("invalid reward id")
+	ErrRewardNotFound                 = errors.New("reward not found")
+	ErrRewardSoldOut                  = errors.New("reward is sold out")
+	ErrRewardMinimumNotMet            = errors.New("contribution amount is below the reward minimum")
+	ErrRewardLimitBelowClaimed        = errors.New("quantity limit cannot be lower than the number of claimed rewards")
+	ErrRewardAlreadyClaimed           = errors.New("reward has already been claimed by backers")
+	ErrTxHashAlreadyUsed              = errors.New("transaction hash has already been used for another contribution")
+	ErrContributionPending            = errors.New("contribution transaction is not confirmed yet")
+	ErrProjectNotRefundable           = errors.New("project has not failed or been cancelled")
+	ErrNothingToRefund                = errors.New("there are no contributions to refund")
+	ErrRefundPending                  = errors.New("refund has not been paid out on chain yet")
+	ErrInvalidProjectUpdateID         = errors.New("invalid project update id")
+	ErrProjectUpdateNotFound          = errors.New("project update not found")
+	ErrBackersOnlyUpdate              = errors.New("project update is only visible to backers")
 )
@@ -3,5 +3,11 @@ package apperrors
 import "errors"
 
 var (
-	ErrUserNotFound = errors.New("user not found")
+	ErrUserNotFound      = errors.New("user not found")
+	ErrEmailAlreadyUsed  = errors.New("email is already used by another account")
+	ErrSameEmail         = errors.New("new email is the same as the current one")
+	ErrIncorrectPassword = errors.New("current password is incorrect")
+	ErrActiveProjects    = errors.New("cannot delete an account that owns a project in review or live")
+	ErrInvalidDataExport = errors.New("data export link is invalid")
+	ErrDataExportExpired = errors.New("data export link has expired")
 )
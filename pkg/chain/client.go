@@ -0,0 +1,109 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+var (
+	ErrTransactionNotFound = errors.New("transaction not found")
+	ErrBlockNotFound       = errors.New("block not found")
+	ErrInvalidHash         = errors.New("invalid transaction hash")
+	ErrInvalidAddress      = errors.New("invalid address")
+)
+
+type TransactionStatus int
+
+const (
+	TransactionPending TransactionStatus = iota + 1
+	TransactionSucceeded
+	TransactionFailed
+)
+
+type Transaction struct {
+	Hash        string
+	From        string
+	To          string
+	Value       *big.Int
+	BlockNumber uint64
+	Status      TransactionStatus
+}
+
+// Log is a contract event emitted in a mined block.
+type Log struct {
+	Address     string
+	Topics      []string
+	Data        string
+	BlockNumber uint64
+	BlockHash   string
+	TxHash      string
+	LogIndex    uint64
+}
+
+// LogQuery selects the logs of a block range. Topics filters on the first topic (the event
+// signature) and matches any of the given values; empty fields match everything.
+type LogQuery struct {
+	FromBlock uint64
+	ToBlock   uint64
+	Addresses []string
+	Topics    []string
+}
+
+// Client reads transactions, blocks and logs from an EVM compatible chain.
+type Client interface {
+	TransactionByHash(ctx context.Context, hash string) (*Transaction, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	BlockHash(ctx context.Context, number uint64) (string, error)
+	FilterLogs(ctx context.Context, query LogQuery) ([]Log, error)
+}
+
+var hashPattern = regexp.MustCompile(`^0x[0-9a-f]{64}$`)
+
+// NormalizeHash lower-cases a transaction hash and validates its format.
+func NormalizeHash(hash string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(hash))
+	if !hashPattern.MatchString(normalized) {
+		return "", ErrInvalidHash
+	}
+
+	return normalized, nil
+}
+
+// SameAddress compares two hex addresses ignoring their checksum casing.
+func SameAddress(a, b string) bool {
+	return a != "" && strings.EqualFold(a, b)
+}
+
+var addressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// ChecksumAddress validates a hex address and returns its mixed-case EIP-55 form.
+func ChecksumAddress(address string) (string, error) {
+	address = strings.TrimSpace(address)
+	if !addressPattern.MatchString(address) {
+		return "", ErrInvalidAddress
+	}
+
+	lower := strings.ToLower(address[2:])
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(lower))
+	digest := hash.Sum(nil)
+
+	checksummed := []byte(lower)
+	for i, c := range checksummed {
+		nibble := digest[i/2] >> 4
+		if i%2 == 1 {
+			nibble = digest[i/2] & 0x0f
+		}
+
+		if c >= 'a' && c <= 'f' && nibble >= 8 {
+			checksummed[i] = c - 'a' + 'A'
+		}
+	}
+
+	return "0x" + string(checksummed), nil
+}
@@ -0,0 +1,100 @@
+package chain
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeHash(t *testing.T) {
+	hash := "0x" + strings.Repeat("Ab", 32)
+
+	t.Run("Test valid hash", func(t *testing.T) {
+		normalized, err := NormalizeHash(" " + hash + " ")
+		require.NoError(t, err)
+		require.Equal(t, strings.ToLower(hash), normalized)
+	})
+
+	t.Run("Test invalid hash", func(t *testing.T) {
+		for _, invalid := range []string{"", "0x1234", strings.Repeat("ab", 32), "0x" + strings.Repeat("zz", 32)} {
+			_, err := NormalizeHash(invalid)
+			require.ErrorIs(t, err, ErrInvalidHash)
+		}
+	})
+}
+
+func TestSameAddress(t *testing.T) {
+	require.True(t, SameAddress("0xAbCd", "0xabcd"))
+	require.False(t, SameAddress("0xabcd", "0xabce"))
+	require.False(t, SameAddress("", ""))
+}
+
+func TestMemoryClient(t *testing.T) {
+	client := NewMemoryClient()
+	hash := "0x" + strings.Repeat("ab", 32)
+
+	_, err := client.TransactionByHash(context.Background(), hash)
+	require.ErrorIs(t, err, ErrTransactionNotFound)
+
+	client.AddTransaction(Transaction{
+		Hash:   strings.ToUpper(hash),
+		Value:  big.NewInt(1),
+		Status: TransactionSucceeded,
+	})
+
+	tx, err := client.TransactionByHash(context.Background(), hash)
+	require.NoError(t, err)
+	require.Equal(t, TransactionSucceeded, tx.Status)
+	require.Equal(t, int64(1), tx.Value.Int64())
+}
+
+func TestMemoryClientBlocks(t *testing.T) {
+	client := NewMemoryClient()
+	contract := "0x" + strings.Repeat("11", 20)
+
+	first := client.AddBlock("a", Log{Address: contract, Topics: []string{EventContributed}})
+	second := client.AddBlock("a", Log{Address: contract, Topics: []string{EventRefunded}})
+	require.Equal(t, uint64(1), first)
+	require.Equal(t, uint64(2), second)
+
+	head, err := client.BlockNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, second, head)
+
+	logs, err := client.FilterLogs(context.Background(), LogQuery{FromBlock: 0, ToBlock: head, Topics: []string{EventContributed}})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Equal(t, first, logs[0].BlockNumber)
+
+	hash, err := client.BlockHash(context.Background(), second)
+	require.NoError(t, err)
+
+	client.Reorg(second)
+	_, err = client.BlockHash(context.Background(), second)
+	require.ErrorIs(t, err, ErrBlockNotFound)
+
+	client.AddBlock("b")
+	forkHash, err := client.BlockHash(context.Background(), second)
+	require.NoError(t, err)
+	require.NotEqual(t, hash, forkHash)
+}
+
+func TestChecksumAddress(t *testing.T) {
+	// Test vectors from EIP-55.
+	for _, expected := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	} {
+		address, err := ChecksumAddress(strings.ToLower(expected))
+		require.NoError(t, err)
+		require.Equal(t, expected, address)
+	}
+
+	_, err := ChecksumAddress("0x1234")
+	require.ErrorIs(t, err, ErrInvalidAddress)
+}
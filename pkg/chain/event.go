@@ -0,0 +1,53 @@
+package chain
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+var ErrMalformedLog = errors.New("malformed log")
+
+// Event signatures of the project contracts. The factory emits ProjectCreated and every
+// project contract emits the others.
+var (
+	EventProjectCreated = EventTopic("ProjectCreated(address,address,uint256,uint256)")
+	EventContributed    = EventTopic("Contributed(address,uint256)")
+	EventRefunded       = EventTopic("Refunded(address,uint256)")
+	EventFundsWithdrawn = EventTopic("FundsWithdrawn(address,uint256)")
+)
+
+// EventTopic returns the keccak-256 hash of an event signature as used for the first log topic.
+func EventTopic(signature string) string {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(signature))
+	return "0x" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// TopicAddress decodes an indexed address parameter.
+func (l *Log) TopicAddress(index int) (string, error) {
+	if index >= len(l.Topics) || len(l.Topics[index]) != 66 {
+		return "", ErrMalformedLog
+	}
+
+	return "0x" + strings.ToLower(l.Topics[index][26:]), nil
+}
+
+// DataUint decodes the non-indexed uint256 parameter at the given position.
+func (l *Log) DataUint(index int) (*big.Int, error) {
+	data := strings.TrimPrefix(l.Data, "0x")
+	start, end := index*64, (index+1)*64
+	if end > len(data) {
+		return nil, ErrMalformedLog
+	}
+
+	value, ok := new(big.Int).SetString(data[start:end], 16)
+	if !ok {
+		return nil, ErrMalformedLog
+	}
+
+	return value, nil
+}
@@ -0,0 +1,38 @@
+package chain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventTopic(t *testing.T) {
+	// keccak256("Transfer(address,address,uint256)") from the ERC-20 standard.
+	require.Equal(t,
+		"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+		EventTopic("Transfer(address,address,uint256)"),
+	)
+}
+
+func TestLogDecoding(t *testing.T) {
+	backer := "0x" + strings.Repeat("ab", 20)
+	log := Log{
+		Topics: []string{EventContributed, "0x" + strings.Repeat("0", 24) + strings.ToUpper(backer[2:])},
+		Data:   "0x" + strings.Repeat("0", 63) + "f",
+	}
+
+	address, err := log.TopicAddress(1)
+	require.NoError(t, err)
+	require.Equal(t, backer, address)
+
+	amount, err := log.DataUint(0)
+	require.NoError(t, err)
+	require.Equal(t, int64(15), amount.Int64())
+
+	_, err = log.TopicAddress(2)
+	require.ErrorIs(t, err, ErrMalformedLog)
+
+	_, err = log.DataUint(1)
+	require.ErrorIs(t, err, ErrMalformedLog)
+}
@@ -0,0 +1,138 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MemoryClient is an in-memory Client used for tests and local development without a node.
+// It simulates a chain that starts with a genesis block and grows with AddBlock.
+type MemoryClient struct {
+	mu           sync.RWMutex
+	transactions map[string]Transaction
+	blocks       []memoryBlock
+}
+
+type memoryBlock struct {
+	hash string
+	logs []Log
+}
+
+func NewMemoryClient() *MemoryClient {
+	return &MemoryClient{
+		transactions: make(map[string]Transaction),
+		blocks:       []memoryBlock{{hash: memoryBlockHash(0, "genesis")}},
+	}
+}
+
+// AddTransaction stores or replaces a transaction so later lookups by hash return it.
+func (client *MemoryClient) AddTransaction(tx Transaction) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	client.transactions[strings.ToLower(tx.Hash)] = tx
+}
+
+// AddBlock mines a block holding the given logs and returns its number. The seed makes the
+// block hash differ from a block mined at the same height before a Reorg.
+func (client *MemoryClient) AddBlock(seed string, logs ...Log) uint64 {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	number := uint64(len(client.blocks))
+	hash := memoryBlockHash(number, seed)
+	for i := range logs {
+		logs[i].BlockNumber = number
+		logs[i].BlockHash = hash
+		logs[i].LogIndex = uint64(i)
+	}
+
+	client.blocks = append(client.blocks, memoryBlock{hash: hash, logs: logs})
+	return number
+}
+
+// Reorg drops every block from the given number onwards, as if they were replaced by a fork.
+func (client *MemoryClient) Reorg(number uint64) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	if number == 0 || number >= uint64(len(client.blocks)) {
+		return
+	}
+
+	client.blocks = client.blocks[:number]
+}
+
+func (client *MemoryClient) TransactionByHash(_ context.Context, hash string) (*Transaction, error) {
+	client.mu.RLock()
+	defer client.mu.RUnlock()
+
+	tx, ok := client.transactions[strings.ToLower(hash)]
+	if !ok {
+		return nil, ErrTransactionNotFound
+	}
+
+	return &tx, nil
+}
+
+func (client *MemoryClient) BlockNumber(_ context.Context) (uint64, error) {
+	client.mu.RLock()
+	defer client.mu.RUnlock()
+
+	return uint64(len(client.blocks) - 1), nil
+}
+
+func (client *MemoryClient) BlockHash(_ context.Context, number uint64) (string, error) {
+	client.mu.RLock()
+	defer client.mu.RUnlock()
+
+	if number >= uint64(len(client.blocks)) {
+		return "", ErrBlockNotFound
+	}
+
+	return client.blocks[number].hash, nil
+}
+
+func (client *MemoryClient) FilterLogs(_ context.Context, query LogQuery) ([]Log, error) {
+	client.mu.RLock()
+	defer client.mu.RUnlock()
+
+	var logs []Log
+	for number := query.FromBlock; number <= query.ToBlock && number < uint64(len(client.blocks)); number++ {
+		for _, log := range client.blocks[number].logs {
+			if matchLog(log, query) {
+				logs = append(logs, log)
+			}
+		}
+	}
+
+	return logs, nil
+}
+
+func matchLog(log Log, query LogQuery) bool {
+	if len(query.Addresses) > 0 && !containsFold(query.Addresses, log.Address) {
+		return false
+	}
+
+	if len(query.Topics) > 0 && (len(log.Topics) == 0 || !containsFold(query.Topics, log.Topics[0])) {
+		return false
+	}
+
+	return true
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func memoryBlockHash(number uint64, seed string) string {
+	return EventTopic(fmt.Sprintf("%d:%s", number, seed))
+}
@@ -0,0 +1,254 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// RPCClient talks to an EVM node through its JSON-RPC HTTP endpoint.
+type RPCClient struct {
+	url        string
+	httpClient *http.Client
+	nextID     atomic.Int64
+}
+
+func NewRPCClient(url string) *RPCClient {
+	return &RPCClient{
+		url:        url,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int64         `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("json-rpc error %d: %s", e.Code, e.Message)
+}
+
+type rpcTransaction struct {
+	Hash        string  `json:"hash"`
+	From        string  `json:"from"`
+	To          string  `json:"to"`
+	Value       string  `json:"value"`
+	BlockNumber *string `json:"blockNumber"`
+}
+
+type rpcReceipt struct {
+	Status string `json:"status"`
+}
+
+type rpcBlock struct {
+	Hash string `json:"hash"`
+}
+
+type rpcLog struct {
+	Address         string   `json:"address"`
+	Topics          []string `json:"topics"`
+	Data            string   `json:"data"`
+	BlockNumber     string   `json:"blockNumber"`
+	BlockHash       string   `json:"blockHash"`
+	TransactionHash string   `json:"transactionHash"`
+	LogIndex        string   `json:"logIndex"`
+	Removed         bool     `json:"removed"`
+}
+
+type rpcLogFilter struct {
+	FromBlock string     `json:"fromBlock"`
+	ToBlock   string     `json:"toBlock"`
+	Address   []string   `json:"address,omitempty"`
+	Topics    [][]string `json:"topics,omitempty"`
+}
+
+func (client *RPCClient) TransactionByHash(ctx context.Context, hash string) (*Transaction, error) {
+	var rawTx *rpcTransaction
+	if err := client.call(ctx, "eth_getTransactionByHash", []interface{}{hash}, &rawTx); err != nil {
+		return nil, err
+	}
+
+	if rawTx == nil {
+		return nil, ErrTransactionNotFound
+	}
+
+	value, err := parseBig(rawTx.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction value: %w", err)
+	}
+
+	tx := &Transaction{
+		Hash:   rawTx.Hash,
+		From:   rawTx.From,
+		To:     rawTx.To,
+		Value:  value,
+		Status: TransactionPending,
+	}
+
+	if rawTx.BlockNumber == nil {
+		return tx, nil
+	}
+
+	tx.BlockNumber, err = parseUint(*rawTx.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block number: %w", err)
+	}
+
+	var receipt *rpcReceipt
+	if err := client.call(ctx, "eth_getTransactionReceipt", []interface{}{hash}, &receipt); err != nil {
+		return nil, err
+	}
+
+	if receipt != nil {
+		if receipt.Status == "0x1" {
+			tx.Status = TransactionSucceeded
+		} else {
+			tx.Status = TransactionFailed
+		}
+	}
+
+	return tx, nil
+}
+
+func (client *RPCClient) BlockNumber(ctx context.Context) (uint64, error) {
+	var number string
+	if err := client.call(ctx, "eth_blockNumber", []interface{}{}, &number); err != nil {
+		return 0, err
+	}
+
+	return parseUint(number)
+}
+
+func (client *RPCClient) BlockHash(ctx context.Context, number uint64) (string, error) {
+	var block *rpcBlock
+	if err := client.call(ctx, "eth_getBlockByNumber", []interface{}{toHex(number), false}, &block); err != nil {
+		return "", err
+	}
+
+	if block == nil {
+		return "", ErrBlockNotFound
+	}
+
+	return strings.ToLower(block.Hash), nil
+}
+
+func (client *RPCClient) FilterLogs(ctx context.Context, query LogQuery) ([]Log, error) {
+	filter := rpcLogFilter{
+		FromBlock: toHex(query.FromBlock),
+		ToBlock:   toHex(query.ToBlock),
+		Address:   query.Addresses,
+	}
+	if len(query.Topics) > 0 {
+		filter.Topics = [][]string{query.Topics}
+	}
+
+	var rawLogs []rpcLog
+	if err := client.call(ctx, "eth_getLogs", []interface{}{filter}, &rawLogs); err != nil {
+		return nil, err
+	}
+
+	logs := make([]Log, 0, len(rawLogs))
+	for _, rawLog := range rawLogs {
+		if rawLog.Removed {
+			continue
+		}
+
+		blockNumber, err := parseUint(rawLog.BlockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log block number: %w", err)
+		}
+
+		logIndex, err := parseUint(rawLog.LogIndex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log index: %w", err)
+		}
+
+		logs = append(logs, Log{
+			Address:     strings.ToLower(rawLog.Address),
+			Topics:      rawLog.Topics,
+			Data:        rawLog.Data,
+			BlockNumber: blockNumber,
+			BlockHash:   strings.ToLower(rawLog.BlockHash),
+			TxHash:      strings.ToLower(rawLog.TransactionHash),
+			LogIndex:    logIndex,
+		})
+	}
+
+	return logs, nil
+}
+
+func (client *RPCClient) call(ctx context.Context, method string, params []interface{}, result interface{}) error {
+	body, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		ID:      client.nextID.Add(1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := client.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to call %s: unexpected status %d", method, res.StatusCode)
+	}
+
+	var response rpcResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+
+	if response.Error != nil {
+		return response.Error
+	}
+
+	return json.Unmarshal(response.Result, result)
+}
+
+func parseBig(hex string) (*big.Int, error) {
+	value, ok := new(big.Int).SetString(strings.TrimPrefix(hex, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("malformed hex quantity %q", hex)
+	}
+
+	return value, nil
+}
+
+func parseUint(hex string) (uint64, error) {
+	return strconv.ParseUint(strings.TrimPrefix(hex, "0x"), 16, 64)
+}
+
+func toHex(value uint64) string {
+	return "0x" + strconv.FormatUint(value, 16)
+}
@@ -0,0 +1,131 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRPCServer(t *testing.T, results map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  results[req.Method],
+		})
+	}))
+}
+
+func TestRPCClientTransactionByHash(t *testing.T) {
+	hash := "0x" + strings.Repeat("ab", 32)
+	blockNumber := "0x10"
+
+	t.Run("Test mined transaction", func(t *testing.T) {
+		server := newTestRPCServer(t, map[string]interface{}{
+			"eth_getTransactionByHash": rpcTransaction{
+				Hash:        hash,
+				From:        "0x1111111111111111111111111111111111111111",
+				To:          "0x2222222222222222222222222222222222222222",
+				Value:       "0xde0b6b3a7640000",
+				BlockNumber: &blockNumber,
+			},
+			"eth_getTransactionReceipt": rpcReceipt{Status: "0x1"},
+		})
+		defer server.Close()
+
+		tx, err := NewRPCClient(server.URL).TransactionByHash(context.Background(), hash)
+		require.NoError(t, err)
+		require.Equal(t, TransactionSucceeded, tx.Status)
+		require.Equal(t, uint64(16), tx.BlockNumber)
+		require.Equal(t, "1000000000000000000", tx.Value.String())
+	})
+
+	t.Run("Test reverted transaction", func(t *testing.T) {
+		server := newTestRPCServer(t, map[string]interface{}{
+			"eth_getTransactionByHash": rpcTransaction{
+				Hash:        hash,
+				Value:       "0x0",
+				BlockNumber: &blockNumber,
+			},
+			"eth_getTransactionReceipt": rpcReceipt{Status: "0x0"},
+		})
+		defer server.Close()
+
+		tx, err := NewRPCClient(server.URL).TransactionByHash(context.Background(), hash)
+		require.NoError(t, err)
+		require.Equal(t, TransactionFailed, tx.Status)
+	})
+
+	t.Run("Test pending transaction", func(t *testing.T) {
+		server := newTestRPCServer(t, map[string]interface{}{
+			"eth_getTransactionByHash": rpcTransaction{
+				Hash:  hash,
+				Value: "0x1",
+			},
+		})
+		defer server.Close()
+
+		tx, err := NewRPCClient(server.URL).TransactionByHash(context.Background(), hash)
+		require.NoError(t, err)
+		require.Equal(t, TransactionPending, tx.Status)
+	})
+
+	t.Run("Test unknown transaction", func(t *testing.T) {
+		server := newTestRPCServer(t, map[string]interface{}{})
+		defer server.Close()
+
+		_, err := NewRPCClient(server.URL).TransactionByHash(context.Background(), hash)
+		require.ErrorIs(t, err, ErrTransactionNotFound)
+	})
+}
+
+func TestRPCClientBlocksAndLogs(t *testing.T) {
+	blockHash := "0x" + strings.Repeat("CD", 32)
+	server := newTestRPCServer(t, map[string]interface{}{
+		"eth_blockNumber":      "0x1f",
+		"eth_getBlockByNumber": rpcBlock{Hash: blockHash},
+		"eth_getLogs": []rpcLog{
+			{
+				Address:         "0x" + strings.Repeat("AA", 20),
+				Topics:          []string{EventContributed},
+				Data:            "0x01",
+				BlockNumber:     "0x1e",
+				BlockHash:       blockHash,
+				TransactionHash: "0x" + strings.Repeat("ab", 32),
+				LogIndex:        "0x2",
+			},
+			{
+				BlockNumber: "0x1e",
+				LogIndex:    "0x3",
+				Removed:     true,
+			},
+		},
+	})
+	defer server.Close()
+
+	client := NewRPCClient(server.URL)
+
+	head, err := client.BlockNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(31), head)
+
+	hash, err := client.BlockHash(context.Background(), head)
+	require.NoError(t, err)
+	require.Equal(t, strings.ToLower(blockHash), hash)
+
+	logs, err := client.FilterLogs(context.Background(), LogQuery{FromBlock: 30, ToBlock: 31, Topics: []string{EventContributed}})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Equal(t, uint64(30), logs[0].BlockNumber)
+	require.Equal(t, uint64(2), logs[0].LogIndex)
+	require.Equal(t, "0x"+strings.Repeat("aa", 20), logs[0].Address)
+}
@@ -0,0 +1,52 @@
+package chain
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+)
+
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// PersonalMessageHash hashes a message the way personal_sign (EIP-191) does before signing it.
+func PersonalMessageHash(message string) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(message), message)))
+	return hash.Sum(nil)
+}
+
+// RecoverPersonalSigner returns the lower-cased address of the account that signed the message
+// with personal_sign. The signature is the 65-byte hex value returned by the wallet.
+func RecoverPersonalSigner(message string, signature string) (string, error) {
+	sig, err := hex.DecodeString(strings.TrimPrefix(signature, "0x"))
+	if err != nil || len(sig) != 65 {
+		return "", ErrInvalidSignature
+	}
+
+	// Wallets return R || S || V with V either 0/1 or 27/28, while the compact format expected
+	// by the library is V || R || S with V in 27/28 for uncompressed keys.
+	v := sig[64]
+	if v < 27 {
+		v += 27
+	}
+	if v != 27 && v != 28 {
+		return "", ErrInvalidSignature
+	}
+
+	compact := make([]byte, 65)
+	compact[0] = v
+	copy(compact[1:], sig[:64])
+
+	publicKey, _, err := ecdsa.RecoverCompact(compact, PersonalMessageHash(message))
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(publicKey.SerializeUncompressed()[1:])
+	return "0x" + hex.EncodeToString(hash.Sum(nil)[12:]), nil
+}
@@ -0,0 +1,33 @@
+package chain
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverPersonalSigner(t *testing.T) {
+	// Private key 1 controls the well-known address below.
+	var keyBytes [32]byte
+	keyBytes[31] = 1
+	key := secp256k1.PrivKeyFromBytes(keyBytes[:])
+	message := "hello fund-o"
+
+	compact := ecdsa.SignCompact(key, PersonalMessageHash(message), false)
+	signature := "0x" + hex.EncodeToString(append(compact[1:], compact[0]))
+
+	signer, err := RecoverPersonalSigner(message, signature)
+	require.NoError(t, err)
+	require.Equal(t, "0x7e5f4552091a69125d5dfcb7b8c2659029395bdf", signer)
+
+	other, err := RecoverPersonalSigner("another message", signature)
+	if err == nil {
+		require.NotEqual(t, signer, other)
+	}
+
+	_, err = RecoverPersonalSigner(message, "0x1234")
+	require.ErrorIs(t, err, ErrInvalidSignature)
+}
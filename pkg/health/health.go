@@ -0,0 +1,139 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Check probes one dependency. Probe should honour the context, but the
+// checker stops waiting once Timeout has passed either way.
+type Check struct {
+	Name    string
+	Timeout time.Duration
+	Probe   func(ctx context.Context) error
+}
+
+type CheckResult struct {
+	Status   string `json:"status"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status   string                 `json:"status"`
+	Draining bool                   `json:"draining"`
+	Checks   map[string]CheckResult `json:"checks"`
+}
+
+func (r *Report) OK() bool {
+	return r.Status == StatusOK
+}
+
+// Checker decides whether the server should receive traffic. It reports
+// ready while every check passes, and never again once Drain is called.
+type Checker interface {
+	Register(check Check)
+	Ready(ctx context.Context) *Report
+	Drain()
+	Draining() bool
+}
+
+type checker struct {
+	mu       sync.RWMutex
+	checks   []Check
+	draining atomic.Bool
+}
+
+func NewChecker() Checker {
+	return &checker{}
+}
+
+func (c *checker) Register(check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, check)
+}
+
+// Ready runs every check concurrently, each with its own timeout. A draining
+// checker fails without probing anything.
+func (c *checker) Ready(ctx context.Context) *Report {
+	report := &Report{
+		Status: StatusOK,
+		Checks: map[string]CheckResult{},
+	}
+
+	if c.Draining() {
+		report.Status = StatusFail
+		report.Draining = true
+		return report
+	}
+
+	c.mu.RLock()
+	checks := make([]Check, len(c.checks))
+	copy(checks, c.checks)
+	c.mu.RUnlock()
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = run(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	for i, check := range checks {
+		report.Checks[check.Name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+
+	return report
+}
+
+func (c *checker) Drain() {
+	c.draining.Store(true)
+}
+
+func (c *checker) Draining() bool {
+	return c.draining.Load()
+}
+
+func run(ctx context.Context, check Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check.Probe(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := CheckResult{
+		Status:   StatusOK,
+		Duration: time.Since(start).Round(time.Millisecond).String(),
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+
+	return result
+}
@@ -0,0 +1,69 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker(t *testing.T) {
+	t.Run("Test all checks pass", func(t *testing.T) {
+		checker := NewChecker()
+		checker.Register(Check{Name: "a", Timeout: time.Second, Probe: func(ctx context.Context) error { return nil }})
+		checker.Register(Check{Name: "b", Timeout: time.Second, Probe: func(ctx context.Context) error { return nil }})
+
+		report := checker.Ready(context.Background())
+		require.True(t, report.OK())
+		require.False(t, report.Draining)
+		require.Len(t, report.Checks, 2)
+		require.Equal(t, StatusOK, report.Checks["a"].Status)
+	})
+
+	t.Run("Test failing check", func(t *testing.T) {
+		checker := NewChecker()
+		checker.Register(Check{Name: "a", Timeout: time.Second, Probe: func(ctx context.Context) error { return nil }})
+		checker.Register(Check{Name: "b", Timeout: time.Second, Probe: func(ctx context.Context) error { return errors.New("down") }})
+
+		report := checker.Ready(context.Background())
+		require.False(t, report.OK())
+		require.Equal(t, StatusOK, report.Checks["a"].Status)
+		require.Equal(t, StatusFail, report.Checks["b"].Status)
+		require.Equal(t, "down", report.Checks["b"].Error)
+	})
+
+	t.Run("Test check ignoring its timeout", func(t *testing.T) {
+		block := make(chan struct{})
+		defer close(block)
+
+		checker := NewChecker()
+		checker.Register(Check{Name: "slow", Timeout: 10 * time.Millisecond, Probe: func(ctx context.Context) error {
+			<-block
+			return nil
+		}})
+
+		start := time.Now()
+		report := checker.Ready(context.Background())
+		require.Less(t, time.Since(start), time.Second)
+		require.False(t, report.OK())
+		require.Equal(t, context.DeadlineExceeded.Error(), report.Checks["slow"].Error)
+	})
+
+	t.Run("Test draining", func(t *testing.T) {
+		probed := false
+		checker := NewChecker()
+		checker.Register(Check{Name: "a", Timeout: time.Second, Probe: func(ctx context.Context) error {
+			probed = true
+			return nil
+		}})
+
+		checker.Drain()
+		report := checker.Ready(context.Background())
+		require.True(t, checker.Draining())
+		require.False(t, report.OK())
+		require.True(t, report.Draining)
+		require.False(t, probed)
+	})
+}
@@ -18,5 +18,5 @@ func InitLogger() {
 		TimeFormat: time.RFC3339,
 	}
 
-	log.Logger = log.Output(consoleWriter)
+	log.Logger = log.Output(consoleWriter).Hook(TracingHook{})
 }
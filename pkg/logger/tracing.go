@@ -0,0 +1,20 @@
+package logger
+
+import (
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingHook adds the IDs of the span found in the event's context, so a log line can be
+// matched with its trace. Events need a context, given with Ctx, to be annotated.
+type TracingHook struct{}
+
+func (TracingHook) Run(e *zerolog.Event, level zerolog.Level, message string) {
+	spanContext := trace.SpanContextFromContext(e.GetCtx())
+	if !spanContext.IsValid() {
+		return
+	}
+
+	e.Str("trace_id", spanContext.TraceID().String()).
+		Str("span_id", spanContext.SpanID().String())
+}
@@ -1,5 +1,11 @@
 package mail
 
+import (
+	"html"
+	"strings"
+	"time"
+)
+
 func NewVerifyEmailTemplate(verifyUrl string) string {
 	content := `
 	<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Transitional//EN" "http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd"><html dir="ltr" xmlns="http://www.w3.org/1999/xhtml" xmlns:o="urn:schemas-microsoft-com:office:office" lang="en"><head><meta charset="UTF-8"><meta content="width=device-width, initial-scale=1" name="viewport"><meta name="x-apple-disable-message-reformatting"><meta http-equiv="X-UA-Compatible" content="IE=edge"><meta content="telephone=no" name="format-detection"><title>New Template</title> <!--[if (mso 16)]><style type="text/css">     a {text-decoration: none;}     </style><![endif]--> <!--[if gte mso 9]><style>sup { font-size: 100% !important; }</style><![endif]--> <!--[if gte mso 9]><xml> <o:OfficeDocumentSettings> <o:AllowPNG></o:AllowPNG> <o:PixelsPerInch>96</o:PixelsPerInch> </o:OfficeDocumentSettings> </xml>
@@ -23,3 +29,108 @@ func NewVerifyEmailTemplate(verifyUrl string) string {
 `
 	return content
 }
+
+// NewProjectUpdateTemplate tells a backer that a project they support posted an update. The
+// titles are escaped since they are written by project creators.
+func NewProjectUpdateTemplate(projectTitle, updateTitle string) string {
+	content := `
+	<!DOCTYPE html><html dir="ltr" lang="en"><head><meta charset="UTF-8"><meta content="width=device-width, initial-scale=1" name="viewport"><title>Project Update</title></head>
+	<body style="width:100%;font-family:'trebuchet ms', 'lucida grande', 'lucida sans unicode', 'lucida sans', tahoma, sans-serif;padding:0;Margin:0;background-color:#F9F7F7">
+	<table width="100%" cellspacing="0" cellpadding="0" role="none" style="border-collapse:collapse;border-spacing:0px;background-color:#F9F7F7"><tr><td align="center" style="padding:20px">
+	<table bgcolor="#ffffff" cellpadding="0" cellspacing="0" role="presentation" style="border-collapse:collapse;border-spacing:0px;background-color:#FFFFFF;width:600px"><tr>
+	<td align="left" style="padding:20px"><img src="https://fejjswz.stripocdn.email/content/guids/CABINET_e2c5475cd85e4b8bc41c311189144f85195a784a1c00e3e43ef4432a56eb6a07/images/fundo.png" alt style="display:block;border:0;outline:none;text-decoration:none" width="150"></td></tr><tr>
+	<td align="left" style="padding:0 20px"><p style="Margin:0;line-height:18px;color:#333333;font-size:12px"><strong>NEW UPDATE FROM ` + html.EscapeString(strings.ToUpper(projectTitle)) + `</strong></p></td></tr><tr>
+	<td align="left" style="padding:5px 20px"><h1 style="Margin:0;line-height:38px;font-size:32px;font-weight:bold;color:#333333">` + html.EscapeString(updateTitle) + `</h1></td></tr><tr>
+	<td align="left" style="padding:0 20px 20px"><p style="Margin:0;line-height:21px;color:#333333;font-size:14px">The creator of a project you back posted a new update. Sign in to FundO to read it.</p></td></tr><tr>
+	<td align="left" style="padding:20px;border-top:1px solid #cccccc"><p style="Margin:0;line-height:18px;color:#a9a9a9;font-size:12px">Kasetsart University Bangkok, Thailand</p><p style="Margin:0;line-height:18px;color:#a9a9a9;font-size:12px">© 2024 FundO, Inc.</p></td></tr></table>
+	</td></tr></table></body></html>
+`
+	return content
+}
+
+// NewPasswordResetTemplate sends the link to choose a new password.
+func NewPasswordResetTemplate(resetUrl string) string {
+	content := `
+	<!DOCTYPE html><html dir="ltr" lang="en"><head><meta charset="UTF-8"><meta content="width=device-width, initial-scale=1" name="viewport"><title>Reset Password</title></head>
+	<body style="width:100%;font-family:'trebuchet ms', 'lucida grande', 'lucida sans unicode', 'lucida sans', tahoma, sans-serif;padding:0;Margin:0;background-color:#F9F7F7">
+	<table width="100%" cellspacing="0" cellpadding="0" role="none" style="border-collapse:collapse;border-spacing:0px;background-color:#F9F7F7"><tr><td align="center" style="padding:20px">
+	<table bgcolor="#ffffff" cellpadding="0" cellspacing="0" role="presentation" style="border-collapse:collapse;border-spacing:0px;background-color:#FFFFFF;width:600px"><tr>
+	<td align="left" style="padding:20px"><img src="https://fejjswz.stripocdn.email/content/guids/CABINET_e2c5475cd85e4b8bc41c311189144f85195a784a1c00e3e43ef4432a56eb6a07/images/fundo.png" alt style="display:block;border:0;outline:none;text-decoration:none" width="150"></td></tr><tr>
+	<td align="left" style="padding:0 20px"><p style="Margin:0;line-height:18px;color:#333333;font-size:12px"><strong>FORGOT YOUR PASSWORD?</strong></p></td></tr><tr>
+	<td align="left" style="padding:5px 20px"><h1 style="Margin:0;line-height:38px;font-size:32px;font-weight:bold;color:#333333">Reset your password</h1></td></tr><tr>
+	<td align="left" style="padding:0 20px"><p style="Margin:0;line-height:21px;color:#333333;font-size:14px">Use the button below to choose a new password. The link expires in 30 minutes and can only be used once. If you did not ask for it, you can ignore this email.</p></td></tr><tr>
+	<td align="center" style="padding:40px 20px"><a href="` + html.EscapeString(resetUrl) + `" target="_blank" style="text-decoration:none;color:#FFFFFF;font-size:14px;padding:15px 60px;display:inline-block;background:#5340ff;border-radius:28px;font-family:verdana, geneva, sans-serif;font-weight:bold;line-height:17px;text-align:center">Reset Password</a></td></tr><tr>
+	<td align="left" style="padding:20px;border-top:1px solid #cccccc"><p style="Margin:0;line-height:18px;color:#a9a9a9;font-size:12px">Kasetsart University Bangkok, Thailand</p><p style="Margin:0;line-height:18px;color:#a9a9a9;font-size:12px">© 2024 FundO, Inc.</p></td></tr></table>
+	</td></tr></table></body></html>
+`
+	return content
+}
+
+func NewAccountLockedTemplate(lockedUntil time.Time) string {
+	content := `
+	<!DOCTYPE html><html dir="ltr" lang="en"><head><meta charset="UTF-8"><meta content="width=device-width, initial-scale=1" name="viewport"><title>Account Locked</title></head>
+	<body style="width:100%;font-family:'trebuchet ms', 'lucida grande', 'lucida sans unicode', 'lucida sans', tahoma, sans-serif;padding:0;Margin:0;background-color:#F9F7F7">
+	<table width="100%" cellspacing="0" cellpadding="0" role="none" style="border-collapse:collapse;border-spacing:0px;background-color:#F9F7F7"><tr><td align="center" style="padding:20px">
+	<table bgcolor="#ffffff" cellpadding="0" cellspacing="0" role="presentation" style="border-collapse:collapse;border-spacing:0px;background-color:#FFFFFF;width:600px"><tr>
+	<td align="left" style="padding:20px"><img src="https://fejjswz.stripocdn.email/content/guids/CABINET_e2c5475cd85e4b8bc41c311189144f85195a784a1c00e3e43ef4432a56eb6a07/images/fundo.png" alt style="display:block;border:0;outline:none;text-decoration:none" width="150"></td></tr><tr>
+	<td align="left" style="padding:0 20px"><p style="Margin:0;line-height:18px;color:#333333;font-size:12px"><strong>SECURITY ALERT</strong></p></td></tr><tr>
+	<td align="left" style="padding:5px 20px"><h1 style="Margin:0;line-height:38px;font-size:32px;font-weight:bold;color:#333333">Your account has been locked</h1></td></tr><tr>
+	<td align="left" style="padding:0 20px 40px"><p style="Margin:0;line-height:21px;color:#333333;font-size:14px">We noticed several failed attempts to sign in to your account, so signing in with your password is blocked until ` + html.EscapeString(lockedUntil.UTC().Format("January 2, 2006 15:04 MST")) + `. If this was not you, we recommend resetting your password once the lock has passed.</p></td></tr><tr>
+	<td align="left" style="padding:20px;border-top:1px solid #cccccc"><p style="Margin:0;line-height:18px;color:#a9a9a9;font-size:12px">Kasetsart University Bangkok, Thailand</p><p style="Margin:0;line-height:18px;color:#a9a9a9;font-size:12px">© 2024 FundO, Inc.</p></td></tr></table>
+	</td></tr></table></body></html>
+`
+	return content
+}
+
+// NewEmailChangeTemplate sends the link that confirms a new email for the account.
+func NewEmailChangeTemplate(confirmUrl string) string {
+	content := `
+	<!DOCTYPE html><html dir="ltr" lang="en"><head><meta charset="UTF-8"><meta content="width=device-width, initial-scale=1" name="viewport"><title>Confirm Email Change</title></head>
+	<body style="width:100%;font-family:'trebuchet ms', 'lucida grande', 'lucida sans unicode', 'lucida sans', tahoma, sans-serif;padding:0;Margin:0;background-color:#F9F7F7">
+	<table width="100%" cellspacing="0" cellpadding="0" role="none" style="border-collapse:collapse;border-spacing:0px;background-color:#F9F7F7"><tr><td align="center" style="padding:20px">
+	<table bgcolor="#ffffff" cellpadding="0" cellspacing="0" role="presentation" style="border-collapse:collapse;border-spacing:0px;background-color:#FFFFFF;width:600px"><tr>
+	<td align="left" style="padding:20px"><img src="https://fejjswz.stripocdn.email/content/guids/CABINET_e2c5475cd85e4b8bc41c311189144f85195a784a1c00e3e43ef4432a56eb6a07/images/fundo.png" alt style="display:block;border:0;outline:none;text-decoration:none" width="150"></td></tr><tr>
+	<td align="left" style="padding:0 20px"><p style="Margin:0;line-height:18px;color:#333333;font-size:12px"><strong>CHANGE OF EMAIL</strong></p></td></tr><tr>
+	<td align="left" style="padding:5px 20px"><h1 style="Margin:0;line-height:38px;font-size:32px;font-weight:bold;color:#333333">Confirm your new email</h1></td></tr><tr>
+	<td align="left" style="padding:0 20px"><p style="Margin:0;line-height:21px;color:#333333;font-size:14px">Use the button below to make this address the email of your FundO account. The link expires in 30 minutes and can only be used once. If you did not ask for it, you can ignore this email.</p></td></tr><tr>
+	<td align="center" style="padding:40px 20px"><a href="` + html.EscapeString(confirmUrl) + `" target="_blank" style="text-decoration:none;color:#FFFFFF;font-size:14px;padding:15px 60px;display:inline-block;background:#5340ff;border-radius:28px;font-family:verdana, geneva, sans-serif;font-weight:bold;line-height:17px;text-align:center">Confirm Email</a></td></tr><tr>
+	<td align="left" style="padding:20px;border-top:1px solid #cccccc"><p style="Margin:0;line-height:18px;color:#a9a9a9;font-size:12px">Kasetsart University Bangkok, Thailand</p><p style="Margin:0;line-height:18px;color:#a9a9a9;font-size:12px">© 2024 FundO, Inc.</p></td></tr></table>
+	</td></tr></table></body></html>
+`
+	return content
+}
+
+// NewEmailChangeNoticeTemplate tells the current address that the account is moving to another email.
+func NewEmailChangeNoticeTemplate(newEmail string) string {
+	content := `
+	<!DOCTYPE html><html dir="ltr" lang="en"><head><meta charset="UTF-8"><meta content="width=device-width, initial-scale=1" name="viewport"><title>Email Change Requested</title></head>
+	<body style="width:100%;font-family:'trebuchet ms', 'lucida grande', 'lucida sans unicode', 'lucida sans', tahoma, sans-serif;padding:0;Margin:0;background-color:#F9F7F7">
+	<table width="100%" cellspacing="0" cellpadding="0" role="none" style="border-collapse:collapse;border-spacing:0px;background-color:#F9F7F7"><tr><td align="center" style="padding:20px">
+	<table bgcolor="#ffffff" cellpadding="0" cellspacing="0" role="presentation" style="border-collapse:collapse;border-spacing:0px;background-color:#FFFFFF;width:600px"><tr>
+	<td align="left" style="padding:20px"><img src="https://fejjswz.stripocdn.email/content/guids/CABINET_e2c5475cd85e4b8bc41c311189144f85195a784a1c00e3e43ef4432a56eb6a07/images/fundo.png" alt style="display:block;border:0;outline:none;text-decoration:none" width="150"></td></tr><tr>
+	<td align="left" style="padding:0 20px"><p style="Margin:0;line-height:18px;color:#333333;font-size:12px"><strong>SECURITY ALERT</strong></p></td></tr><tr>
+	<td align="left" style="padding:5px 20px"><h1 style="Margin:0;line-height:38px;font-size:32px;font-weight:bold;color:#333333">Your email is being changed</h1></td></tr><tr>
+	<td align="left" style="padding:0 20px 40px"><p style="Margin:0;line-height:21px;color:#333333;font-size:14px">A request was made to change the email of your FundO account to ` + html.EscapeString(newEmail) + `. The change takes effect once the link sent to that address is followed. If this was not you, change your password right away.</p></td></tr><tr>
+	<td align="left" style="padding:20px;border-top:1px solid #cccccc"><p style="Margin:0;line-height:18px;color:#a9a9a9;font-size:12px">Kasetsart University Bangkok, Thailand</p><p style="Margin:0;line-height:18px;color:#a9a9a9;font-size:12px">© 2024 FundO, Inc.</p></td></tr></table>
+	</td></tr></table></body></html>
+`
+	return content
+}
+
+// NewDataExportTemplate sends the link to download the archive of the user's data.
+func NewDataExportTemplate(downloadUrl string) string {
+	content := `
+	<!DOCTYPE html><html dir="ltr" lang="en"><head><meta charset="UTF-8"><meta content="width=device-width, initial-scale=1" name="viewport"><title>Your Data Export</title></head>
+	<body style="width:100%;font-family:'trebuchet ms', 'lucida grande', 'lucida sans unicode', 'lucida sans', tahoma, sans-serif;padding:0;Margin:0;background-color:#F9F7F7">
+	<table width="100%" cellspacing="0" cellpadding="0" role="none" style="border-collapse:collapse;border-spacing:0px;background-color:#F9F7F7"><tr><td align="center" style="padding:20px">
+	<table bgcolor="#ffffff" cellpadding="0" cellspacing="0" role="presentation" style="border-collapse:collapse;border-spacing:0px;background-color:#FFFFFF;width:600px"><tr>
+	<td align="left" style="padding:20px"><img src="https://fejjswz.stripocdn.email/content/guids/CABINET_e2c5475cd85e4b8bc41c311189144f85195a784a1c00e3e43ef4432a56eb6a07/images/fundo.png" alt style="display:block;border:0;outline:none;text-decoration:none" width="150"></td></tr><tr>
+	<td align="left" style="padding:0 20px"><p style="Margin:0;line-height:18px;color:#333333;font-size:12px"><strong>YOUR DATA</strong></p></td></tr><tr>
+	<td align="left" style="padding:5px 20px"><h1 style="Margin:0;line-height:38px;font-size:32px;font-weight:bold;color:#333333">Your data export is ready</h1></td></tr><tr>
+	<td align="left" style="padding:0 20px"><p style="Margin:0;line-height:21px;color:#333333;font-size:14px">Use the button below to download a copy of the personal data we keep about your FundO account. The link expires in 24 hours. If you did not ask for it, change your password right away.</p></td></tr><tr>
+	<td align="center" style="padding:40px 20px"><a href="` + html.EscapeString(downloadUrl) + `" target="_blank" style="text-decoration:none;color:#FFFFFF;font-size:14px;padding:15px 60px;display:inline-block;background:#5340ff;border-radius:28px;font-family:verdana, geneva, sans-serif;font-weight:bold;line-height:17px;text-align:center">Download Data</a></td></tr><tr>
+	<td align="left" style="padding:20px;border-top:1px solid #cccccc"><p style="Margin:0;line-height:18px;color:#a9a9a9;font-size:12px">Kasetsart University Bangkok, Thailand</p><p style="Margin:0;line-height:18px;color:#a9a9a9;font-size:12px">© 2024 FundO, Inc.</p></td></tr></table>
+	</td></tr></table></body></html>
+`
+	return content
+}
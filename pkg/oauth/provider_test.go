@@ -0,0 +1,112 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProvider(t *testing.T, handler http.HandlerFunc) Provider {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return NewProvider(&Config{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		AuthURL:      server.URL + "/auth",
+		TokenURL:     server.URL + "/token",
+		UserInfoURL:  server.URL + "/userinfo",
+		RedirectURL:  "http://localhost/callback",
+		Scopes:       []string{"openid", "email"},
+	})
+}
+
+func TestCodeChallenge(t *testing.T) {
+	t.Run("Test RFC 7636 example", func(t *testing.T) {
+		require.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+	})
+
+	t.Run("Test NewCodeVerifier", func(t *testing.T) {
+		verifier, err := NewCodeVerifier()
+		require.NoError(t, err)
+		require.Len(t, verifier, 43)
+	})
+}
+
+func TestProviderAuthCodeURL(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	raw := provider.AuthCodeURL("some-state", "some-challenge")
+	parsed, err := url.Parse(raw)
+	require.NoError(t, err)
+
+	query := parsed.Query()
+	require.Equal(t, "/auth", parsed.Path)
+	require.Equal(t, "code", query.Get("response_type"))
+	require.Equal(t, "client-id", query.Get("client_id"))
+	require.Equal(t, "openid email", query.Get("scope"))
+	require.Equal(t, "some-state", query.Get("state"))
+	require.Equal(t, "some-challenge", query.Get("code_challenge"))
+	require.Equal(t, "S256", query.Get("code_challenge_method"))
+}
+
+func TestProviderExchange(t *testing.T) {
+	t.Run("Test exchange succeeds", func(t *testing.T) {
+		provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseForm())
+			require.Equal(t, "authorization_code", r.PostForm.Get("grant_type"))
+			require.Equal(t, "some-code", r.PostForm.Get("code"))
+			require.Equal(t, "some-verifier", r.PostForm.Get("code_verifier"))
+			require.Equal(t, "client-secret", r.PostForm.Get("client_secret"))
+
+			_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "access", "token_type": "Bearer"})
+		})
+
+		accessToken, err := provider.Exchange(context.Background(), "some-code", "some-verifier")
+		require.NoError(t, err)
+		require.Equal(t, "access", accessToken)
+	})
+
+	t.Run("Test exchange is rejected", func(t *testing.T) {
+		provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		})
+
+		_, err := provider.Exchange(context.Background(), "some-code", "wrong-verifier")
+		require.True(t, errors.Is(err, ErrExchangeFailed))
+	})
+}
+
+func TestProviderUserInfo(t *testing.T) {
+	t.Run("Test user info", func(t *testing.T) {
+		provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "Bearer access", r.Header.Get("Authorization"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"sub":            "1234",
+				"email":          "someone@gmail.com",
+				"email_verified": true,
+			})
+		})
+
+		info, err := provider.UserInfo(context.Background(), "access")
+		require.NoError(t, err)
+		require.Equal(t, "1234", info.Subject)
+		require.True(t, info.EmailVerified)
+	})
+
+	t.Run("Test unauthorized", func(t *testing.T) {
+		provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+
+		_, err := provider.UserInfo(context.Background(), "expired")
+		require.True(t, errors.Is(err, ErrUserInfoFailed))
+	})
+}
@@ -19,6 +19,17 @@ func HashPassword(password string) (string, error) {
 	return string(hashedPassword), nil
 }
 
+// HashCode hashes a generated secret, such as a recovery code, that does not have to meet the
+// password conditions. It is checked with CheckPassword.
+func HashCode(code string) (string, error) {
+	hashedCode, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash code: %w", err)
+	}
+
+	return string(hashedCode), nil
+}
+
 func CheckPassword(password string, hashedPassword string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 }
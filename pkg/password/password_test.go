@@ -35,6 +35,14 @@ func TestPassword(t *testing.T) {
 		})
 	})
 
+	t.Run("Test HashCode", func(t *testing.T) {
+		code := "a1b2c-d3e4f"
+		hashedCode, err := HashCode(code)
+		require.NoError(t, err)
+		require.NoError(t, CheckPassword(code, hashedCode))
+		require.Error(t, CheckPassword("a1b2c-d3e4e", hashedCode))
+	})
+
 	t.Run("Test CheckPassword", func(t *testing.T) {
 		t.Run("Test CheckPassword with valid password", func(t *testing.T) {
 			password := "Password1!"
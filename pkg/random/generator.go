@@ -0,0 +1,51 @@
+package random
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Generator produces the same sequence of values for the same seed. Use it for fixtures that
+// should look random but stay identical between runs; it is never suitable for secrets.
+type Generator struct {
+	rand *rand.Rand
+}
+
+func NewGenerator(seed int64) *Generator {
+	return &Generator{rand: rand.New(rand.NewSource(seed))}
+}
+
+func (g *Generator) Int(min, max int) int {
+	return min + g.rand.Intn(max-min)
+}
+
+func (g *Generator) Float32(min, max float32) float32 {
+	return min + g.rand.Float32()*(max-min)
+}
+
+func (g *Generator) String(length int) string {
+	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = charset[g.rand.Intn(len(charset))]
+	}
+	return string(b)
+}
+
+func (g *Generator) Hex(length int) string {
+	const charset = "0123456789abcdef"
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = charset[g.rand.Intn(len(charset))]
+	}
+	return string(b)
+}
+
+func (g *Generator) Email() string {
+	return fmt.Sprintf("%s@%s.com", g.String(10), g.String(5))
+}
+
+// Pick returns one of the given values.
+func (g *Generator) Pick(values []string) string {
+	return values[g.rand.Intn(len(values))]
+}
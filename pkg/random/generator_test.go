@@ -0,0 +1,35 @@
+package random
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator(t *testing.T) {
+	t.Run("Test same seed gives same values", func(t *testing.T) {
+		a := NewGenerator(42)
+		b := NewGenerator(42)
+		require.Equal(t, a.String(16), b.String(16))
+		require.Equal(t, a.Int(1, 100), b.Int(1, 100))
+		require.Equal(t, a.Email(), b.Email())
+		require.Equal(t, a.Hex(40), b.Hex(40))
+	})
+
+	t.Run("Test different seeds give different values", func(t *testing.T) {
+		a := NewGenerator(1)
+		b := NewGenerator(2)
+		require.NotEqual(t, a.String(16), b.String(16))
+	})
+
+	t.Run("Test bounds", func(t *testing.T) {
+		g := NewGenerator(7)
+		for i := 0; i < 100; i++ {
+			number := g.Int(3, 5)
+			require.GreaterOrEqual(t, number, 3)
+			require.Less(t, number, 5)
+		}
+		require.Len(t, g.Hex(64), 64)
+		require.Contains(t, []string{"a", "b"}, g.Pick([]string{"a", "b"}))
+	})
+}
@@ -0,0 +1,17 @@
+package random
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// NewSecret returns size bytes from a cryptographically secure source, hex encoded. Unlike
+// NewString it is safe to use for anything that grants access.
+func NewSecret(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
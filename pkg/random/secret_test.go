@@ -0,0 +1,19 @@
+package random
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomSecret(t *testing.T) {
+	t.Run("Test NewSecret", func(t *testing.T) {
+		secret, err := NewSecret(32)
+		require.NoError(t, err)
+		require.Len(t, secret, 64)
+
+		other, err := NewSecret(32)
+		require.NoError(t, err)
+		require.NotEqual(t, secret, other)
+	})
+}
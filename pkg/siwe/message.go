@@ -0,0 +1,199 @@
+package siwe
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidMessage     = errors.New("invalid sign-in message")
+	ErrDomainMismatch     = errors.New("sign-in message domain does not match")
+	ErrChainIDMismatch    = errors.New("sign-in message chain id does not match")
+	ErrMessageExpired     = errors.New("sign-in message has expired")
+	ErrMessageNotYetValid = errors.New("sign-in message is not valid yet")
+)
+
+const (
+	headerSuffix = " wants you to sign in with your Ethereum account:"
+	version      = "1"
+)
+
+var (
+	addressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	noncePattern   = regexp.MustCompile(`^[0-9a-zA-Z]{8,}$`)
+)
+
+// Message is a Sign-In with Ethereum (EIP-4361) message.
+type Message struct {
+	Domain         string
+	Address        string
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        int64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// NewNonce returns a random alphanumeric nonce suitable for a sign-in message.
+func NewNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// ParseMessage parses the text a wallet signed into a Message.
+func ParseMessage(raw string) (*Message, error) {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	if len(lines) < 3 || !strings.HasSuffix(lines[0], headerSuffix) {
+		return nil, ErrInvalidMessage
+	}
+
+	message := &Message{
+		Domain:  strings.TrimPrefix(strings.TrimPrefix(strings.TrimSuffix(lines[0], headerSuffix), "https://"), "http://"),
+		Address: lines[1],
+	}
+	if message.Domain == "" || !addressPattern.MatchString(message.Address) {
+		return nil, ErrInvalidMessage
+	}
+
+	i := 2
+	for i < len(lines) && lines[i] == "" {
+		i++
+	}
+	if i < len(lines) && !strings.HasPrefix(lines[i], "URI: ") {
+		message.Statement = lines[i]
+		i++
+	}
+
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if line == "" {
+			continue
+		}
+
+		if line == "Resources:" {
+			for i++; i < len(lines) && strings.HasPrefix(lines[i], "- "); i++ {
+				message.Resources = append(message.Resources, strings.TrimPrefix(lines[i], "- "))
+			}
+			break
+		}
+
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			return nil, ErrInvalidMessage
+		}
+
+		if err := message.setField(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if message.URI == "" || message.Version != version || message.ChainID == 0 ||
+		!noncePattern.MatchString(message.Nonce) || message.IssuedAt.IsZero() {
+		return nil, ErrInvalidMessage
+	}
+
+	return message, nil
+}
+
+func (m *Message) setField(key, value string) error {
+	var err error
+	switch key {
+	case "URI":
+		m.URI = value
+	case "Version":
+		m.Version = value
+	case "Chain ID":
+		m.ChainID, err = strconv.ParseInt(value, 10, 64)
+	case "Nonce":
+		m.Nonce = value
+	case "Issued At":
+		m.IssuedAt, err = time.Parse(time.RFC3339, value)
+	case "Expiration Time":
+		m.ExpirationTime, err = parseTime(value)
+	case "Not Before":
+		m.NotBefore, err = parseTime(value)
+	case "Request ID":
+		m.RequestID = value
+	default:
+		return ErrInvalidMessage
+	}
+
+	if err != nil {
+		return ErrInvalidMessage
+	}
+
+	return nil
+}
+
+// String formats the message as the text the wallet is asked to sign.
+func (m *Message) String() string {
+	var b strings.Builder
+	b.WriteString(m.Domain + headerSuffix + "\n")
+	b.WriteString(m.Address + "\n\n")
+	if m.Statement != "" {
+		b.WriteString(m.Statement + "\n\n")
+	}
+
+	b.WriteString("URI: " + m.URI + "\n")
+	b.WriteString("Version: " + m.Version + "\n")
+	b.WriteString("Chain ID: " + strconv.FormatInt(m.ChainID, 10) + "\n")
+	b.WriteString("Nonce: " + m.Nonce + "\n")
+	b.WriteString("Issued At: " + m.IssuedAt.UTC().Format(time.RFC3339))
+	if m.ExpirationTime != nil {
+		b.WriteString("\nExpiration Time: " + m.ExpirationTime.UTC().Format(time.RFC3339))
+	}
+	if m.NotBefore != nil {
+		b.WriteString("\nNot Before: " + m.NotBefore.UTC().Format(time.RFC3339))
+	}
+	if m.RequestID != "" {
+		b.WriteString("\nRequest ID: " + m.RequestID)
+	}
+	if len(m.Resources) > 0 {
+		b.WriteString("\nResources:")
+		for _, resource := range m.Resources {
+			b.WriteString("\n- " + resource)
+		}
+	}
+
+	return b.String()
+}
+
+// Validate checks that the message was issued for the given domain and is valid at the given time.
+func (m *Message) Validate(domain string, now time.Time) error {
+	if !strings.EqualFold(m.Domain, domain) {
+		return ErrDomainMismatch
+	}
+
+	if m.ExpirationTime != nil && !now.Before(*m.ExpirationTime) {
+		return ErrMessageExpired
+	}
+
+	if m.NotBefore != nil && now.Before(*m.NotBefore) {
+		return ErrMessageNotYetValid
+	}
+
+	return nil
+}
+
+func parseTime(value string) (*time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,79 @@
+package siwe
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testMessage = `example.com wants you to sign in with your Ethereum account:
+0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+
+Sign in to Fund-O
+
+URI: https://example.com/login
+Version: 1
+Chain ID: 1
+Nonce: 32891756abcdef12
+Issued At: 2024-05-01T10:00:00Z
+Expiration Time: 2024-05-01T10:10:00Z
+Resources:
+- https://example.com/terms`
+
+func TestParseMessage(t *testing.T) {
+	t.Run("Test valid message", func(t *testing.T) {
+		message, err := ParseMessage(testMessage)
+		require.NoError(t, err)
+		require.Equal(t, "example.com", message.Domain)
+		require.Equal(t, "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", message.Address)
+		require.Equal(t, "Sign in to Fund-O", message.Statement)
+		require.Equal(t, int64(1), message.ChainID)
+		require.Equal(t, "32891756abcdef12", message.Nonce)
+		require.NotNil(t, message.ExpirationTime)
+		require.Equal(t, []string{"https://example.com/terms"}, message.Resources)
+	})
+
+	t.Run("Test message without statement", func(t *testing.T) {
+		raw := strings.Replace(testMessage, "Sign in to Fund-O\n\n", "", 1)
+		message, err := ParseMessage(raw)
+		require.NoError(t, err)
+		require.Empty(t, message.Statement)
+	})
+
+	t.Run("Test invalid message", func(t *testing.T) {
+		for _, raw := range []string{
+			"",
+			strings.Replace(testMessage, "Version: 1", "Version: 2", 1),
+			strings.Replace(testMessage, "Nonce: 32891756abcdef12", "Nonce: short", 1),
+			strings.Replace(testMessage, "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "0x1234", 1),
+			strings.Replace(testMessage, "Issued At: 2024-05-01T10:00:00Z", "Issued At: yesterday", 1),
+		} {
+			_, err := ParseMessage(raw)
+			require.ErrorIs(t, err, ErrInvalidMessage)
+		}
+	})
+}
+
+func TestValidateMessage(t *testing.T) {
+	message, err := ParseMessage(testMessage)
+	require.NoError(t, err)
+
+	issuedAt := time.Date(2024, 5, 1, 10, 5, 0, 0, time.UTC)
+	require.NoError(t, message.Validate("example.com", issuedAt))
+	require.ErrorIs(t, message.Validate("evil.com", issuedAt), ErrDomainMismatch)
+	require.ErrorIs(t, message.Validate("example.com", issuedAt.Add(time.Hour)), ErrMessageExpired)
+}
+
+func TestNewNonce(t *testing.T) {
+	nonce, err := NewNonce()
+	require.NoError(t, err)
+	require.Regexp(t, noncePattern, nonce)
+}
+
+func TestMessageString(t *testing.T) {
+	message, err := ParseMessage(testMessage)
+	require.NoError(t, err)
+	require.Equal(t, testMessage, message.String())
+}
@@ -0,0 +1,108 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+// signingMethodEdDSA signs JWTs with Ed25519 (RFC 8037), which jwt-go does not support itself.
+type signingMethodEdDSA struct{}
+
+var SigningMethodEdDSA = &signingMethodEdDSA{}
+
+func init() {
+	jwt.RegisterSigningMethod(SigningMethodEdDSA.Alg(), func() jwt.SigningMethod {
+		return SigningMethodEdDSA
+	})
+}
+
+func (m *signingMethodEdDSA) Alg() string {
+	return "EdDSA"
+}
+
+func (m *signingMethodEdDSA) Sign(signingString string, key interface{}) (string, error) {
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return "", jwt.ErrInvalidKeyType
+	}
+
+	return jwt.EncodeSegment(ed25519.Sign(privateKey, []byte(signingString))), nil
+}
+
+func (m *signingMethodEdDSA) Verify(signingString, signature string, key interface{}) error {
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return jwt.ErrInvalidKeyType
+	}
+
+	sig, err := jwt.DecodeSegment(signature)
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(publicKey, []byte(signingString), sig) {
+		return jwt.ErrSignatureInvalid
+	}
+
+	return nil
+}
+
+// Ed25519JWTMaker signs JWTs with the signing key of a key set and names the key in the kid
+// header, so that services holding only the public keys can verify them.
+type Ed25519JWTMaker struct {
+	keys *KeySet
+}
+
+func NewEd25519JWTMaker(keys *KeySet) (Maker, error) {
+	if keys == nil {
+		return nil, errors.New("key set is required")
+	}
+
+	return &Ed25519JWTMaker{keys}, nil
+}
+
+func (maker *Ed25519JWTMaker) CreateToken(userID string, role string, tokenType Type, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(userID, role, tokenType, duration)
+	if err != nil {
+		return "", payload, err
+	}
+
+	jwtToken := jwt.NewWithClaims(SigningMethodEdDSA, payload)
+	jwtToken.Header["kid"] = maker.keys.signingKeyID
+	token, err := jwtToken.SignedString(maker.keys.signingKey)
+	return token, payload, err
+}
+
+func (maker *Ed25519JWTMaker) VerifyToken(token string) (*Payload, error) {
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		if token.Method != SigningMethodEdDSA {
+			return nil, ErrInvalidToken
+		}
+
+		id, _ := token.Header["kid"].(string)
+		key, ok := maker.keys.verificationKey(id)
+		if !ok {
+			return nil, ErrInvalidToken
+		}
+		return key, nil
+	}
+
+	jwtToken, err := jwt.ParseWithClaims(token, &Payload{}, keyFunc)
+	if err != nil {
+		verr, ok := err.(*jwt.ValidationError)
+		if ok && errors.Is(verr.Inner, ErrExpiredToken) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	payload, ok := jwtToken.Claims.(*Payload)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	return payload, nil
+}
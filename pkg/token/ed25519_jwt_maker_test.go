@@ -0,0 +1,144 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fund-o/api-server/pkg/random"
+	"strings"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEd25519JwtTokenMaker(t *testing.T) {
+	t.Run("Create and verify token", func(t *testing.T) {
+		maker, err := NewEd25519JWTMaker(randomKeySet(t, "key-1"))
+		require.NoError(t, err)
+
+		userID := uuid.NewString()
+		issuedAt := time.Now()
+		expiredAt := issuedAt.Add(time.Minute)
+
+		token, payload, err := maker.CreateToken(userID, "admin", TypeAccess, time.Minute)
+		require.NoError(t, err)
+		require.NotEmpty(t, token)
+		require.NotEmpty(t, payload)
+
+		parsed, _, err := new(jwt.Parser).ParseUnverified(token, &Payload{})
+		require.NoError(t, err)
+		require.Equal(t, "EdDSA", parsed.Header["alg"])
+		require.Equal(t, "key-1", parsed.Header["kid"])
+
+		payload, err = maker.VerifyToken(token)
+		require.NoError(t, err)
+		require.Equal(t, userID, payload.UserID)
+		require.Equal(t, "admin", payload.Role)
+		require.Equal(t, TypeAccess, payload.Type)
+		require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+		require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		maker, err := NewEd25519JWTMaker(randomKeySet(t, "key-1"))
+		require.NoError(t, err)
+
+		token, _, err := maker.CreateToken(uuid.NewString(), "user", TypeAccess, -time.Minute)
+		require.NoError(t, err)
+
+		payload, err := maker.VerifyToken(token)
+		require.EqualError(t, err, ErrExpiredToken.Error())
+		require.Nil(t, payload)
+	})
+
+	t.Run("Rotated key", func(t *testing.T) {
+		oldKeys := randomKeySet(t, "key-1")
+		oldMaker, err := NewEd25519JWTMaker(oldKeys)
+		require.NoError(t, err)
+
+		token, _, err := oldMaker.CreateToken(uuid.NewString(), "user", TypeAccess, time.Minute)
+		require.NoError(t, err)
+
+		// The new key signs, the old one is only accepted until its tokens have expired.
+		newKeys := randomKeySet(t, "key-2")
+		require.NoError(t, newKeys.AddVerificationKey("key-1", oldKeys.signingKey.Public().(ed25519.PublicKey)))
+		newMaker, err := NewEd25519JWTMaker(newKeys)
+		require.NoError(t, err)
+
+		_, err = newMaker.VerifyToken(token)
+		require.NoError(t, err)
+
+		// Without the old key the token is rejected.
+		otherMaker, err := NewEd25519JWTMaker(randomKeySet(t, "key-2"))
+		require.NoError(t, err)
+
+		_, err = otherMaker.VerifyToken(token)
+		require.EqualError(t, err, ErrInvalidToken.Error())
+	})
+
+	t.Run("Invalid signing methods", func(t *testing.T) {
+		maker, err := NewEd25519JWTMaker(randomKeySet(t, "key-1"))
+		require.NoError(t, err)
+
+		payload, err := NewPayload(uuid.NewString(), "user", TypeAccess, time.Minute)
+		require.NoError(t, err)
+
+		none := jwt.NewWithClaims(jwt.SigningMethodNone, payload)
+		none.Header["kid"] = "key-1"
+		noneToken, err := none.SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+
+		hmac := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+		hmac.Header["kid"] = "key-1"
+		hmacToken, err := hmac.SignedString([]byte(random.NewString(32)))
+		require.NoError(t, err)
+
+		for _, token := range []string{noneToken, hmacToken} {
+			_, err = maker.VerifyToken(token)
+			require.EqualError(t, err, ErrInvalidToken.Error())
+		}
+	})
+}
+
+func TestParseKeySet(t *testing.T) {
+	signingKey, err := GenerateSigningKey("key-2")
+	require.NoError(t, err)
+
+	oldKeys := randomKeySet(t, "key-1")
+	oldPublicKey := base64.StdEncoding.EncodeToString(oldKeys.signingKey.Public().(ed25519.PublicKey))
+
+	keys, err := ParseKeySet(signingKey, "key-1:"+oldPublicKey)
+	require.NoError(t, err)
+	require.Equal(t, "key-2", keys.signingKeyID)
+
+	jwks := keys.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	require.Equal(t, "key-1", jwks.Keys[0].KeyID)
+	require.Equal(t, "OKP", jwks.Keys[0].KeyType)
+	require.Equal(t, "Ed25519", jwks.Keys[0].Curve)
+	require.Equal(t, strings.TrimRight(base64.URLEncoding.EncodeToString(oldKeys.signingKey.Public().(ed25519.PublicKey)), "="), jwks.Keys[0].X)
+	require.Equal(t, "key-2", jwks.Keys[1].KeyID)
+
+	for _, tc := range []struct{ signingKey, verificationKeys string }{
+		{"", ""},
+		{"key-1", ""},
+		{"key-1:not-base64", ""},
+		{"key-1:" + base64.StdEncoding.EncodeToString([]byte("short")), ""},
+		{signingKey, "key-2:" + oldPublicKey},
+		{signingKey, "key-1:" + oldPublicKey + ",key-1:" + oldPublicKey},
+	} {
+		_, err := ParseKeySet(tc.signingKey, tc.verificationKeys)
+		require.Error(t, err)
+	}
+}
+
+func randomKeySet(t *testing.T, id string) *KeySet {
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	keys, err := NewKeySet(id, privateKey)
+	require.NoError(t, err)
+	return keys
+}
@@ -16,12 +16,13 @@ func TestJwtTokenMaker(t *testing.T) {
 		require.NoError(t, err)
 
 		userID := uuid.NewString()
+		role := "admin"
 		duration := time.Minute
 
 		issuedAt := time.Now()
 		expiredAt := issuedAt.Add(duration)
 
-		token, payload, err := maker.CreateToken(userID, duration)
+		token, payload, err := maker.CreateToken(userID, role, TypeAccess, duration)
 		require.NoError(t, err)
 		require.NotEmpty(t, token)
 		require.NotEmpty(t, payload)
@@ -32,6 +33,8 @@ func TestJwtTokenMaker(t *testing.T) {
 
 		require.NotZero(t, payload.ID)
 		require.Equal(t, userID, payload.UserID)
+		require.Equal(t, role, payload.Role)
+		require.Equal(t, TypeAccess, payload.Type)
 		require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
 		require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
 	})
@@ -42,7 +45,7 @@ func TestExpiredJwtToken(t *testing.T) {
 		maker, err := NewJWTMaker(random.NewString(32))
 		require.NoError(t, err)
 
-		token, payload, err := maker.CreateToken(uuid.NewString(), -time.Minute)
+		token, payload, err := maker.CreateToken(uuid.NewString(), "user", TypeAccess, -time.Minute)
 		require.NoError(t, err)
 		require.NotEmpty(t, token)
 		require.NotEmpty(t, payload)
@@ -56,7 +59,7 @@ func TestExpiredJwtToken(t *testing.T) {
 
 func TestInvalidJWTTokenAlgNone(t *testing.T) {
 	t.Run("Invalid JWT token alg none", func(t *testing.T) {
-		payload, err := NewPayload(uuid.NewString(), time.Minute)
+		payload, err := NewPayload(uuid.NewString(), "user", TypeAccess, time.Minute)
 		require.NoError(t, err)
 
 		jwtToken := jwt.NewWithClaims(jwt.SigningMethodNone, payload)
@@ -0,0 +1,156 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// KeySet holds the Ed25519 key new tokens are signed with and the public keys tokens are still
+// accepted from. Keys are rotated by signing with a new key while keeping the public key of the
+// previous one until the tokens it signed have expired.
+type KeySet struct {
+	signingKeyID     string
+	signingKey       ed25519.PrivateKey
+	verificationKeys map[string]ed25519.PublicKey
+}
+
+// JWK is the public part of an Ed25519 key as described in RFC 8037.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	X         string `json:"x"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func NewKeySet(signingKeyID string, signingKey ed25519.PrivateKey) (*KeySet, error) {
+	if signingKeyID == "" {
+		return nil, fmt.Errorf("signing key id is empty")
+	}
+
+	if len(signingKey) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid signing key size: must be %d bytes", ed25519.PrivateKeySize)
+	}
+
+	return &KeySet{
+		signingKeyID: signingKeyID,
+		signingKey:   signingKey,
+		verificationKeys: map[string]ed25519.PublicKey{
+			signingKeyID: signingKey.Public().(ed25519.PublicKey),
+		},
+	}, nil
+}
+
+// ParseKeySet creates a key set from configuration. The signing key is written as "kid:seed" and
+// the verification keys as a comma separated list of "kid:public key", with the seed and public
+// keys in standard base64.
+func ParseKeySet(signingKey string, verificationKeys string) (*KeySet, error) {
+	id, seed, err := parseKey(signingKey, ed25519.SeedSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key: %w", err)
+	}
+
+	keys, err := NewKeySet(id, ed25519.NewKeyFromSeed(seed))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range strings.Split(verificationKeys, ",") {
+		if strings.TrimSpace(key) == "" {
+			continue
+		}
+
+		id, publicKey, err := parseKey(key, ed25519.PublicKeySize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid verification key: %w", err)
+		}
+
+		if err := keys.AddVerificationKey(id, publicKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
+
+// GenerateSigningKey returns a new signing key written the way ParseKeySet expects it.
+func GenerateSigningKey(id string) (string, error) {
+	seed := make([]byte, ed25519.SeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return "", err
+	}
+
+	return id + ":" + base64.StdEncoding.EncodeToString(seed), nil
+}
+
+func (keys *KeySet) AddVerificationKey(id string, publicKey ed25519.PublicKey) error {
+	if id == "" {
+		return fmt.Errorf("verification key id is empty")
+	}
+
+	if len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid verification key size: must be %d bytes", ed25519.PublicKeySize)
+	}
+
+	if _, ok := keys.verificationKeys[id]; ok {
+		return fmt.Errorf("duplicate key id %q", id)
+	}
+
+	keys.verificationKeys[id] = publicKey
+	return nil
+}
+
+// JWKS returns the public keys tokens are accepted from, to be published for other services.
+func (keys *KeySet) JWKS() *JWKS {
+	ids := make([]string, 0, len(keys.verificationKeys))
+	for id := range keys.verificationKeys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	jwks := &JWKS{Keys: make([]JWK, 0, len(ids))}
+	for _, id := range ids {
+		jwks.Keys = append(jwks.Keys, JWK{
+			KeyType:   "OKP",
+			Curve:     "Ed25519",
+			KeyID:     id,
+			Use:       "sig",
+			Algorithm: "EdDSA",
+			X:         base64.RawURLEncoding.EncodeToString(keys.verificationKeys[id]),
+		})
+	}
+
+	return jwks
+}
+
+func (keys *KeySet) verificationKey(id string) (ed25519.PublicKey, bool) {
+	key, ok := keys.verificationKeys[id]
+	return key, ok
+}
+
+func parseKey(key string, size int) (string, []byte, error) {
+	id, encoded, ok := strings.Cut(strings.TrimSpace(key), ":")
+	if !ok || id == "" {
+		return "", nil, fmt.Errorf("must be written as kid:key")
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, fmt.Errorf("key %q is not base64: %w", id, err)
+	}
+
+	if len(raw) != size {
+		return "", nil, fmt.Errorf("key %q must be %d bytes", id, size)
+	}
+
+	return id, raw, nil
+}
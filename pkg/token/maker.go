@@ -5,6 +5,6 @@ import (
 )
 
 type Maker interface {
-	CreateToken(userID string, duration time.Duration) (string, *Payload, error)
+	CreateToken(userID string, role string, tokenType Type, duration time.Duration) (string, *Payload, error)
 	VerifyToken(token string) (*Payload, error)
 }
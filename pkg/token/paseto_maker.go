@@ -0,0 +1,131 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const pasetoV4PublicHeader = "v4.public."
+
+// PasetoMaker creates PASETO v4.public tokens. They are signed with Ed25519 like the tokens of
+// Ed25519JWTMaker and name the signing key in the footer, so the same key set and JWKS serve both.
+type PasetoMaker struct {
+	keys *KeySet
+}
+
+type pasetoFooter struct {
+	KeyID string `json:"kid"`
+}
+
+func NewPasetoMaker(keys *KeySet) (Maker, error) {
+	if keys == nil {
+		return nil, errors.New("key set is required")
+	}
+
+	return &PasetoMaker{keys}, nil
+}
+
+func (maker *PasetoMaker) CreateToken(userID string, role string, tokenType Type, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(userID, role, tokenType, duration)
+	if err != nil {
+		return "", payload, err
+	}
+
+	message, err := json.Marshal(payload)
+	if err != nil {
+		return "", payload, err
+	}
+
+	footer, err := json.Marshal(&pasetoFooter{KeyID: maker.keys.signingKeyID})
+	if err != nil {
+		return "", payload, err
+	}
+
+	return signV4Public(maker.keys.signingKey, message, footer), payload, nil
+}
+
+func (maker *PasetoMaker) VerifyToken(token string) (*Payload, error) {
+	encodedFooter := ""
+	if parts := strings.Split(token, "."); len(parts) == 4 {
+		encodedFooter = parts[3]
+	}
+
+	rawFooter, err := base64.RawURLEncoding.DecodeString(encodedFooter)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var footer pasetoFooter
+	if err := json.Unmarshal(rawFooter, &footer); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	key, ok := maker.keys.verificationKey(footer.KeyID)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	message, err := verifyV4Public(key, token, rawFooter)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(message, &payload); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if err := payload.Valid(); err != nil {
+		return nil, err
+	}
+
+	return &payload, nil
+}
+
+func signV4Public(key ed25519.PrivateKey, message, footer []byte) string {
+	signature := ed25519.Sign(key, preAuthEncode([]byte(pasetoV4PublicHeader), message, footer, nil))
+
+	token := pasetoV4PublicHeader + base64.RawURLEncoding.EncodeToString(append(message, signature...))
+	if len(footer) > 0 {
+		token += "." + base64.RawURLEncoding.EncodeToString(footer)
+	}
+
+	return token
+}
+
+func verifyV4Public(key ed25519.PublicKey, token string, footer []byte) ([]byte, error) {
+	if !strings.HasPrefix(token, pasetoV4PublicHeader) {
+		return nil, ErrInvalidToken
+	}
+
+	body, _, _ := strings.Cut(strings.TrimPrefix(token, pasetoV4PublicHeader), ".")
+	raw, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil || len(raw) < ed25519.SignatureSize {
+		return nil, ErrInvalidToken
+	}
+
+	message := raw[:len(raw)-ed25519.SignatureSize]
+	signature := raw[len(raw)-ed25519.SignatureSize:]
+	if !ed25519.Verify(key, preAuthEncode([]byte(pasetoV4PublicHeader), message, footer, nil), signature) {
+		return nil, ErrInvalidToken
+	}
+
+	return message, nil
+}
+
+// preAuthEncode is the PAE function of the PASETO specification, which encodes the pieces of a
+// token unambiguously before they are signed.
+func preAuthEncode(pieces ...[]byte) []byte {
+	encoded := binary.LittleEndian.AppendUint64(nil, uint64(len(pieces)))
+	for _, piece := range pieces {
+		encoded = binary.LittleEndian.AppendUint64(encoded, uint64(len(piece))&^(1<<63))
+		encoded = append(encoded, piece...)
+	}
+
+	return encoded
+}
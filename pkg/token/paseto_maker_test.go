@@ -0,0 +1,92 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasetoTokenMaker(t *testing.T) {
+	t.Run("Create and verify token", func(t *testing.T) {
+		maker, err := NewPasetoMaker(randomKeySet(t, "key-1"))
+		require.NoError(t, err)
+
+		userID := uuid.NewString()
+		issuedAt := time.Now()
+		expiredAt := issuedAt.Add(time.Minute)
+
+		token, payload, err := maker.CreateToken(userID, "admin", TypeAccess, time.Minute)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(token, "v4.public."))
+		require.NotEmpty(t, payload)
+
+		payload, err = maker.VerifyToken(token)
+		require.NoError(t, err)
+		require.Equal(t, userID, payload.UserID)
+		require.Equal(t, "admin", payload.Role)
+		require.Equal(t, TypeAccess, payload.Type)
+		require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+		require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		maker, err := NewPasetoMaker(randomKeySet(t, "key-1"))
+		require.NoError(t, err)
+
+		token, _, err := maker.CreateToken(uuid.NewString(), "user", TypeAccess, -time.Minute)
+		require.NoError(t, err)
+
+		payload, err := maker.VerifyToken(token)
+		require.EqualError(t, err, ErrExpiredToken.Error())
+		require.Nil(t, payload)
+	})
+
+	t.Run("Tampered token", func(t *testing.T) {
+		keys := randomKeySet(t, "key-1")
+		maker, err := NewPasetoMaker(keys)
+		require.NoError(t, err)
+
+		token, _, err := maker.CreateToken(uuid.NewString(), "user", TypeAccess, time.Minute)
+		require.NoError(t, err)
+
+		// Signed with another key under the same key id.
+		otherMaker, err := NewPasetoMaker(randomKeySet(t, "key-1"))
+		require.NoError(t, err)
+
+		forged, _, err := otherMaker.CreateToken(uuid.NewString(), "admin", TypeAccess, time.Minute)
+		require.NoError(t, err)
+
+		for _, invalid := range []string{
+			"v3.public." + strings.TrimPrefix(token, "v4.public."),
+			token[:len(token)-1] + "A",
+			forged,
+			"v4.public.AAAA",
+		} {
+			_, err = maker.VerifyToken(invalid)
+			require.EqualError(t, err, ErrInvalidToken.Error())
+		}
+	})
+}
+
+// TestPasetoV4PublicVector checks the signing against test vector 4-S-1 of the PASETO specification.
+func TestPasetoV4PublicVector(t *testing.T) {
+	secretKey, err := hex.DecodeString("b4cbfb43df4ce210727d953e4a713307fa19bb7d9f85041438d9e11b942a3774" +
+		"1eb9dbbbbc047c03fd70604e0071f0987e16b28b757225c11f00415d0e20b1a2")
+	require.NoError(t, err)
+
+	message := `{"data":"this is a signed message","exp":"2022-01-01T00:00:00+00:00"}`
+	expected := "v4.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAyMi0wMS0wMVQwMDowMDowMCswMDowMCJ9" +
+		"bg_XBBzds8lTZShVlwwKSgeKpLT3yukTw6JUz3W4h_ExsQV-P0V54zemZDcAxFaSeef1QlXEFtkqxT1ciiQEDA"
+
+	token := signV4Public(ed25519.PrivateKey(secretKey), []byte(message), nil)
+	require.Equal(t, expected, token)
+
+	verified, err := verifyV4Public(ed25519.PrivateKey(secretKey).Public().(ed25519.PublicKey), token, nil)
+	require.NoError(t, err)
+	require.Equal(t, message, string(verified))
+}
@@ -10,16 +10,31 @@ import (
 var (
 	ErrInvalidToken = errors.New("token is invalid")
 	ErrExpiredToken = errors.New("token is expired")
+	ErrWrongType    = errors.New("token is of the wrong type")
+)
+
+// Type tells the tokens issued at sign in apart, so that a refresh token cannot be used in place
+// of an access token.
+type Type string
+
+const (
+	TypeAccess  Type = "access"
+	TypeRefresh Type = "refresh"
 )
 
 type Payload struct {
 	ID        uuid.UUID `json:"id"`
 	UserID    string    `json:"user_id"`
+	Role      string    `json:"role"`
+	Type      Type      `json:"type,omitempty"`
 	IssuedAt  time.Time `json:"issued_at"`
 	ExpiredAt time.Time `json:"expired_at"`
+	// Scopes limit what the bearer may do. Only payloads of API keys have scopes; tokens issued
+	// at sign in have none and may do everything the user may.
+	Scopes []string `json:"scopes,omitempty"`
 }
 
-func NewPayload(userId string, duration time.Duration) (*Payload, error) {
+func NewPayload(userId string, role string, tokenType Type, duration time.Duration) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
@@ -28,6 +43,8 @@ func NewPayload(userId string, duration time.Duration) (*Payload, error) {
 	payload := &Payload{
 		ID:        tokenID,
 		UserID:    userId,
+		Role:      role,
+		Type:      tokenType,
 		IssuedAt:  time.Now(),
 		ExpiredAt: time.Now().Add(duration),
 	}
@@ -40,3 +57,25 @@ func (payload *Payload) Valid() error {
 	}
 	return nil
 }
+
+// CheckType returns ErrWrongType unless the token is of the given type.
+func (payload *Payload) CheckType(tokenType Type) error {
+	if payload.Type != tokenType {
+		return ErrWrongType
+	}
+	return nil
+}
+
+// HasScope reports whether the payload allows the scope.
+func (payload *Payload) HasScope(scope string) bool {
+	if len(payload.Scopes) == 0 {
+		return true
+	}
+
+	for _, s := range payload.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
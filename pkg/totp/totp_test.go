@@ -0,0 +1,76 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 key of the RFC 6238 test vectors, "12345678901234567890", base32 encoded.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	testCases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tc := range testCases {
+		code, err := Code(rfcSecret, Step(time.Unix(tc.unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, tc.code, code)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	t.Run("Test current code", func(t *testing.T) {
+		step, ok := Validate(rfcSecret, "050471", now)
+		require.True(t, ok)
+		require.Equal(t, Step(now), step)
+	})
+
+	t.Run("Test code of the previous period", func(t *testing.T) {
+		step, ok := Validate(rfcSecret, "050471", now.Add(Period*time.Second))
+		require.True(t, ok)
+		require.Equal(t, Step(now), step)
+	})
+
+	t.Run("Test expired code", func(t *testing.T) {
+		_, ok := Validate(rfcSecret, "050471", now.Add(3*Period*time.Second))
+		require.False(t, ok)
+	})
+
+	t.Run("Test malformed code", func(t *testing.T) {
+		_, ok := Validate(rfcSecret, "12345", now)
+		require.False(t, ok)
+	})
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, 32)
+
+	_, err = Code(secret, 1)
+	require.NoError(t, err)
+}
+
+func TestURI(t *testing.T) {
+	uri, err := url.Parse(URI("FundO", "someone@gmail.com", rfcSecret))
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", uri.Scheme)
+	require.Equal(t, "totp", uri.Host)
+	require.Equal(t, "/FundO:someone@gmail.com", uri.Path)
+	require.Equal(t, rfcSecret, uri.Query().Get("secret"))
+	require.Equal(t, "FundO", uri.Query().Get("issuer"))
+}
@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// payloadKey is the field of a task payload that carries the trace context.
+const payloadKey = "trace_context"
+
+// InjectPayload adds the trace context of ctx to a JSON object payload, so that the task
+// continues the trace of the request that enqueued it. The payload is returned unchanged when
+// ctx carries no trace.
+func InjectPayload(ctx context.Context, payload []byte) ([]byte, error) {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return payload, nil
+	}
+
+	fields := map[string]json.RawMessage{}
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &fields); err != nil {
+			return nil, err
+		}
+	}
+
+	traceContext, err := json.Marshal(carrier)
+	if err != nil {
+		return nil, err
+	}
+	fields[payloadKey] = traceContext
+
+	return json.Marshal(fields)
+}
+
+// ExtractPayload returns ctx with the trace context carried by the payload, if any.
+func ExtractPayload(ctx context.Context, payload []byte) context.Context {
+	var fields struct {
+		TraceContext propagation.MapCarrier `json:"trace_context"`
+	}
+	if err := json.Unmarshal(payload, &fields); err != nil || len(fields.TraceContext) == 0 {
+		return ctx
+	}
+
+	return otel.GetTextMapPropagator().Extract(ctx, fields.TraceContext)
+}
@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestPayload(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x0a, 0xf7, 0x65, 0x19, 0x16, 0xcd, 0x43, 0xdd, 0x84, 0x48, 0xeb, 0x21, 0x1c, 0x80, 0x31, 0x9c},
+		SpanID:     trace.SpanID{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+	t.Run("Test round trip", func(t *testing.T) {
+		payload, err := InjectPayload(ctx, []byte(`{"backer_id":"42"}`))
+		require.NoError(t, err)
+
+		var fields struct {
+			BackerID string `json:"backer_id"`
+		}
+		require.NoError(t, json.Unmarshal(payload, &fields))
+		require.Equal(t, "42", fields.BackerID)
+
+		extracted := trace.SpanContextFromContext(ExtractPayload(context.Background(), payload))
+		require.Equal(t, spanContext.TraceID(), extracted.TraceID())
+		require.Equal(t, spanContext.SpanID(), extracted.SpanID())
+		require.True(t, extracted.IsRemote())
+	})
+
+	t.Run("Test empty payload", func(t *testing.T) {
+		payload, err := InjectPayload(ctx, nil)
+		require.NoError(t, err)
+
+		extracted := trace.SpanContextFromContext(ExtractPayload(context.Background(), payload))
+		require.Equal(t, spanContext.TraceID(), extracted.TraceID())
+	})
+
+	t.Run("Test without trace", func(t *testing.T) {
+		payload, err := InjectPayload(context.Background(), []byte(`{"email":"a@b.c"}`))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"email":"a@b.c"}`, string(payload))
+
+		extracted := trace.SpanContextFromContext(ExtractPayload(context.Background(), payload))
+		require.False(t, extracted.IsValid())
+	})
+
+	t.Run("Test invalid payload", func(t *testing.T) {
+		_, err := InjectPayload(ctx, []byte(`[1, 2]`))
+		require.Error(t, err)
+
+		require.Equal(t, context.Background(), ExtractPayload(context.Background(), []byte("garbage")))
+	})
+}
@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "fund-o/api-server"
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOtlp   = "otlp"
+)
+
+type Config struct {
+	ServiceName string
+	// Exporter is where spans are sent: "otlp" to a collector, "stdout" for local use, or "none"
+	// to only propagate the trace context.
+	Exporter string
+	// OtlpEndpoint is the host:port of the collector receiving OTLP over HTTP.
+	OtlpEndpoint string
+	OtlpInsecure bool
+	// SampleRatio is the fraction of new traces recorded. Requests that are part of a sampled
+	// trace are always recorded.
+	SampleRatio float64
+}
+
+// Shutdown exports the spans still buffered and stops the exporter.
+type Shutdown func(ctx context.Context) error
+
+// Init installs the global tracer provider and the W3C trace context propagator. Spans are
+// created even with the "none" exporter so that trace IDs still reach the log lines.
+func Init(ctx context.Context, config *Config) (Shutdown, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(config.ServiceName))),
+	}
+
+	switch config.Exporter {
+	case ExporterNone, "":
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	case ExporterOtlp:
+		clientOptions := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.OtlpEndpoint)}
+		if config.OtlpInsecure {
+			clientOptions = append(clientOptions, otlptracehttp.WithInsecure())
+		}
+
+		exporter, err := otlptracehttp.New(ctx, clientOptions...)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", config.Exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the service from the global provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
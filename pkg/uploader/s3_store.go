@@ -6,6 +6,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
@@ -86,3 +87,10 @@ func (s *s3Store) Upload(folder string, file *multipart.FileHeader) (string, err
 
 	return result.Location, nil
 }
+
+func (s *s3Store) Ping(ctx context.Context) error {
+	_, err := s.uploader.S3.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(s.bucket),
+	})
+	return err
+}
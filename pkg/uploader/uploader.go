@@ -1,9 +1,12 @@
 package uploader
 
 import (
+	"context"
 	"mime/multipart"
 )
 
 type ImageUploader interface {
 	Upload(folder string, file *multipart.FileHeader) (string, error)
+	// Ping checks that the storage behind the uploader is reachable.
+	Ping(ctx context.Context) error
 }